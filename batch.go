@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"strcli/pkg/compare"
+	"strcli/pkg/diff"
+	"strcli/pkg/render"
+)
+
+func newBatchCmd() *cobra.Command {
+	var showDiff, quiet bool
+	var format string
+	var of optionFlags
+	cmd := &cobra.Command{
+		Use:   "batch MANIFEST",
+		Short: "Compare every pair of files listed in a manifest",
+		Long: `Compare the pairs of files listed in MANIFEST and report which differ.
+
+A manifest ending in .json holds a list of objects:
+
+  [{"name": "greeting", "a": "golden/hello.txt", "b": "out/hello.txt"}]
+
+Any other manifest is read as CSV with the columns a, b and an optional name;
+a first row of "a,b" is taken as a header. Relative paths are resolved
+against the manifest's directory.
+
+The exit status is 0 if all pairs are identical, 1 if any differ and 2 if any
+could not be compared.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, ok := render.Lookup(format, diffStyles)
+			if !ok {
+				return fmt.Errorf("unknown format %q", format)
+			}
+			opts, err := of.options()
+			if err != nil {
+				return err
+			}
+			pairs, err := readManifest(args[0])
+			if err != nil {
+				return err
+			}
+			w := cmd.OutOrStdout()
+			var same, differ, failed int
+			for _, pair := range pairs {
+				d, err := pair.compare(opts)
+				switch {
+				case err != nil:
+					failed++
+					if !quiet {
+						fmt.Fprintf(w, "error\t%s\t%v\n", pair.label(), err)
+					}
+				case d.Equal():
+					same++
+					if !quiet {
+						fmt.Fprintf(w, "same\t%s\n", pair.label())
+					}
+				default:
+					differ++
+					if !quiet {
+						fmt.Fprintf(w, "differ\t%s\n", pair.label())
+						if showDiff {
+							out, err := r(d)
+							if err != nil {
+								return err
+							}
+							fmt.Fprint(w, out)
+						}
+					}
+				}
+			}
+			if !quiet {
+				fmt.Fprintf(w, "%d pairs: %d same, %d differ, %d failed\n", len(pairs), same, differ, failed)
+			}
+			switch {
+			case failed > 0:
+				return fmt.Errorf("%d of %d pairs could not be compared", failed, len(pairs))
+			case differ > 0:
+				return errDiffer
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&showDiff, "diff", "d", false, "print the diff of every pair that differs")
+	cmd.Flags().StringVar(&format, "format", "plain", "format of the diffs printed with --diff: "+strings.Join(render.Names(), ", "))
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "print nothing; only set the exit status")
+	of.register(cmd, "both files of every pair")
+	cmd.RegisterFlagCompletionFunc("format", completeFormats)
+	return cmd
+}
+
+// filePair is a manifest entry.
+type filePair struct {
+	Name string `json:"name"`
+	A    string `json:"a"`
+	B    string `json:"b"`
+}
+
+func (p filePair) label() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.A + "\t" + p.B
+}
+
+func (p filePair) compare(opts compare.Options) (diff.Diff, error) {
+	a, err := os.ReadFile(p.A)
+	if err != nil {
+		return diff.Diff{}, err
+	}
+	b, err := os.ReadFile(p.B)
+	if err != nil {
+		return diff.Diff{}, err
+	}
+	return compare.Compare(string(a), string(b), opts).Diff, nil
+}
+
+// readManifest reads the file pairs listed in the manifest at path.
+func readManifest(path string) ([]filePair, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var pairs []filePair
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.NewDecoder(f).Decode(&pairs); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	} else if pairs, err = readCSVManifest(f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	for i, p := range pairs {
+		if p.A == "" || p.B == "" {
+			return nil, fmt.Errorf("%s: entry %d needs both a and b", path, i+1)
+		}
+		if !filepath.IsAbs(p.A) {
+			pairs[i].A = filepath.Join(dir, p.A)
+		}
+		if !filepath.IsAbs(p.B) {
+			pairs[i].B = filepath.Join(dir, p.B)
+		}
+	}
+	return pairs, nil
+}
+
+func readCSVManifest(r io.Reader) ([]filePair, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+	var pairs []filePair
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return pairs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rec) < 2 || len(rec) > 3 {
+			line, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("line %d: want a,b[,name]", line)
+		}
+		if len(pairs) == 0 && rec[0] == "a" && rec[1] == "b" {
+			continue
+		}
+		p := filePair{A: rec[0], B: rec[1]}
+		if len(rec) == 3 {
+			p.Name = rec[2]
+		}
+		pairs = append(pairs, p)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadManifest(t *testing.T) {
+	dir := t.TempDir()
+	abs := filepath.Join(dir, "abs.txt")
+	tests := []struct {
+		name, file, manifest string
+		want                 []filePair
+		wantErr              string
+	}{
+		{"json", "m.json", `[{"name": "n", "a": "x", "b": "` + abs + `"}]`,
+			[]filePair{{Name: "n", A: filepath.Join(dir, "x"), B: abs}}, ""},
+		{"csv with header", "m.csv", "a,b,name\nx, y, n\n# comment\nz,w\n",
+			[]filePair{{Name: "n", A: filepath.Join(dir, "x"), B: filepath.Join(dir, "y")}, {A: filepath.Join(dir, "z"), B: filepath.Join(dir, "w")}}, ""},
+		{"csv without header", "m.txt", "x,y\n", []filePair{{A: filepath.Join(dir, "x"), B: filepath.Join(dir, "y")}}, ""},
+		{"csv row too short", "m.csv", "x,y\nz\n", nil, "line 2: want a,b[,name]"},
+		{"json entry missing b", "m.json", `[{"a": "x"}]`, nil, "entry 1 needs both a and b"},
+		{"invalid json", "m.json", `{`, nil, "unexpected EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			os.WriteFile(path, []byte(tt.manifest), 0o644)
+			got, err := readManifest(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchCommand(t *testing.T) {
+	dir := t.TempDir()
+	for name, text := range map[string]string{"x": "one\n", "y": "one\n", "z": "six\n"} {
+		os.WriteFile(filepath.Join(dir, name), []byte(text), 0o644)
+	}
+	write := func(name, manifest string) string {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(manifest), 0o644)
+		return path
+	}
+	same := write("same.csv", "x,y,xy\n")
+	mixed := write("mixed.csv", "x,y,xy\nx,z,xz\n")
+	failing := write("failing.csv", "x,z,xz\nx,missing,xm\n")
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     string
+		wantCode int
+	}{
+		{"all same", []string{same}, "same\txy\n1 pairs: 1 same, 0 differ, 0 failed\n", exitSame},
+		{"some differ", []string{mixed}, "same\txy\ndiffer\txz\n2 pairs: 1 same, 1 differ, 0 failed\n", exitDiffer},
+		{"with diffs", []string{"--diff", "--format", "porcelain", mixed}, "differ\txz\nhunk\tmodified\t1,1\t1,1\n", exitDiffer},
+		{"quiet", []string{"-q", mixed}, "", exitDiffer},
+		{"failure wins", []string{failing}, "2 pairs: 0 same, 1 differ, 1 failed\n", exitError},
+		{"no manifest", []string{filepath.Join(dir, "missing.csv")}, "", exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, code := runMain(t, "", append([]string{"batch"}, tt.args...)...)
+			if code != tt.wantCode {
+				t.Errorf("exit status = %d, want %d", code, tt.wantCode)
+			}
+			if !strings.Contains(out, tt.want) || tt.want == "" && out != "" {
+				t.Errorf("printed\n%s\nwant %q", out, tt.want)
+			}
+		})
+	}
+}
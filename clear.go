@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/diff"
+)
+
+// confirmClearPane asks before clearing the focused input pane.
+func (m *model) confirmClearPane() overlay {
+	if m.focus > 1 {
+		m.err = errNoInputPane
+		return nil
+	}
+	i := m.focus
+	return &confirm{
+		title: fmt.Sprintf("Clear pane %c?", 'A'+i),
+		text:  fmt.Sprintf("Its %d characters can be brought back with undo.", len([]rune(m.inputs[i].Value()))),
+		yes: func(m *model) tea.Cmd {
+			path := m.paths[i]
+			m.clearPane(i)
+			m.notice = fmt.Sprintf("cleared pane %c", 'A'+i)
+			if m.watch != nil && path != "" {
+				m.notice += "; no longer reloading " + path
+			}
+			return nil
+		},
+	}
+}
+
+// confirmClearAll asks before clearing the input panes and the result.
+func (m *model) confirmClearAll() overlay {
+	return &confirm{
+		title: "Clear all panes?",
+		text:  "Undo brings back the text of each input pane; the result is gone.",
+		yes: func(m *model) tea.Cmd {
+			for i := range m.inputs[:len(m.inputs)-1] {
+				m.clearPane(i)
+			}
+			// Drop any comparison in flight along with the result.
+			m.gen++
+			m.resultLog = nil
+			m.diff = diff.Diff{}
+			m.setResult("")
+			m.notice = "cleared all panes"
+			return nil
+		},
+	}
+}
+
+// clearPane empties input pane i, which forgets the file it was loaded
+// from. In watch mode the pane is therefore no longer reloaded when the
+// file changes, rather than reloaded with nothing to read.
+func (m *model) clearPane(i int) {
+	m.replacePane(i, "")
+	m.paths[i] = ""
+	m.paneGen[i]++
+}
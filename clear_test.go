@@ -0,0 +1,107 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	clearPaneKey = tea.KeyMsg{Type: tea.KeyCtrlL}
+	clearAllKey  = tea.KeyMsg{Type: tea.KeyCtrlL, Alt: true}
+	yesKey       = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}}
+)
+
+func TestClearPane(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer tea.KeyMsg
+		want   [2]string
+	}{
+		{"yes", yesKey, [2]string{"", "b"}},
+		{"enter", tea.KeyMsg{Type: tea.KeyEnter}, [2]string{"", "b"}},
+		{"no", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}}, [2]string{"a", "b"}},
+		{"esc", tea.KeyMsg{Type: tea.KeyEsc}, [2]string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.setInputs([]string{"a", "b"}, []string{"a.txt", "b.txt"})
+			m, _ = update(m, clearPaneKey)
+			if _, ok := m.overlay.(*confirm); !ok {
+				t.Fatalf("overlay = %T, want a confirmation", m.overlay)
+			}
+			m, _ = update(m, tt.answer)
+			if m.overlay != nil {
+				t.Error("the confirmation is still open")
+			}
+			if got := [2]string{m.inputs[0].Value(), m.inputs[1].Value()}; got != tt.want {
+				t.Errorf("panes %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClearPaneUndo(t *testing.T) {
+	m := newModel()
+	m.setInputs([]string{"a", "b"}, []string{"a.txt", "b.txt"})
+	m, _ = update(m, clearPaneKey)
+	m, _ = update(m, yesKey)
+	if m.paths[0] != "" || m.notice != "cleared pane A" {
+		t.Errorf("path %q, notice %q", m.paths[0], m.notice)
+	}
+	m, _ = update(m, undoKey)
+	if got := m.inputs[0].Value(); got != "a" {
+		t.Errorf("undo brings back %q", got)
+	}
+}
+
+func TestClearAll(t *testing.T) {
+	m := newModel()
+	m.setInputs([]string{"a", "b"}, nil)
+	m = compareNow(m)
+	if m.result == "" {
+		t.Fatal("no result to clear")
+	}
+	m, _ = update(m, clearAllKey)
+	m, _ = update(m, yesKey)
+	if m.inputs[0].Value() != "" || m.inputs[1].Value() != "" || m.result != "" || len(m.diff.Changes) != 0 {
+		t.Errorf("panes %q and %q, result %q", m.inputs[0].Value(), m.inputs[1].Value(), m.result)
+	}
+	if m.notice != "cleared all panes" {
+		t.Errorf("notice %q", m.notice)
+	}
+}
+
+func TestClearResultPane(t *testing.T) {
+	m := newModel()
+	m.focus = 2
+	m, _ = update(m, clearPaneKey)
+	if m.overlay != nil || m.err != errNoInputPane {
+		t.Errorf("clearing the result pane: overlay %T, %v", m.overlay, m.err)
+	}
+}
+
+func TestClearWatchedPane(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	os.WriteFile(a, []byte("a"), 0o644)
+	os.WriteFile(b, []byte("b"), 0o644)
+
+	m := newModel()
+	m.setInputs([]string{"a", "b"}, []string{a, b})
+	m.watch = &watcher{paths: []string{a, b}, changed: make(chan string), close: func() error { return nil }}
+	m, _ = update(m, clearPaneKey)
+	m, _ = update(m, yesKey)
+	if want := "cleared pane A; no longer reloading " + a; m.notice != want {
+		t.Errorf("notice %q, want %q", m.notice, want)
+	}
+
+	os.WriteFile(a, []byte("a changed"), 0o644)
+	m = settle(m, fileChangedMsg{path: a})
+	if got := m.inputs[0].Value(); got != "" {
+		t.Errorf("the cleared pane was reloaded: %q", got)
+	}
+}
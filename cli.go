@@ -0,0 +1,597 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"strcli/pkg/compare"
+	"strcli/pkg/diff"
+	"strcli/pkg/hash"
+	"strcli/pkg/id"
+	"strcli/pkg/render"
+	"strcli/pkg/theme"
+	"strcli/pkg/transform"
+)
+
+// Exit codes of the non-interactive mode, mirroring diff(1).
+const (
+	exitSame   = 0
+	exitDiffer = 1
+	exitError  = 2
+)
+
+// errDiffer is returned by commands whose inputs differ. It is reported
+// through the exit status only.
+var errDiffer = errors.New("inputs differ")
+
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "strcli [A [B]]",
+		Short: "Compare and transform strings",
+		Long: `strcli compares two texts side by side in an interactive view.
+
+When standard input is not a terminal and no files are given, the first pane
+is read from it. When standard output is not a terminal, the diff is written
+instead, as by compare, and the exit status is 0 if the inputs are identical,
+1 if they differ and 2 on error.`,
+		Args:          cobra.MaximumNArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			path, err := cmd.Flags().GetString("config")
+			if err != nil {
+				return err
+			}
+			if settings, err = loadConfig(path); err != nil {
+				return err
+			}
+			if err := settings.applyFlags(cmd); err != nil {
+				return err
+			}
+			name, err := cmd.Flags().GetString("theme")
+			if err != nil {
+				return err
+			}
+			t, ok := theme.Lookup(name)
+			if !ok {
+				return fmt.Errorf("unknown theme %q (have %s)", name, strings.Join(theme.Names(), ", "))
+			}
+			noColor, err := cmd.Flags().GetBool("no-color")
+			if err != nil {
+				return err
+			}
+			// NO_COLOR gives way to a theme chosen by flag or config;
+			// see https://no-color.org.
+			if noColor || os.Getenv("NO_COLOR") != "" && !cmd.Flags().Changed("theme") {
+				t = theme.Monochrome
+			}
+			applyTheme(t)
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			texts, err := readInputs(args)
+			if err != nil {
+				return err
+			}
+			if !isTerminal(os.Stdout) {
+				of, err := settings.optionFlags()
+				if err != nil {
+					return err
+				}
+				return compareTexts(cmd, texts, args, compareFlags{optionFlags: of, format: "plain"})
+			}
+			m := newModel()
+			m.setInputs(texts, args)
+			return runTUI(cmd, m)
+		},
+	}
+	root.PersistentFlags().Bool("title", false, "show the files and whether they differ in the terminal window title")
+	root.PersistentFlags().String("record", "", "record the session to `file` as an asciinema cast")
+	root.PersistentFlags().Bool("vim", false, "edit the panes with vim keys: hjkl, w/b, gg/G, i, v, dd and more")
+	root.PersistentFlags().Duration("lock-after", 0, "hide the panes after no key was pressed for `duration`, e.g. 5m")
+	root.PersistentFlags().String("config", "", "read defaults from `file` instead of "+filepath.Join("~/.config/strcli", configName))
+	root.PersistentFlags().String("theme", theme.Default.Name, "use the color theme `name`: "+strings.Join(theme.Names(), ", "))
+	root.RegisterFlagCompletionFunc("theme", completeThemes)
+	root.PersistentFlags().Bool("no-color", false, "use no colors: changes are marked with +/- and underlined or struck through instead; also set by NO_COLOR")
+	root.CompletionOptions.DisableDefaultCmd = true
+	root.AddCommand(
+		newCompletionCmd(),
+		newCompareCmd(),
+		newTransformCmd(),
+		newHashCmd(),
+		newFmtCmd(),
+		newHistoryCmd(),
+		newWatchCmd(),
+		newBatchCmd(),
+		newRoundTripCmd(),
+		newMatchCmd(),
+		newLoremCmd(),
+		newIDCmd(),
+	)
+	return root
+}
+
+func newCompareCmd() *cobra.Command {
+	var of compareFlags
+	var large bool
+	cmd := &cobra.Command{
+		Use:   "compare [A [B]]",
+		Short: "Compare two files",
+		Long: `Compare files A and B. "-" reads standard input.
+
+When standard output is not a terminal, an output file is given or --quiet or
+--porcelain is set, the diff is written instead of starting the interactive
+view, and the exit status is 0 if the inputs are identical, 1 if they differ
+and 2 on error.
+
+Files of 64 MiB or more, or any files with --large, are compared a line at a
+time without loading them into memory, and their diff is always written.`,
+		Args: cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if large || isLarge(args) {
+				return compareLarge(cmd, args, of)
+			}
+			texts, err := readInputs(args)
+			if err != nil {
+				return err
+			}
+			return compareTexts(cmd, texts, args, of)
+		},
+	}
+	of.register(cmd)
+	cmd.Flags().BoolVar(&large, "large", false, "compare a line at a time without loading the files into memory")
+	return cmd
+}
+
+// optionFlags are the flags that select compare.Options.
+type optionFlags struct {
+	preset, unit                             string
+	ignoreCase, ignoreEOL, template, overlap bool
+	// timeout is how long to look for the smallest diff, or 0 for as long
+	// as it takes.
+	timeout time.Duration
+}
+
+// register adds the flags to cmd. inputs names what is compared in the
+// flag descriptions, e.g. "both files".
+func (of *optionFlags) register(cmd *cobra.Command, inputs string) {
+	cmd.Flags().StringVarP(&of.preset, "preset", "p", "", "prepare "+inputs+" with `preset` before comparing")
+	cmd.Flags().BoolVarP(&of.ignoreCase, "ignore-case", "i", false, "ignore differences in case")
+	cmd.Flags().BoolVar(&of.ignoreEOL, "ignore-eol", false, "ignore differences in line endings (CRLF, CR and LF)")
+	cmd.Flags().BoolVarP(&of.template, "template", "t", false, "let placeholders such as {{number}} in the first input match anything of their kind")
+	cmd.Flags().BoolVar(&of.overlap, "overlap", false, "compare only as much of the longer input as the shorter has, e.g. when one is truncated")
+	cmd.Flags().StringVar(&of.unit, "unit", diff.Grapheme.String(), "diff one grapheme, rune or byte at a time")
+	cmd.Flags().DurationVar(&of.timeout, "diff-timeout", diff.DefaultTimeout, "settle for a larger diff of inputs that take longer than `duration` to compare, or 0 to wait as long as it takes")
+	cmd.RegisterFlagCompletionFunc("preset", completePresets)
+	cmd.RegisterFlagCompletionFunc("unit", completeUnits)
+}
+
+// options returns the options selected by the flags.
+func (of optionFlags) options() (compare.Options, error) {
+	p, err := lookupPreset(of.preset)
+	if err != nil {
+		return compare.Options{}, err
+	}
+	u, err := diff.ParseUnit(of.unit)
+	if err != nil {
+		return compare.Options{}, err
+	}
+	if of.timeout < 0 {
+		return compare.Options{}, fmt.Errorf("invalid diff timeout %s", of.timeout)
+	}
+	timeout := of.timeout
+	if timeout == 0 {
+		timeout = -1
+	}
+	return compare.Options{Preset: p, IgnoreCase: of.ignoreCase, IgnoreLineEndings: of.ignoreEOL, Template: of.template, Overlap: of.overlap, Unit: u, Timeout: timeout}, nil
+}
+
+// compareFlags are the flags of commands that compare two texts.
+type compareFlags struct {
+	optionFlags
+	output, format   string
+	quiet, porcelain bool
+}
+
+func (of *compareFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&of.output, "output", "o", "", "write the diff to `file` instead of standard output")
+	cmd.Flags().StringVar(&of.format, "format", "plain", "output format: "+strings.Join(render.Names(), ", "))
+	cmd.Flags().BoolVarP(&of.quiet, "quiet", "q", false, "print nothing; only set the exit status")
+	cmd.Flags().BoolVar(&of.porcelain, "porcelain", false, "print a stable, parseable summary (same as --format porcelain)")
+	of.optionFlags.register(cmd, "both inputs")
+	cmd.MarkFlagsMutuallyExclusive("quiet", "porcelain")
+	cmd.RegisterFlagCompletionFunc("format", completeFormats)
+}
+
+// interactive reports whether the comparison should be shown in the
+// interactive view rather than written out.
+func (of compareFlags) interactive() bool {
+	return of.output == "" && !of.quiet && !of.porcelain && isTerminal(os.Stdout)
+}
+
+func newTransformCmd() *cobra.Command {
+	var arg, output string
+	cmd := &cobra.Command{
+		Use:   "transform [NAME [FILE]]",
+		Short: "Apply a transform to a file, or list transforms",
+		Args:  cobra.MaximumNArgs(2),
+
+		ValidArgsFunction: completeTransformArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+				for _, t := range transform.All() {
+					fmt.Fprintf(w, "%s\t%s\n", t.Name, t.Description)
+				}
+				return w.Flush()
+			}
+			t, ok := transform.Lookup(args[0])
+			if !ok {
+				return fmt.Errorf("unknown transform %q", args[0])
+			}
+			if t.Arg != "" && arg == "" {
+				return fmt.Errorf("transform %s needs --arg (%s)", t.Name, t.Arg)
+			}
+			in, err := readInput(fileArg(args[1:]))
+			if err != nil {
+				return err
+			}
+			out, err := t.Apply(in, arg)
+			if err != nil {
+				return err
+			}
+			recordStats(func(s *usageStats) { s.recordTransform(t.Name) })
+			return writeOutput(cmd, output, out)
+		},
+	}
+	cmd.Flags().StringVar(&arg, "arg", "", "argument for transforms that take one")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "write the result to `file` instead of standard output")
+	return cmd
+}
+
+func newHashCmd() *cobra.Command {
+	var algo string
+	var names []string
+	for _, a := range hash.Algorithms {
+		names = append(names, a.Name)
+	}
+	cmd := &cobra.Command{
+		Use:   "hash [FILE]",
+		Short: "Print digests of a file",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			algos := hash.Algorithms
+			if algo != "" {
+				a, ok := hash.Lookup(algo)
+				if !ok {
+					return fmt.Errorf("unknown algorithm %q", algo)
+				}
+				algos = []hash.Algorithm{a}
+			}
+			in, err := readInput(fileArg(args))
+			if err != nil {
+				return err
+			}
+			for _, a := range algos {
+				if len(algos) == 1 {
+					fmt.Fprintln(cmd.OutOrStdout(), a.Sum(in))
+				} else {
+					fmt.Fprintf(cmd.OutOrStdout(), "%-7s %s\n", a.Name, a.Sum(in))
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&algo, "algorithm", "a", "", "only print this digest: "+strings.Join(names, ", "))
+	cmd.RegisterFlagCompletionFunc("algorithm", completeAlgorithms)
+	return cmd
+}
+
+func newFmtCmd() *cobra.Command {
+	var indent int
+	var minify bool
+	var output string
+	cmd := &cobra.Command{
+		Use:   "fmt [FILE]",
+		Short: "Format a JSON document",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in, err := readInput(fileArg(args))
+			if err != nil {
+				return err
+			}
+			var out string
+			if minify {
+				out, err = transform.JSONCompact(in)
+			} else {
+				out, err = transform.JSONIndent(in, strings.Repeat(" ", indent))
+			}
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, output, out)
+		},
+	}
+	cmd.Flags().IntVar(&indent, "indent", 2, "number of spaces to indent with")
+	cmd.Flags().BoolVar(&minify, "minify", false, "remove all insignificant whitespace")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "write the result to `file` instead of standard output")
+	return cmd
+}
+
+func newLoremCmd() *cobra.Command {
+	var paragraphs, sentences bool
+	cmd := &cobra.Command{
+		Use:   "lorem [COUNT]",
+		Short: "Print placeholder text: COUNT words of lorem ipsum by default",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n := 50
+			if len(args) > 0 {
+				var err error
+				if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+					return fmt.Errorf("invalid count %q", args[0])
+				}
+			}
+			var out string
+			switch {
+			case paragraphs:
+				out = transform.LoremParagraphs(n)
+			case sentences:
+				out = transform.RandomSentences(n) + "\n"
+			default:
+				out = transform.LoremWords(n) + "\n"
+			}
+			_, err := io.WriteString(cmd.OutOrStdout(), out)
+			return err
+		},
+	}
+	cmd.Flags().BoolVarP(&paragraphs, "paragraphs", "p", false, "count paragraphs instead of words")
+	cmd.Flags().BoolVarP(&sentences, "sentences", "s", false, "print COUNT simple random English sentences instead")
+	cmd.MarkFlagsMutuallyExclusive("paragraphs", "sentences")
+	return cmd
+}
+
+func newIDCmd() *cobra.Command {
+	var kind string
+	var names []string
+	for _, k := range id.Kinds {
+		names = append(names, k.Name)
+	}
+	cmd := &cobra.Command{
+		Use:   "id [COUNT]",
+		Short: "Print COUNT new UUIDs or ULIDs, one per line",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, ok := id.Lookup(kind)
+			if !ok {
+				return fmt.Errorf("unknown kind %q", kind)
+			}
+			n := 1
+			if len(args) > 0 {
+				var err error
+				if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+					return fmt.Errorf("invalid count %q", args[0])
+				}
+			}
+			_, err := io.WriteString(cmd.OutOrStdout(), strings.TrimSuffix(generateIDs(k, n), "\n")+"\n")
+			return err
+		},
+	}
+	cmd.Flags().StringVarP(&kind, "kind", "k", "uuid4", "kind of identifier: "+strings.Join(names, ", "))
+	cmd.RegisterFlagCompletionFunc("kind", completeIDKinds)
+	return cmd
+}
+
+// compareTexts shows texts, loaded from paths, in the interactive view, or
+// writes their diff as selected by of.
+func compareTexts(cmd *cobra.Command, texts, paths []string, of compareFlags) error {
+	if of.porcelain {
+		of.format = "porcelain"
+	}
+	r, ok := render.Lookup(of.format, diffStyles)
+	if !ok {
+		return fmt.Errorf("unknown format %q", of.format)
+	}
+	opts, err := of.options()
+	if err != nil {
+		return err
+	}
+	if of.interactive() {
+		m := newModel()
+		m.format = of.format
+		m.options = opts
+		m.setInputs(texts, paths)
+		return runTUI(cmd, m)
+	}
+	res := compare.Compare(texts[0], texts[1], opts)
+	d := res.Diff
+	recordStats(func(s *usageStats) { s.recordCompare(len(texts[0]), len(texts[1])) })
+	if !of.quiet {
+		out, err := r(d)
+		if err != nil {
+			return err
+		}
+		if of.format == "plain" || of.format == "color" {
+			out = res.Report + out
+		}
+		if err := writeOutput(cmd, of.output, out); err != nil {
+			return err
+		}
+	}
+	if !d.Equal() {
+		return errDiffer
+	}
+	return nil
+}
+
+// runTUI starts the interactive view of cmd with m as its initial state.
+func runTUI(cmd *cobra.Command, m model) error {
+	var err error
+	if m.title, err = cmd.Flags().GetBool("title"); err != nil {
+		return err
+	}
+	if m.lockAfter, err = cmd.Flags().GetDuration("lock-after"); err != nil {
+		return err
+	}
+	if on, err := cmd.Flags().GetBool("vim"); err != nil {
+		return err
+	} else if on {
+		m.vim = &vim{}
+	}
+	m.lastKey = time.Now()
+	record, err := cmd.Flags().GetString("record")
+	if err != nil {
+		return err
+	}
+	if record != "" {
+		if m.recorder, err = newRecorder(record); err != nil {
+			return err
+		}
+		defer func() {
+			if cerr := m.recorder.close(); cerr != nil {
+				fmt.Fprintln(os.Stderr, "Warning: recording incomplete:", cerr)
+			}
+		}()
+	}
+	if m.tips, err = loadTips(); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: ignoring saved tips:", err)
+	}
+	if m.stats, err = loadStats(); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: ignoring saved stats:", err)
+	}
+	// Detect the background color while nothing else reads from the
+	// terminal; lipgloss remembers the answer. Themes with a variant for
+	// light backgrounds switch to it.
+	darkBackground = lipgloss.HasDarkBackground()
+	if t, ok := theme.Lookup(currentTheme.Name); ok {
+		m.setTheme(t)
+	}
+	opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+	if !isTerminal(os.Stdin) {
+		// Standard input was used for the panes, so read keys from the terminal.
+		opts = append(opts, tea.WithInputTTY())
+	}
+	if settings.autosaves() && m.empty() {
+		if name, saved, err := abandonedDraft(); err != nil {
+			fmt.Fprintln(os.Stderr, "Warning: ignoring autosaved draft:", err)
+		} else if saved != nil {
+			m.overlay = recoverPrompt{name: name, saved: saved}
+		}
+	}
+	if settings.savesSession() && m.empty() && m.overlay == nil {
+		if saved, err := loadSession(); err != nil {
+			fmt.Fprintln(os.Stderr, "Warning: ignoring saved session:", err)
+		} else if saved != nil {
+			m.overlay = restorePrompt{saved: saved}
+		}
+	}
+	if settings.autosaves() {
+		m.draft = draftName()
+	}
+	final, err := tea.NewProgram(m, opts...).Run()
+	if err != nil {
+		return err
+	}
+	// The model is missing after a panic, which leaves the draft to be
+	// recovered.
+	fm, ok := final.(model)
+	if !ok {
+		return nil
+	}
+	if err := fm.stats.flush(); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: could not save stats:", err)
+	}
+	if settings.savesSession() {
+		if err := saveSession(&fm); err != nil {
+			fmt.Fprintln(os.Stderr, "Warning: could not save session:", err)
+		}
+	}
+	if fm.draft != "" {
+		if err := removeState(fm.draft); err != nil {
+			fmt.Fprintln(os.Stderr, "Warning: could not remove autosaved draft:", err)
+		}
+	}
+	return nil
+}
+
+// recordStats loads the usage stats, records an event with fn and saves
+// them. Stats are best effort, so failures are only reported as warnings.
+func recordStats(fn func(*usageStats)) {
+	s, err := loadStats()
+	if err == nil {
+		fn(s)
+		err = s.flush()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: could not record stats:", err)
+	}
+}
+
+// readInputs reads the content of the two input panes from the files at
+// paths. With no paths, the first pane is read from standard input if it is
+// not a terminal.
+func readInputs(paths []string) ([]string, error) {
+	if len(paths) == 0 && !isTerminal(os.Stdin) {
+		paths = []string{"-"}
+	}
+	texts := make([]string, 2)
+	stdinRead := false
+	for i, path := range paths {
+		if path == "-" {
+			if stdinRead {
+				return nil, errors.New("standard input can only be read once")
+			}
+			stdinRead = true
+		}
+		text, err := readInput(path)
+		if err != nil {
+			return nil, err
+		}
+		texts[i] = text
+	}
+	return texts, nil
+}
+
+// fileArg returns the single optional file argument, or "-" for standard
+// input.
+func fileArg(args []string) string {
+	if len(args) == 0 {
+		return "-"
+	}
+	return args[0]
+}
+
+// readInput reads the file at path, or standard input when path is "-".
+func readInput(path string) (string, error) {
+	if path == "-" {
+		b, err := io.ReadAll(os.Stdin)
+		return string(b), err
+	}
+	b, err := os.ReadFile(path)
+	return string(b), err
+}
+
+// writeOutput writes s to the file at path, or to the output of cmd when
+// path is empty.
+func writeOutput(cmd *cobra.Command, path, s string) error {
+	if path == "" {
+		_, err := io.WriteString(cmd.OutOrStdout(), s)
+		return err
+	}
+	return os.WriteFile(path, []byte(s), 0o644)
+}
+
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadInputs(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.txt")
+	b := filepath.Join(dir, "b.txt")
+	os.WriteFile(a, []byte("first\n"), 0o644)
+	os.WriteFile(b, []byte("second\n"), 0o644)
+
+	tests := []struct {
+		name    string
+		paths   []string
+		want    []string
+		wantErr string
+	}{
+		{"two files", []string{a, b}, []string{"first\n", "second\n"}, ""},
+		{"one file", []string{a}, []string{"first\n", ""}, ""},
+		{"stdin twice", []string{"-", "-"}, nil, "standard input can only be read once"},
+		{"missing file", []string{filepath.Join(dir, "missing")}, nil, "no such file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readInputs(tt.paths)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommands(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.txt")
+	os.WriteFile(a, []byte("one\ntwo\n"), 0o644)
+	b := filepath.Join(dir, "b.txt")
+	os.WriteFile(b, []byte("one\nsix\n"), 0o644)
+	j := filepath.Join(dir, "a.json")
+	os.WriteFile(j, []byte(`{"a": [1, 2]}`), 0o644)
+
+	tests := []struct {
+		name  string
+		stdin string
+		args  []string
+		// want is part of the output expected.
+		want     string
+		wantCode int
+	}{
+		{"root identical", "", []string{a, a}, "", exitSame},
+		{"root different", "", []string{a, b}, "[-two-]{+six+}", exitDiffer},
+		{"root stdin", "one\nsix\n", []string{"-", a}, "[-six-]{+two+}", exitDiffer},
+		{"root missing file", "", []string{filepath.Join(dir, "missing")}, "", exitError},
+		{"compare identical", "one\ntwo\n", []string{"compare", a, "-"}, "", exitSame},
+		{"compare different", "one\nsix\n", []string{"compare", a, "-"}, "[-two-]{+six+}", exitDiffer},
+		{"compare as json", "one\n", []string{"compare", "--format", "json", a, "-"}, `"kind": "removed"`, exitDiffer},
+		{"compare quiet", "one\nsix\n", []string{"compare", "-q", a, "-"}, "", exitDiffer},
+		{"compare porcelain", "one\nsix\n", []string{"compare", "--porcelain", a, "-"}, "hunk\tmodified\t2,1\t2,1\n", exitDiffer},
+		{"compare quiet and porcelain", "", []string{"compare", "-q", "--porcelain", a, a}, "", exitError},
+		{"compare unknown format", "", []string{"compare", "--format", "xml", a, a}, "", exitError},
+		{"compare missing file", "", []string{"compare", filepath.Join(dir, "missing")}, "", exitError},
+		{"compare ignoring case", "ONE\nTwo\n", []string{"compare", "-i", a, "-"}, "", exitSame},
+		{"compare ignoring line endings", "one\r\ntwo\r\n", []string{"compare", "--ignore-eol", a, "-"}, "", exitSame},
+		{"compare template", "{{any}}\ntwo\n", []string{"compare", "-t", "-", a}, "", exitSame},
+		{"compare template mismatch", "one\n{{number}}\n", []string{"compare", "-t", "-", a}, "[-{{number}}-]{+two+}", exitDiffer},
+		{"compare truncated", "one\n", []string{"compare", a, "-"}, "\\ B is truncated after line 1, byte 3 (5 more bytes in the other)\n", exitDiffer},
+		{"compare overlap", "one\n", []string{"compare", "--overlap", a, "-"}, "", exitSame},
+		{"compare bytes", "one\nsix\n", []string{"compare", "--unit", "byte", a, "-"}, "@@ -2,1 +2,1 @@ modified at byte -4 +4\n", exitDiffer},
+		{"compare without timeout", "one\nsix\n", []string{"compare", "--diff-timeout", "0", a, "-"}, "[-two-]{+six+}", exitDiffer},
+		{"compare negative timeout", "", []string{"compare", "--diff-timeout", "-1s", a, a}, "", exitError},
+		{"compare unknown unit", "", []string{"compare", "--unit", "word", a, a}, "", exitError},
+		{"compare too many files", "", []string{"compare", a, a, a}, "", exitError},
+		{"compare large", "", []string{"compare", "--large", a, b}, "[-two-]{+six+}", exitDiffer},
+		{"compare large identical", "", []string{"compare", "--large", a, a}, "", exitSame},
+		{"compare large porcelain", "", []string{"compare", "--large", "--porcelain", a, b}, "hunk\tmodified\t2,1\t2,1\n", exitDiffer},
+		{"compare large stdin", "one\n", []string{"compare", "--large", a, "-"}, "", exitError},
+		{"compare large negative timeout", "", []string{"compare", "--large", "--diff-timeout", "-1s", a, b}, "", exitError},
+		{"compare large ignoring case", "", []string{"compare", "--large", "-i", a, b}, "", exitError},
+		{"unknown theme", "", []string{"--theme", "neon", "transform"}, "", exitError},
+		{"theme", "x", []string{"--theme", "monochrome", "transform", "upper"}, "X", exitSame},
+		{"unknown command", "", []string{"frobnicate", "x", "y"}, "", exitError},
+		{"transform list", "", []string{"transform"}, "upper", exitSame},
+		{"transform file", "", []string{"transform", "upper", a}, "ONE\nTWO\n", exitSame},
+		{"transform stdin", "Mixed", []string{"transform", "lower"}, "mixed", exitSame},
+		{"transform unknown", "", []string{"transform", "nope", a}, "", exitError},
+		{"hash one algorithm", "abc", []string{"hash", "-a", "md5"}, "900150983cd24fb0d6963f7d28e17f72\n", exitSame},
+		{"hash all algorithms", "abc", []string{"hash"}, "crc32   352441c2\n", exitSame},
+		{"hash unknown algorithm", "abc", []string{"hash", "-a", "md4"}, "", exitError},
+		{"fmt", "", []string{"fmt", j}, "{\n  \"a\": [\n    1,\n    2\n  ]\n}\n", exitSame},
+		{"fmt indent", "", []string{"fmt", "--indent", "4", j}, "{\n    \"a\"", exitSame},
+		{"fmt minify", "", []string{"fmt", "--minify", j}, `{"a":[1,2]}`, exitSame},
+		{"fmt invalid", "{", []string{"fmt"}, "", exitError},
+		{"lorem", "", []string{"lorem", "5"}, "Lorem ipsum dolor sit amet.\n", exitSame},
+		{"lorem invalid count", "", []string{"lorem", "none"}, "", exitError},
+		{"id", "", []string{"id"}, "-4", exitSame},
+		{"id ulids", "", []string{"id", "-k", "ulid", "2"}, "\n0", exitSame},
+		{"id unknown kind", "", []string{"id", "-k", "uuid1"}, "", exitError},
+		{"id invalid count", "", []string{"id", "0"}, "", exitError},
+		{"lorem paragraphs and sentences", "", []string{"lorem", "-p", "-s"}, "", exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, code := runMain(t, tt.stdin, tt.args...)
+			if code != tt.wantCode {
+				t.Errorf("exit status = %d, want %d", code, tt.wantCode)
+			}
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("printed\n%s\nwant %q in it", out, tt.want)
+			}
+			if tt.want == "" && out != "" && tt.wantCode != exitError {
+				t.Errorf("printed\n%s\nwant nothing", out)
+			}
+		})
+	}
+}
+
+func TestOutputFile(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.txt")
+	os.WriteFile(a, []byte("one\n"), 0o644)
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"plain", "@@ -1,1 +1,1 @@ modified\n[-one-]{+six+}\n"},
+		{"json", `"kind": "modified"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out := filepath.Join(dir, "diff."+tt.format)
+			stdout, code := runMain(t, "six\n", "compare", "-o", out, "--format", tt.format, a, "-")
+			if code != exitDiffer {
+				t.Errorf("exit status = %d, want %d", code, exitDiffer)
+			}
+			if stdout != "" {
+				t.Errorf("printed %q with an output file", stdout)
+			}
+			b, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), tt.want) {
+				t.Errorf("output file has\n%s\nwant %q in it", b, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandOutput(t *testing.T) {
+	in := filepath.Join(t.TempDir(), "in.json")
+	os.WriteFile(in, []byte(`{"a":"b"}`), 0o644)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"transform", []string{"transform", "upper", in}, `{"A":"B"}`},
+		{"fmt", []string{"fmt", in}, "{\n  \"a\": \"b\"\n}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			cmd := newRootCmd()
+			cmd.SetOut(&out)
+			cmd.SetArgs(tt.args)
+			if err := cmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("wrote %q, want %q in it", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestListingsAlign(t *testing.T) {
+	for _, name := range []string{"transform", "roundtrip"} {
+		t.Run(name, func(t *testing.T) {
+			var out strings.Builder
+			cmd := newRootCmd()
+			cmd.SetOut(&out)
+			cmd.SetArgs([]string{name})
+			if err := cmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+			column := -1
+			for _, l := range lines {
+				name, _, _ := strings.Cut(l, " ")
+				at := len(l) - len(strings.TrimLeft(l[len(name):], " "))
+				if column == -1 {
+					column = at
+				}
+				if at != column || at <= len(name) {
+					t.Errorf("%q does not start its description at column %d", l, column)
+				}
+			}
+		})
+	}
+}
+
+func TestOptionsTimeout(t *testing.T) {
+	tests := []struct {
+		timeout time.Duration
+		want    time.Duration
+		wantErr bool
+	}{
+		{time.Second, time.Second, false},
+		{0, -1, false},
+		{-time.Second, 0, true},
+	}
+	for _, tt := range tests {
+		opts, err := optionFlags{unit: "grapheme", timeout: tt.timeout}.options()
+		if (err != nil) != tt.wantErr || opts.Timeout != tt.want {
+			t.Errorf("timeout %s: got %s, %v", tt.timeout, opts.Timeout, err)
+		}
+	}
+}
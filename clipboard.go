@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/transform"
+)
+
+// copyText puts s on the system clipboard. It asks the terminal to do so
+// with an OSC 52 escape sequence, which also works over SSH, and also uses
+// the clipboard tools of the local system if there are any. Only if
+// neither is possible is the error of the local tools returned.
+func copyText(s string) error {
+	sent := false
+	if isTerminal(os.Stderr) {
+		seq := osc52.New(s)
+		switch {
+		case os.Getenv("TMUX") != "":
+			seq = seq.Tmux()
+		case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+			seq = seq.Screen()
+		}
+		// Standard error is the terminal too, and not written to by
+		// the program's renderer.
+		_, err := seq.WriteTo(os.Stderr)
+		sent = err == nil
+	}
+	if err := clipboard.WriteAll(s); err != nil && !sent {
+		return err
+	}
+	return nil
+}
+
+// paste inserts the system clipboard at the cursor of the focused input
+// pane. The clipboard is read in the background and inserted as a whole,
+// which is much quicker for long text than inserting it as typed.
+func (m *model) paste() tea.Cmd {
+	if m.focus >= len(m.inputs)-1 {
+		m.err = errNoInputPane
+		return nil
+	}
+	return m.startInsert(m.focus, clipboard.ReadAll)
+}
+
+// insertText inserts text at the cursor of pane i, leaving the cursor after
+// it. Like loading, inserting can be undone.
+func (m *model) insertText(i int, text string) {
+	t := &m.inputs[i]
+	lines := strings.Split(t.Value(), "\n")
+	li := t.LineInfo()
+	line := t.Line()
+	cur := []rune(lines[line])
+	col := min(li.StartColumn+li.ColumnOffset, len(cur))
+	text = transform.ExpandTabs(transform.ToLF(text), m.tabWidth)
+	lines[line] = string(cur[:col]) + text + string(cur[col:])
+	m.replacePane(i, m.withLineEndings(i, strings.Join(lines, "\n")))
+
+	end := strings.LastIndex(text, "\n")
+	endCol := col + utf8.RuneCountInString(text)
+	if end >= 0 {
+		endCol = utf8.RuneCountInString(text[end+1:])
+	}
+	m.moveCursor(i, panePos{line: line + strings.Count(text, "\n"), col: endCol})
+}
+
+// copyFocused copies the text of the focused pane.
+func (m *model) copyFocused() {
+	text, what := m.focusedText()
+	if err := copyText(text); err != nil {
+		m.err = fmt.Errorf("copy: %w", err)
+		return
+	}
+	m.err = nil
+	m.notice = "copied " + what
+}
+
+// focusedText returns the text of the focused input pane, or the result
+// without its colors, and what it is.
+func (m *model) focusedText() (text, what string) {
+	if m.focus < len(m.inputs)-1 {
+		return m.paneText(m.focus), string(rune('A' + m.focus))
+	}
+	return escapePattern.ReplaceAllString(m.result, ""), "result"
+}
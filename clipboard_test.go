@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFocusedText(t *testing.T) {
+	m := newModel()
+	m.eols[1] = "CRLF"
+	m.setPane(0, "a")
+	m.setPane(1, "b\r\nc")
+	m.setResult("\x1b[32mplain\x1b[0m result")
+	tests := []struct {
+		focus      int
+		text, what string
+	}{
+		{0, "a", "A"},
+		{1, "b\r\nc", "B"},
+		{2, "plain result", "result"},
+	}
+	for _, tt := range tests {
+		m.focus = tt.focus
+		if text, what := m.focusedText(); text != tt.text || what != tt.what {
+			t.Errorf("pane %d: %q, %q, want %q, %q", tt.focus, text, what, tt.text, tt.what)
+		}
+	}
+}
+
+func TestCopyKey(t *testing.T) {
+	m := newModel()
+	m.setPane(0, "a")
+	m, _ = update(m, alt('x'))
+	// Whether there is a clipboard depends on where the test runs.
+	switch {
+	case m.err != nil:
+		if !strings.HasPrefix(m.err.Error(), "copy: ") {
+			t.Errorf("error %v", m.err)
+		}
+	case m.notice != "copied A":
+		t.Errorf("notice %q", m.notice)
+	}
+}
+
+func TestInsertText(t *testing.T) {
+	tests := []struct {
+		name, text        string
+		line, col         int
+		insert, want      string
+		wantLine, wantCol int
+	}{
+		{"middle", "abcd", 0, 2, "XY", "abXYcd", 0, 4},
+		{"end", "ab\ncd", 1, 2, "!", "ab\ncd!", 1, 3},
+		{"lines", "ab\ncd", 0, 1, "1\n22\n3", "a1\n22\n3b\ncd", 2, 1},
+		{"line endings", "ab", 0, 0, "x\r\ny\r\n", "x\ny\nab", 2, 0},
+		{"tabs", "ab", 0, 1, "\tc", "a    cb", 0, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.setPane(0, tt.text)
+			m.moveCursor(0, panePos{line: tt.line, col: tt.col})
+			m.insertText(0, tt.insert)
+			if got := m.inputs[0].Value(); got != tt.want {
+				t.Errorf("%q, want %q", got, tt.want)
+			}
+			li := m.inputs[0].LineInfo()
+			if line, col := m.inputs[0].Line(), li.StartColumn+li.ColumnOffset; line != tt.wantLine || col != tt.wantCol {
+				t.Errorf("cursor at %d:%d, want %d:%d", line, col, tt.wantLine, tt.wantCol)
+			}
+		})
+	}
+}
+
+func TestPaste(t *testing.T) {
+	m := newModel()
+	m.setPane(0, "ab")
+	cmd := m.startInsert(0, func() (string, error) { return "XY", nil })
+	m = settle(m, cmd())
+	if got := m.inputs[0].Value(); got != "abXY" {
+		t.Errorf("pasting gives %q", got)
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if got := m.inputs[0].Value(); got != "ab" {
+		t.Errorf("undoing the paste gives %q", got)
+	}
+
+	m.focus = len(m.inputs) - 1
+	m, cmd = update(m, tea.KeyMsg{Type: tea.KeyCtrlV})
+	if cmd != nil || m.err != errNoInputPane {
+		t.Errorf("pasting into the result pane: %v", m.err)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// deficiency simulates a kind of color vision deficiency with a matrix
+// applied to linear RGB (Machado, Oliveira and Fernandes, 2009, at full
+// severity).
+type deficiency struct {
+	name   string
+	matrix [3][3]float64
+}
+
+var deficiencies = []deficiency{
+	{"normal vision", [3][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}},
+	{"protanopia", [3][3]float64{
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	}},
+	{"deuteranopia", [3][3]float64{
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	}},
+	{"tritanopia", [3][3]float64{
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	}},
+}
+
+// minColorDistance is the CIE76 color difference below which two text
+// colors are hard to tell apart.
+const minColorDistance = 20
+
+// simulate returns c as seen with deficiency d.
+func (d deficiency) simulate(c colorful.Color) colorful.Color {
+	r, g, b := c.LinearRgb()
+	m := d.matrix
+	return colorful.LinearRgb(
+		m[0][0]*r+m[0][1]*g+m[0][2]*b,
+		m[1][0]*r+m[1][1]*g+m[1][2]*b,
+		m[2][0]*r+m[2][1]*g+m[2][2]*b,
+	).Clamped()
+}
+
+// colorCheck previews the insert and delete colors as people with common
+// color vision deficiencies see them.
+type colorCheck struct{}
+
+func (colorCheck) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		return true, nil
+	}
+	return false, nil
+}
+
+func (colorCheck) view(m *model) string {
+	ins, err1 := colorful.Hex(string(currentTheme.Insert))
+	del, err2 := colorful.Hex(string(currentTheme.Delete))
+	var b strings.Builder
+	b.WriteString(overlayTitleStyle.Render("Color check") + "\n\n")
+	if err1 != nil || err2 != nil {
+		b.WriteString("The diff colors are not RGB colors and cannot be simulated.\n")
+	} else {
+		for _, d := range deficiencies {
+			si, sd := d.simulate(ins), d.simulate(del)
+			dist := si.DistanceLab(sd) * 100
+			fmt.Fprintf(&b, "%-14s %s %s  ΔE %3.0f",
+				d.name,
+				lipgloss.NewStyle().Foreground(lipgloss.Color(si.Hex())).Render("inserted"),
+				lipgloss.NewStyle().Foreground(lipgloss.Color(sd.Hex())).Render("deleted"),
+				dist)
+			if dist < minColorDistance {
+				b.WriteString("  " + errorStyle.Render("hard to tell apart"))
+			}
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\nesc close")
+	return overlayStyle.Render(b.String())
+}
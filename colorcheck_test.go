@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+func TestSimulateDeficiencies(t *testing.T) {
+	red, _ := colorful.Hex("#FF0000")
+	green, _ := colorful.Hex("#00FF00")
+	normal := red.DistanceLab(green) * 100
+	tests := []struct {
+		name string
+		// closer is whether red and green look much more alike than with
+		// normal vision.
+		closer bool
+	}{
+		{"normal vision", false},
+		{"protanopia", true},
+		{"deuteranopia", true},
+		{"tritanopia", false},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := deficiencies[i]
+			if d.name != tt.name {
+				t.Fatalf("deficiency %d is %q", i, d.name)
+			}
+			dist := d.simulate(red).DistanceLab(d.simulate(green)) * 100
+			if got := dist < normal/2; got != tt.closer {
+				t.Errorf("ΔE %.0f against %.0f with normal vision; closer = %v, want %v", dist, normal, got, tt.closer)
+			}
+		})
+	}
+	if got := deficiencies[0].simulate(red); got.Hex() != red.Hex() {
+		t.Errorf("normal vision changed %s to %s", red.Hex(), got.Hex())
+	}
+}
+
+func TestColorCheckFlagsSimilarColors(t *testing.T) {
+	ins, _ := colorful.Hex(string(currentTheme.Insert))
+	del, _ := colorful.Hex(string(currentTheme.Delete))
+	for _, d := range deficiencies {
+		if dist := d.simulate(ins).DistanceLab(d.simulate(del)) * 100; dist < minColorDistance {
+			t.Errorf("the diff colors are hard to tell apart with %s (ΔE %.0f)", d.name, dist)
+		}
+	}
+}
+
+func TestColorCheckScreen(t *testing.T) {
+	m := newModel()
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyF4})
+	if _, ok := m.overlay.(colorCheck); !ok {
+		t.Fatalf("overlay = %T, want the color check", m.overlay)
+	}
+	view := m.View()
+	for _, d := range deficiencies {
+		if !strings.Contains(view, d.name) {
+			t.Errorf("view does not show %s", d.name)
+		}
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.overlay != nil {
+		t.Error("esc did not close the color check")
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"strcli/pkg/diff"
+	"strcli/pkg/hash"
+	"strcli/pkg/id"
+	"strcli/pkg/render"
+	"strcli/pkg/theme"
+	"strcli/pkg/transform"
+)
+
+func newCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish|powershell",
+		Short: "Print a shell completion script",
+		Long: `Print a completion script for the given shell. For example, to load
+completions in the current bash session:
+
+  source <(strcli completion bash)`,
+		ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, w := cmd.Root(), cmd.OutOrStdout()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(w, true)
+			case "zsh":
+				return root.GenZshCompletion(w)
+			case "fish":
+				return root.GenFishCompletion(w, true)
+			case "powershell":
+				return root.GenPowerShellCompletionWithDesc(w)
+			}
+			return fmt.Errorf("unsupported shell %q", args[0])
+		},
+	}
+}
+
+// completeFormats completes the names of output formats.
+func completeFormats(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return render.Names(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeUnits completes the names of diff units.
+func completeUnits(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	for _, u := range diff.Units {
+		names = append(names, u.String())
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeAlgorithms completes the names of hash algorithms.
+func completeAlgorithms(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	for _, a := range hash.Algorithms {
+		names = append(names, a.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeIDKinds(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	for _, k := range id.Kinds {
+		names = append(names, k.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeThemes(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return theme.Names(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTransformArgs completes a transform name followed by a file.
+func completeTransformArgs(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	var names []string
+	for _, t := range transform.All() {
+		names = append(names, t.Name+"\t"+t.Description)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
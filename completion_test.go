@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompletion(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		// want are lines expected in the output.
+		want     []string
+		wantCode int
+	}{
+		{"formats", []string{"__complete", "compare", "--format", ""}, []string{"json", "plain"}, exitSame},
+		{"algorithms", []string{"__complete", "hash", "-a", ""}, []string{"md5", "sha256"}, exitSame},
+		{"transform names", []string{"__complete", "transform", ""}, []string{"upper\tConvert to UPPER CASE"}, exitSame},
+		{"units", []string{"__complete", "watch", "--unit", ""}, []string{"grapheme", "rune", "byte"}, exitSame},
+		{"history formats", []string{"__complete", "history", "--format", ""}, []string{"json"}, exitSame},
+		{"themes", []string{"__complete", "--theme", ""}, []string{"default", "monochrome"}, exitSame},
+		{"id kinds", []string{"__complete", "id", "--kind", ""}, []string{"uuid4", "uuid7", "ulid"}, exitSame},
+		{"bash script", []string{"completion", "bash"}, []string{"# bash completion V2 for strcli                               -*- shell-script -*-"}, exitSame},
+		{"unknown shell", []string{"completion", "tcsh"}, nil, exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, code := runMain(t, "", tt.args...)
+			if code != tt.wantCode {
+				t.Errorf("exit status = %d, want %d", code, tt.wantCode)
+			}
+			lines := strings.Split(out, "\n")
+			for _, w := range tt.want {
+				found := false
+				for _, l := range lines {
+					found = found || l == w
+				}
+				if !found {
+					t.Errorf("no line %q in\n%s", w, out)
+				}
+			}
+		})
+	}
+}
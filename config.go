@@ -0,0 +1,339 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/spf13/cobra"
+	"strcli/pkg/compare"
+	"strcli/pkg/diff"
+	"strcli/pkg/theme"
+)
+
+// configName is the name of the config file in the state directory.
+const configName = "config.toml"
+
+// defaultTabWidth is the tab width of the panes unless configured.
+const defaultTabWidth = 4
+
+// config holds the defaults read from the config file, e.g.
+//
+//	theme = "solarized-light"
+//	layout = "vertical"
+//	tab_width = 8
+//	save_session = false
+//	vim = true
+//
+//	[diff]
+//	unit = "rune"
+//	ignore_case = true
+//	timeout = "5s"
+//
+//	[keys]
+//	compare = "ctrl+d"
+//	next = ["tab", "ctrl+n"]
+type config struct {
+	Theme    string `toml:"theme"`
+	Layout   string `toml:"layout"`
+	TabWidth int    `toml:"tab_width"`
+	// SaveSession, unless false, saves the panes on quit and offers to
+	// restore them on the next start.
+	SaveSession *bool `toml:"save_session"`
+	// Autosave, unless false, saves the panes as a draft while the TUI
+	// runs and offers to recover them after a crash.
+	Autosave *bool `toml:"autosave"`
+	// LiveCompare compares the panes while they are edited.
+	LiveCompare bool `toml:"live_compare"`
+	// Vim edits the input panes the way vim does.
+	Vim  bool `toml:"vim"`
+	Diff struct {
+		Unit              string `toml:"unit"`
+		Preset            string `toml:"preset"`
+		IgnoreCase        bool   `toml:"ignore_case"`
+		IgnoreLineEndings bool   `toml:"ignore_line_endings"`
+		// Timeout is how long to look for the smallest diff, such as
+		// "5s", or "0" for as long as it takes.
+		Timeout string `toml:"timeout"`
+	} `toml:"diff"`
+	Keys map[string]keyList `toml:"keys"`
+}
+
+// settings is the config the root command loaded before any command ran.
+var settings config
+
+// keyList is one key or a list of keys bound to an action.
+type keyList []string
+
+func (k *keyList) UnmarshalTOML(v any) error {
+	switch v := v.(type) {
+	case string:
+		*k = keyList{v}
+	case []any:
+		for _, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return fmt.Errorf("keys must be strings, not %v", e)
+			}
+			*k = append(*k, s)
+		}
+	default:
+		return fmt.Errorf("keys must be a string or a list of strings, not %v", v)
+	}
+	return nil
+}
+
+// loadConfig reads the config file at path, or the one in the state
+// directory if path is empty. Only a missing file in the state directory
+// is not an error.
+func loadConfig(path string) (config, error) {
+	var c config
+	explicit := path != ""
+	if !explicit {
+		dir, err := stateDir()
+		if err != nil {
+			return c, err
+		}
+		path = filepath.Join(dir, configName)
+	}
+	md, err := toml.DecodeFile(path, &c)
+	if !explicit && errors.Is(err, fs.ErrNotExist) {
+		return config{}, nil
+	}
+	if err != nil {
+		return c, fmt.Errorf("config: %w", err)
+	}
+	if u := md.Undecoded(); len(u) > 0 {
+		return c, fmt.Errorf("config %s: unknown setting %s", path, u[0])
+	}
+	if err := c.check(); err != nil {
+		return c, fmt.Errorf("config %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// check reports settings with values that cannot be used.
+func (c config) check() error {
+	if c.Theme != "" {
+		if _, ok := theme.Lookup(c.Theme); !ok {
+			return fmt.Errorf("unknown theme %q (have %s)", c.Theme, strings.Join(theme.Names(), ", "))
+		}
+	}
+	switch c.Layout {
+	case "", "horizontal", "vertical":
+	default:
+		return fmt.Errorf("unknown layout %q (have horizontal, vertical)", c.Layout)
+	}
+	if c.TabWidth < 0 {
+		return fmt.Errorf("invalid tab width %d", c.TabWidth)
+	}
+	if _, err := c.options(); err != nil {
+		return err
+	}
+	return c.checkKeys()
+}
+
+// checkKeys reports unknown actions in the keys the config binds, and keys
+// bound to two actions once the config's keys replace the defaults.
+func (c config) checkKeys() error {
+	k := newKeymap()
+	bindings := keyBindings(&k)
+	for name, keys := range c.Keys {
+		b, ok := bindings[name]
+		if !ok {
+			return fmt.Errorf("unknown action %q in keys (have %s)", name, strings.Join(keyNames(), ", "))
+		}
+		if len(keys) == 0 {
+			return fmt.Errorf("no keys for %s", name)
+		}
+		b.SetKeys(keys...)
+	}
+	actions := map[string]string{}
+	for _, name := range keyNames() {
+		for _, key := range bindings[name].Keys() {
+			if other, ok := actions[key]; ok {
+				return fmt.Errorf("%s is bound to both %s and %s", key, other, name)
+			}
+			actions[key] = name
+		}
+	}
+	return nil
+}
+
+// savesSession reports whether the TUI saves its panes on quit.
+func (c config) savesSession() bool {
+	return c.SaveSession == nil || *c.SaveSession
+}
+
+// autosaves reports whether the TUI autosaves its panes as a draft.
+func (c config) autosaves() bool {
+	return c.Autosave == nil || *c.Autosave
+}
+
+// diffFlags returns the values of the comparison flags the config sets, by
+// flag name.
+func (c config) diffFlags() map[string]string {
+	flags := map[string]string{}
+	if c.Diff.Unit != "" {
+		flags["unit"] = c.Diff.Unit
+	}
+	if c.Diff.Preset != "" {
+		flags["preset"] = c.Diff.Preset
+	}
+	if c.Diff.IgnoreCase {
+		flags["ignore-case"] = strconv.FormatBool(true)
+	}
+	if c.Diff.IgnoreLineEndings {
+		flags["ignore-eol"] = strconv.FormatBool(true)
+	}
+	if c.Diff.Timeout != "" {
+		flags["diff-timeout"] = c.Diff.Timeout
+	}
+	return flags
+}
+
+// options returns the comparison options the config sets.
+func (c config) options() (compare.Options, error) {
+	of, err := c.optionFlags()
+	if err != nil {
+		return compare.Options{}, err
+	}
+	return of.options()
+}
+
+// optionFlags returns the config as the flags that set the same
+// comparison options.
+func (c config) optionFlags() (optionFlags, error) {
+	of := optionFlags{unit: c.Diff.Unit, preset: c.Diff.Preset, ignoreCase: c.Diff.IgnoreCase, ignoreEOL: c.Diff.IgnoreLineEndings, timeout: diff.DefaultTimeout}
+	if of.unit == "" {
+		of.unit = "grapheme"
+	}
+	if c.Diff.Timeout != "" {
+		t, err := time.ParseDuration(c.Diff.Timeout)
+		if err != nil {
+			return optionFlags{}, fmt.Errorf("invalid diff timeout %q", c.Diff.Timeout)
+		}
+		of.timeout = t
+	}
+	return of, nil
+}
+
+// applyFlags uses the config for the flags of cmd that were not given.
+func (c config) applyFlags(cmd *cobra.Command) error {
+	defaults := c.diffFlags()
+	if c.Theme != "" {
+		defaults["theme"] = c.Theme
+	}
+	if c.Vim {
+		defaults["vim"] = strconv.FormatBool(true)
+	}
+	for name, v := range defaults {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || f.Changed {
+			continue
+		}
+		if err := cmd.Flags().Set(name, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply sets up m as the config says. Commands with comparison flags
+// replace m.options with theirs, which applyFlags defaulted to the config.
+func (c config) apply(m *model) {
+	m.options, _ = c.options()
+	m.vertical = c.Layout == "vertical"
+	m.live = c.LiveCompare
+	m.tabWidth = defaultTabWidth
+	if c.TabWidth > 0 {
+		m.tabWidth = c.TabWidth
+	}
+	bindings := keyBindings(&m.keymap)
+	for name, keys := range c.Keys {
+		b := bindings[name]
+		b.SetKeys(keys...)
+		b.SetHelp(keys[0], b.Help().Desc)
+	}
+}
+
+// keyBindings returns the bindings of k by the action names used in the
+// config file.
+func keyBindings(k *keymap) map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"next":           &k.next,
+		"prev":           &k.prev,
+		"quit":           &k.quit,
+		"compare":        &k.compare,
+		"restore":        &k.restore,
+		"transform":      &k.transform,
+		"export":         &k.export,
+		"save":           &k.save,
+		"open":           &k.open,
+		"new-tab":        &k.newTab,
+		"close-tab":      &k.closeTab,
+		"next-tab":       &k.nextTab,
+		"prev-tab":       &k.prevTab,
+		"dismiss-tip":    &k.dismissTip,
+		"stats":          &k.stats,
+		"preset":         &k.preset,
+		"round-trip":     &k.roundTrip,
+		"ignore-case":    &k.ignoreCase,
+		"unit":           &k.unit,
+		"match":          &k.match,
+		"hashes":         &k.hashes,
+		"template":       &k.template,
+		"overlap":        &k.overlap,
+		"color-check":    &k.colorCheck,
+		"text-stats":     &k.textStats,
+		"hide":           &k.hide,
+		"ids":            &k.ids,
+		"present":        &k.present,
+		"replace":        &k.replace,
+		"regex-tester":   &k.regexTester,
+		"append-results": &k.appendResults,
+		"frequency":      &k.frequency,
+		"markdown":       &k.markdown,
+		"whitespace":     &k.whitespace,
+		"ignore-eol":     &k.ignoreEOL,
+		"inspect":        &k.inspect,
+		"grow":           &k.grow,
+		"shrink":         &k.shrink,
+		"reset-layout":   &k.resetLayout,
+		"layout":         &k.layout,
+		"zoom":           &k.zoom,
+		"help":           &k.help,
+		"lock-scroll":    &k.lockScroll,
+		"swap":           &k.swap,
+		"clear-pane":     &k.clearPane,
+		"clear-all":      &k.clearAll,
+		"live":           &k.live,
+		"wrap":           &k.wrap,
+		"palette":        &k.palette,
+		"theme":          &k.theme,
+		"search":         &k.search,
+		"next-match":     &k.nextMatch,
+		"prev-match":     &k.prevMatch,
+		"copy":           &k.copy,
+		"paste":          &k.paste,
+		"undo":           &k.undo,
+		"redo":           &k.redo,
+	}
+}
+
+// keyNames returns the sorted action names of keyBindings.
+func keyNames() []string {
+	var names []string
+	for name := range keyBindings(&keymap{}) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"strcli/pkg/diff"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name, toml string
+		// wantErr is part of the error expected.
+		wantErr string
+	}{
+		{"empty", "", ""},
+		{"everything", `theme = "dracula"
+layout = "vertical"
+tab_width = 8
+
+[diff]
+unit = "rune"
+preset = "json"
+ignore_case = true
+ignore_line_endings = true
+
+[keys]
+compare = "ctrl+d"
+next = ["tab", "ctrl+n"]
+`, ""},
+		{"unknown setting", "colour = \"red\"", "unknown setting colour"},
+		{"unknown theme", `theme = "neon"`, `unknown theme "neon"`},
+		{"unknown layout", `layout = "diagonal"`, `unknown layout "diagonal"`},
+		{"negative tab width", "tab_width = -1", "invalid tab width -1"},
+		{"unknown unit", "[diff]\nunit = \"word\"", "word"},
+		{"unknown preset", "[diff]\npreset = \"nope\"", "nope"},
+		{"invalid timeout", "[diff]\ntimeout = \"soon\"", `invalid diff timeout "soon"`},
+		{"negative timeout", "[diff]\ntimeout = \"-1s\"", "invalid diff timeout -1s"},
+		{"unknown action", "[keys]\nfly = \"f1\"", `unknown action "fly" in keys`},
+		{"no keys", "[keys]\ncompare = []", "no keys for compare"},
+		{"keys of the wrong type", "[keys]\ncompare = 1", "keys must be a string or a list of strings"},
+		{"syntax error", "theme = ", "config: "},
+		{"key of another action", "[keys]\nundo = \"ctrl+r\"", "ctrl+r is bound to both"},
+		{"key bound twice", "[keys]\nundo = \"f12\"\nredo = [\"ctrl+y\", \"f12\"]", "f12 is bound to both"},
+		{"keys swapped", "[keys]\nundo = \"ctrl+y\"\nredo = \"ctrl+z\"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "-")+".toml")
+			if err := os.WriteFile(path, []byte(tt.toml), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := loadConfig(path)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefaultKeysDiffer(t *testing.T) {
+	if err := (config{}).checkKeys(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLoadConfigMissing(t *testing.T) {
+	if _, err := loadConfig(filepath.Join(t.TempDir(), "missing.toml")); err == nil {
+		t.Error("a missing config file given explicitly is not an error")
+	}
+	// TestMain points the state directory at an empty one.
+	if c, err := loadConfig(""); err != nil || !reflect.DeepEqual(c, config{}) {
+		t.Errorf("without a config file: %+v, %v", c, err)
+	}
+}
+
+func TestConfigApply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), configName)
+	os.WriteFile(path, []byte(`layout = "vertical"
+tab_width = 2
+
+[diff]
+unit = "byte"
+ignore_case = true
+
+[keys]
+compare = ["ctrl+d", "f12"]
+`), 0o644)
+	c, err := loadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m model
+	c.apply(&m)
+	if !m.vertical || m.tabWidth != 2 || m.options.Unit != diff.Byte || !m.options.IgnoreCase {
+		t.Errorf("vertical %v, tab width %d, options %+v", m.vertical, m.tabWidth, m.options)
+	}
+	if keys := m.keymap.compare.Keys(); !reflect.DeepEqual(keys, []string{"ctrl+d", "f12"}) || m.keymap.compare.Help().Key != "ctrl+d" {
+		t.Errorf("compare is bound to %q, shown as %q", keys, m.keymap.compare.Help().Key)
+	}
+}
+
+func TestConfigFlags(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.txt")
+	os.WriteFile(a, []byte("one\n"), 0o644)
+	cfg := filepath.Join(dir, "config.toml")
+	os.WriteFile(cfg, []byte("theme = \"monochrome\"\n[diff]\nignore_case = true\n"), 0o644)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+	}{
+		{"config", []string{"--config", cfg, "compare", a, "-"}, exitSame},
+		{"flag wins", []string{"--config", cfg, "compare", "--ignore-case=false", a, "-"}, exitDiffer},
+		{"theme flag wins", []string{"--config", cfg, "--theme", "neon", "compare", a, "-"}, exitError},
+		{"missing config", []string{"--config", filepath.Join(dir, "missing.toml"), "compare", a, "-"}, exitError},
+		{"config without a command", []string{"--config", cfg, a, "-"}, exitSame},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, code := runMain(t, "ONE\n", tt.args...); code != tt.wantCode {
+				t.Errorf("exit status = %d, want %d", code, tt.wantCode)
+			}
+		})
+	}
+}
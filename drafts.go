@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// While the TUI runs it saves its panes as a draft every draftInterval, into
+// a file of draftDir named after its process. The draft is removed on quit,
+// so a draft that is no longer kept up to date was left by a crash or a
+// dropped connection, and is offered for recovery.
+const (
+	draftDir      = "drafts"
+	draftInterval = 30 * time.Second
+)
+
+// draftMsg asks the model to save its draft.
+type draftMsg struct{}
+
+func draftCmd() tea.Cmd {
+	return tea.Tick(draftInterval, func(time.Time) tea.Msg { return draftMsg{} })
+}
+
+// draftName returns the name of the draft of this process.
+func draftName() string {
+	return filepath.Join(draftDir, fmt.Sprintf("%d.json", os.Getpid()))
+}
+
+// saveDraft saves the panes of m to its draft, even if unchanged, which
+// shows that the draft is still kept up to date. A failure is reported once
+// and then retried quietly.
+func (m *model) saveDraft() tea.Cmd {
+	s, empty := m.snapshot()
+	var err error
+	if empty {
+		err = removeState(m.draft)
+	} else {
+		err = saveState(m.draft, s)
+	}
+	if err != nil && !m.draftFailed {
+		m.err = fmt.Errorf("could not autosave: %w", err)
+	}
+	m.draftFailed = err != nil
+	return draftCmd()
+}
+
+// abandonedDraft returns the name and content of the newest draft that is
+// no longer kept up to date, or "" if there is none.
+func abandonedDraft() (string, *savedSession, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, draftDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	var name string
+	var newest time.Time
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		age := time.Since(info.ModTime())
+		if age > 2*draftInterval && info.ModTime().After(newest) {
+			name, newest = filepath.Join(draftDir, e.Name()), info.ModTime()
+		}
+	}
+	if name == "" {
+		return "", nil, nil
+	}
+	var s savedSession
+	if err := loadState(name, &s); err != nil {
+		return name, nil, err
+	}
+	return name, &s, nil
+}
+
+// recoverPrompt offers to recover a draft left by a TUI that did not quit.
+// The draft is removed either way.
+type recoverPrompt struct {
+	name  string
+	saved *savedSession
+}
+
+func (p recoverPrompt) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		m.err = removeState(p.name)
+		return true, m.restoreSession(p.saved)
+	case "n":
+		m.err = removeState(p.name)
+		return true, nil
+	case "esc":
+		return true, nil
+	}
+	return false, nil
+}
+
+func (p recoverPrompt) view(m *model) string {
+	return overlayStyle.Render(fmt.Sprintf("%s\n\nA session that did not quit normally left panes autosaved on %s.\n\ny/enter recover • n discard • esc ask again next time",
+		overlayTitleStyle.Render("Recover unsaved panes?"), p.saved.SavedAt.Format(time.DateTime)))
+}
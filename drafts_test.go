@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSaveDraft(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	m := newModel()
+	m.draft = draftName()
+	path := filepath.Join(dir, "strcli", m.draft)
+	m.setPane(0, "unsaved")
+	if cmd := m.saveDraft(); cmd == nil {
+		t.Error("saving a draft does not schedule the next one")
+	}
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Fatalf("saved draft: %v, %v", fi, err)
+	}
+	m.setPane(0, "")
+	m.saveDraft()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("empty panes keep the draft: %v", err)
+	}
+	if m.err != nil {
+		t.Errorf("error %v", m.err)
+	}
+}
+
+func TestAbandonedDraft(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	if name, s, err := abandonedDraft(); name != "" || s != nil || err != nil {
+		t.Fatalf("without drafts: %q, %v, %v", name, s, err)
+	}
+	save := func(name, text string, age time.Duration) {
+		t.Helper()
+		s := savedSession{Tabs: []savedTab{{Panes: [2]savedPane{{Text: text}}}}}
+		if err := saveState(filepath.Join(draftDir, name), s); err != nil {
+			t.Fatal(err)
+		}
+		at := time.Now().Add(-age)
+		if err := os.Chtimes(filepath.Join(dir, "strcli", draftDir, name), at, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	save("1.json", "fresh", 0)
+	if name, _, err := abandonedDraft(); name != "" || err != nil {
+		t.Fatalf("a draft kept up to date is offered: %q, %v", name, err)
+	}
+	save("2.json", "older", time.Hour)
+	save("3.json", "newer", 3*draftInterval)
+	save("4.txt", "other", time.Minute)
+
+	name, s, err := abandonedDraft()
+	if err != nil || s == nil {
+		t.Fatalf("abandoned draft: %q, %v, %v", name, s, err)
+	}
+	if name != filepath.Join(draftDir, "3.json") || s.Tabs[0].Panes[0].Text != "newer" {
+		t.Errorf("offered %q holding %q", name, s.Tabs[0].Panes[0].Text)
+	}
+}
+
+func TestRecoverPrompt(t *testing.T) {
+	tests := []struct {
+		key   tea.KeyMsg
+		want  string
+		keeps bool
+	}{
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}, "lost", false},
+		{tea.KeyMsg{Type: tea.KeyEnter}, "lost", false},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}, "", false},
+		{tea.KeyMsg{Type: tea.KeyEsc}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key.String(), func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", dir)
+			name := filepath.Join(draftDir, "1.json")
+			saved := &savedSession{Tabs: []savedTab{{Panes: [2]savedPane{{Text: "lost"}}, Unit: "rune"}}}
+			if err := saveState(name, saved); err != nil {
+				t.Fatal(err)
+			}
+
+			m := newModel()
+			m.overlay = recoverPrompt{name: name, saved: saved}
+			m, _ = update(m, tt.key)
+			if m.overlay != nil {
+				t.Fatal("the prompt is still open")
+			}
+			if got := m.inputs[0].Value(); got != tt.want {
+				t.Errorf("pane A holds %q, want %q", got, tt.want)
+			}
+			_, err := os.Stat(filepath.Join(dir, "strcli", name))
+			if kept := err == nil; kept != tt.keeps {
+				t.Errorf("draft kept %v, want %v", kept, tt.keeps)
+			}
+		})
+	}
+}
+
+func TestAutosaves(t *testing.T) {
+	no := false
+	if !(config{}).autosaves() || (config{Autosave: &no}).autosaves() {
+		t.Error("panes are autosaved unless autosave is false")
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+
+	"strcli/pkg/diff"
+	"strcli/pkg/transform"
+)
+
+// setPane shows text in pane i. Text areas only hold LF line endings, and
+// would turn each CR into a line break of its own, so the line endings of
+// the input panes are remembered to be put back by paneText. Tabs are
+// expanded to m.tabWidth, as text areas would replace each with a fixed
+// number of spaces.
+func (m *model) setPane(i int, text string) {
+	if i < len(m.eols) {
+		m.eols[i] = diff.LineEndings(text)
+	}
+	m.inputs[i].SetValue(transform.ExpandTabs(transform.ToLF(text), m.tabWidth))
+	m.edits++
+}
+
+// paneText returns the text of pane i with its line endings. Mixed line
+// endings cannot be restored and come back as LF.
+func (s *session) paneText(i int) string {
+	return s.withLineEndings(i, s.inputs[i].Value())
+}
+
+// withLineEndings returns text, as shown in pane i, with the line endings
+// of the pane.
+func (s *session) withLineEndings(i int, text string) string {
+	if i < len(s.eols) && s.eols[i] == "CRLF" {
+		return strings.ReplaceAll(text, "\n", "\r\n")
+	}
+	return text
+}
+
+// lineEndingsView shows the line endings of the input panes unless both
+// use LF.
+func (m *model) lineEndingsView() string {
+	a, b := m.eols[0], m.eols[1]
+	if (a == "LF" || a == "") && (b == "LF" || b == "") {
+		if m.options.IgnoreLineEndings {
+			return "ignoring line endings"
+		}
+		return ""
+	}
+	s := "line endings A " + a + ", B " + b
+	if m.options.IgnoreLineEndings {
+		s += " (ignored)"
+	}
+	return s
+}
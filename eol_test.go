@@ -0,0 +1,50 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPaneLineEndings(t *testing.T) {
+	tests := []struct {
+		name, in, value, back, view string
+	}{
+		{"LF", "a\nb\n", "a\nb\n", "a\nb\n", ""},
+		{"CRLF", "a\r\nb\r\n", "a\nb\n", "a\r\nb\r\n", "line endings A CRLF, B LF"},
+		{"mixed", "a\r\nb\n", "a\nb\n", "a\nb\n", "line endings A mixed, B LF"},
+		{"none", "a", "a", "a", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.setPane(1, "b\n")
+			m.setPane(0, tt.in)
+			if got := m.inputs[0].Value(); got != tt.value {
+				t.Errorf("pane shows %q, want %q", got, tt.value)
+			}
+			if got := m.paneText(0); got != tt.back {
+				t.Errorf("paneText = %q, want %q", got, tt.back)
+			}
+			if got := m.lineEndingsView(); got != tt.view {
+				t.Errorf("lineEndingsView() = %q, want %q", got, tt.view)
+			}
+		})
+	}
+}
+
+func TestIgnoreLineEndingsKey(t *testing.T) {
+	m := newModel()
+	m.setPane(0, "a\r\nb\r\n")
+	m.setPane(1, "a\nb\n")
+	m = settle(m, alt('l'))
+	if !m.options.IgnoreLineEndings || !m.diff.Equal() {
+		t.Errorf("IgnoreLineEndings = %v, Equal() = %v, want both", m.options.IgnoreLineEndings, m.diff.Equal())
+	}
+	if v := m.View(); !strings.Contains(v, "line endings A CRLF, B LF (ignored)") {
+		t.Errorf("the help line does not show the ignored line endings:\n%s", v)
+	}
+	m = settle(m, alt('l'))
+	if m.options.IgnoreLineEndings || m.diff.Equal() {
+		t.Errorf("IgnoreLineEndings = %v, Equal() = %v, want neither", m.options.IgnoreLineEndings, m.diff.Equal())
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Choices for each export option. The first entry of each is the default.
+var (
+	eolChoices          = []string{"keep", "lf", "crlf"}
+	encodingChoices     = []string{"utf-8", "utf-8-bom", "utf-16le", "utf-16be"}
+	finalNewlineChoices = []string{"keep", "add", "remove"}
+)
+
+// exportOptions controls how text is converted when it is written to a file.
+type exportOptions struct {
+	eol, encoding, finalNewline string
+}
+
+// encode converts s to the line endings, final newline and encoding of o.
+func (o exportOptions) encode(s string) []byte {
+	switch o.eol {
+	case "lf":
+		s = strings.ReplaceAll(s, "\r\n", "\n")
+	case "crlf":
+		s = strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\n", "\r\n")
+	}
+
+	newline := "\n"
+	if o.eol == "crlf" || (o.eol != "lf" && strings.Contains(s, "\r\n")) {
+		newline = "\r\n"
+	}
+	switch o.finalNewline {
+	case "add":
+		if !strings.HasSuffix(s, "\n") && s != "" {
+			s += newline
+		}
+	case "remove":
+		if t, ok := strings.CutSuffix(s, "\r\n"); ok {
+			s = t
+		} else {
+			s = strings.TrimSuffix(s, "\n")
+		}
+	}
+
+	switch o.encoding {
+	case "utf-8-bom":
+		return append([]byte("\uFEFF"), s...)
+	case "utf-16le", "utf-16be":
+		var order binary.ByteOrder = binary.LittleEndian
+		if o.encoding == "utf-16be" {
+			order = binary.BigEndian
+		}
+		units := utf16.Encode([]rune("\uFEFF" + s))
+		b := make([]byte, 2*len(units))
+		for i, u := range units {
+			order.PutUint16(b[2*i:], u)
+		}
+		return b
+	}
+	return []byte(s)
+}
+
+func (o exportOptions) String() string {
+	return fmt.Sprintf("line endings: %s • encoding: %s • final newline: %s", o.eol, o.encoding, o.finalNewline)
+}
+
+var (
+	exportEOL          = key.NewBinding(key.WithKeys("alt+l"), key.WithHelp("alt+l", "line endings"))
+	exportEncoding     = key.NewBinding(key.WithKeys("alt+e"), key.WithHelp("alt+e", "encoding"))
+	exportFinalNewline = key.NewBinding(key.WithKeys("alt+n"), key.WithHelp("alt+n", "final newline"))
+)
+
+// exportPrompt asks for a file name and the conversions to apply, then
+// writes the text returned by content to that file.
+type exportPrompt struct {
+	title   string
+	input   textinput.Model
+	opts    exportOptions
+	content func(m *model) string
+}
+
+func newExportPrompt(title, path string, content func(m *model) string) *exportPrompt {
+	t := textinput.New()
+	t.SetValue(path)
+	t.Focus()
+	return &exportPrompt{
+		title:   title,
+		input:   t,
+		opts:    exportOptions{eol: eolChoices[0], encoding: encodingChoices[0], finalNewline: finalNewlineChoices[0]},
+		content: content,
+	}
+}
+
+// cycle returns the choice after cur.
+func cycle(choices []string, cur string) string {
+	for i, c := range choices {
+		if c == cur {
+			return choices[(i+1)%len(choices)]
+		}
+	}
+	return choices[0]
+}
+
+func (p *exportPrompt) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEsc:
+		return true, nil
+	case msg.Type == tea.KeyEnter:
+		m.err = os.WriteFile(p.input.Value(), p.opts.encode(p.content(m)), 0o644)
+		if m.err == nil {
+			m.notice = "wrote " + p.input.Value()
+		}
+		return true, nil
+	case key.Matches(msg, exportEOL):
+		p.opts.eol = cycle(eolChoices, p.opts.eol)
+		return false, nil
+	case key.Matches(msg, exportEncoding):
+		p.opts.encoding = cycle(encodingChoices, p.opts.encoding)
+		return false, nil
+	case key.Matches(msg, exportFinalNewline):
+		p.opts.finalNewline = cycle(finalNewlineChoices, p.opts.finalNewline)
+		return false, nil
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return false, cmd
+}
+
+// savePrompt asks where to save the focused pane: an input pane, by default
+// to the file it was loaded from, or the result like the export key does.
+func (m *model) savePrompt() *exportPrompt {
+	i := m.focus
+	if i == len(m.inputs)-1 {
+		return newExportPrompt("Save result to file", "diff."+m.format, (*model).exportedResult)
+	}
+	path := m.paths[i]
+	if path == "" || path == "-" {
+		path = fmt.Sprintf("pane-%c.txt", 'a'+i)
+	}
+	return newExportPrompt(fmt.Sprintf("Save pane %c to file", 'A'+i), path, func(m *model) string {
+		return m.paneText(i)
+	})
+}
+
+func (p *exportPrompt) view(m *model) string {
+	p.input.Width = m.width - 8
+	keys := m.help.ShortHelpView([]key.Binding{exportEOL, exportEncoding, exportFinalNewline})
+	return overlayStyle.Render(overlayTitleStyle.Render(p.title) + "\n" + p.input.View() + "\n\n" + p.opts.String() + "\n" + keys)
+}
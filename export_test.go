@@ -0,0 +1,134 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		opts exportOptions
+		in   string
+		want string
+	}{
+		{"defaults", exportOptions{"keep", "utf-8", "keep"}, "a\r\nb\n", "a\r\nb\n"},
+		{"to lf", exportOptions{"lf", "utf-8", "keep"}, "a\r\nb\r\n", "a\nb\n"},
+		{"to crlf", exportOptions{"crlf", "utf-8", "keep"}, "a\r\nb\n", "a\r\nb\r\n"},
+		{"add newline", exportOptions{"keep", "utf-8", "add"}, "a\nb", "a\nb\n"},
+		{"add crlf newline", exportOptions{"keep", "utf-8", "add"}, "a\r\nb", "a\r\nb\r\n"},
+		{"add to empty", exportOptions{"keep", "utf-8", "add"}, "", ""},
+		{"add when present", exportOptions{"keep", "utf-8", "add"}, "a\n", "a\n"},
+		{"remove newline", exportOptions{"keep", "utf-8", "remove"}, "a\nb\n", "a\nb"},
+		{"remove crlf newline", exportOptions{"crlf", "utf-8", "remove"}, "a\nb\n", "a\r\nb"},
+		{"remove one newline", exportOptions{"keep", "utf-8", "remove"}, "a\n\n", "a\n"},
+		{"remove one crlf newline", exportOptions{"keep", "utf-8", "remove"}, "a\r\n\r\n", "a\r\n"},
+		{"remove keeps a carriage return", exportOptions{"keep", "utf-8", "remove"}, "a\r", "a\r"},
+		{"bom", exportOptions{"keep", "utf-8-bom", "keep"}, "é", "\xef\xbb\xbf\xc3\xa9"},
+		{"utf-16le", exportOptions{"keep", "utf-16le", "keep"}, "aé", "\xff\xfea\x00\xe9\x00"},
+		{"utf-16be", exportOptions{"keep", "utf-16be", "keep"}, "a😀", "\xfe\xff\x00a\xd8\x3d\xde\x00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.opts.encode(tt.in)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportOptionKeys(t *testing.T) {
+	tests := []struct {
+		keys []rune
+		want exportOptions
+	}{
+		{nil, exportOptions{"keep", "utf-8", "keep"}},
+		{[]rune("l"), exportOptions{"lf", "utf-8", "keep"}},
+		{[]rune("lll"), exportOptions{"keep", "utf-8", "keep"}},
+		{[]rune("ee"), exportOptions{"keep", "utf-16le", "keep"}},
+		{[]rune("nnl"), exportOptions{"lf", "utf-8", "remove"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.keys), func(t *testing.T) {
+			m := newModel()
+			m.inputs[0].SetValue("a")
+			m = compareNow(m)
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlX})
+			for _, r := range tt.keys {
+				m, _ = update(m, alt(r))
+			}
+			p := m.overlay.(*exportPrompt)
+			if p.opts != tt.want {
+				t.Fatalf("options = %+v, want %+v", p.opts, tt.want)
+			}
+			path := filepath.Join(t.TempDir(), "out")
+			p.input.SetValue(path)
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := tt.want.encode(m.render(m.diff)); string(b) != string(want) {
+				t.Errorf("wrote %q, want %q", b, want)
+			}
+		})
+	}
+}
+
+func TestSavePrompt(t *testing.T) {
+	dir := t.TempDir()
+	loaded := filepath.Join(dir, "loaded.txt")
+	tests := []struct {
+		name    string
+		focus   int
+		path    string
+		suggest string
+		want    string
+	}{
+		{"loaded pane", 0, loaded, loaded, "one\r\ntwo"},
+		{"new pane", 1, "", "pane-b.txt", "three"},
+		{"standard input", 1, "-", "pane-b.txt", "three"},
+		{"result", 2, "", "diff.plain", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.eols[0] = "CRLF"
+			m.setPane(0, "one\r\ntwo")
+			m.setPane(1, "three")
+			m.paths[0], m.paths[1] = loaded, tt.path
+			m.focus = tt.focus
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+			p, ok := m.overlay.(*exportPrompt)
+			if !ok {
+				t.Fatalf("overlay = %T, want the export prompt", m.overlay)
+			}
+			if got := p.input.Value(); got != tt.suggest {
+				t.Errorf("suggested %q, want %q", got, tt.suggest)
+			}
+			path := filepath.Join(dir, "saved")
+			p.input.SetValue(path)
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+			if m.err != nil {
+				t.Fatal(m.err)
+			}
+			if m.notice != "wrote "+path {
+				t.Errorf("notice %q", m.notice)
+			}
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := tt.want
+			if tt.focus == 2 {
+				want = m.render(m.diff)
+			}
+			if string(b) != want {
+				t.Errorf("saved %q, want %q", b, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/charmbracelet/bubbles/filepicker"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// filePicker browses the file system for a file to load into an input pane.
+type filePicker struct {
+	pane   int
+	picker filepicker.Model
+}
+
+// newFilePicker opens a file picker for the focused pane, in the directory
+// of the file it was loaded from.
+func newFilePicker(m *model) (overlay, tea.Cmd) {
+	if m.focus > 1 {
+		m.err = errNoInputPane
+		return nil, nil
+	}
+	p := filepicker.New()
+	p.CurrentDirectory = "."
+	if path := m.paths[m.focus]; path != "" && path != "-" {
+		p.CurrentDirectory = filepath.Dir(path)
+	}
+	p.AutoHeight = false
+	p.Height = max(m.height-8, 3)
+	// esc closes the picker rather than going up a directory.
+	p.KeyMap.Back.SetKeys("h", "backspace", "left")
+	p.Styles.Cursor = selectedStyle
+	p.Styles.Selected = selectedStyle.Copy().Bold(true)
+	p.Styles.Directory = p.Styles.Directory.Copy().Foreground(currentTheme.Note)
+	p.Styles.Symlink = p.Styles.Symlink.Copy().Foreground(currentTheme.Insert)
+	p.Styles.Permission = p.Styles.Permission.Copy().Foreground(currentTheme.Subtle)
+	p.Styles.FileSize = p.Styles.FileSize.Copy().Foreground(currentTheme.Subtle)
+	p.Styles.EmptyDirectory = p.Styles.EmptyDirectory.Copy().Foreground(currentTheme.Subtle)
+	return &filePicker{pane: m.focus, picker: p}, p.Init()
+}
+
+func (f *filePicker) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	if msg.String() == "esc" || msg.String() == "q" {
+		return true, nil
+	}
+	var cmd tea.Cmd
+	f.picker, cmd = f.picker.Update(msg)
+	if ok, path := f.picker.DidSelectFile(msg); ok {
+		m.paths[f.pane] = path
+		return true, m.startLoad(f.pane, func() (string, error) {
+			return readInput(path)
+		})
+	}
+	return false, cmd
+}
+
+// receive hands the picker the messages other than keys, which bring it
+// the entries of the directory it reads.
+func (f *filePicker) receive(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	f.picker, cmd = f.picker.Update(msg)
+	return cmd
+}
+
+func (f *filePicker) view(m *model) string {
+	dir, err := filepath.Abs(f.picker.CurrentDirectory)
+	if err != nil {
+		dir = f.picker.CurrentDirectory
+	}
+	title := overlayTitleStyle.Render(fmt.Sprintf("Load a file into pane %c", 'A'+f.pane))
+	return overlayStyle.Render(title + "\n" + dir + "\n\n" + f.picker.View() + "\n↑/↓ select • →/enter open • ← up • esc close")
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFilePicker(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte("first"), 0o644)
+	os.WriteFile(filepath.Join(dir, "b.txt"), []byte("second"), 0o644)
+
+	m := newModel()
+	m, _ = update(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+	m.focus = 1
+	m.paths[1] = filepath.Join(dir, "a.txt")
+	m = settle(m, tea.KeyMsg{Type: tea.KeyCtrlO})
+	f, ok := m.overlay.(*filePicker)
+	if !ok {
+		t.Fatalf("overlay = %T, want the file picker", m.overlay)
+	}
+	if f.picker.CurrentDirectory != dir {
+		t.Errorf("opened in %q, want the directory of the pane's file", f.picker.CurrentDirectory)
+	}
+	m = settle(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = settle(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.overlay != nil {
+		t.Fatal("choosing a file does not close the picker")
+	}
+	if got := m.inputs[1].Value(); got != "second" {
+		t.Errorf("pane B holds %q", got)
+	}
+	if want := filepath.Join(dir, "b.txt"); m.paths[1] != want {
+		t.Errorf("pane B is from %q, want %q", m.paths[1], want)
+	}
+}
+
+func TestFilePickerClose(t *testing.T) {
+	for _, k := range []tea.KeyMsg{{Type: tea.KeyEsc}, {Type: tea.KeyRunes, Runes: []rune{'q'}}} {
+		m := newModel()
+		m = settle(m, tea.KeyMsg{Type: tea.KeyCtrlO})
+		m = settle(m, k)
+		if m.overlay != nil {
+			t.Errorf("%s does not close the picker", k)
+		}
+	}
+	m := newModel()
+	m.focus = len(m.inputs) - 1
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlO})
+	if m.overlay != nil || m.err != errNoInputPane {
+		t.Errorf("opening a file for the result pane: %v", m.err)
+	}
+}
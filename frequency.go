@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+	"strcli/pkg/transform"
+)
+
+// frequency is how often an item occurs in a text.
+type frequency struct {
+	item  string
+	count int
+}
+
+// countFrequencies counts items and sorts them by count, most frequent
+// first, then by item.
+func countFrequencies(items []string) []frequency {
+	counts := map[string]int{}
+	for _, it := range items {
+		counts[it]++
+	}
+	freqs := make([]frequency, 0, len(counts))
+	for it, n := range counts {
+		freqs = append(freqs, frequency{it, n})
+	}
+	sort.Slice(freqs, func(i, j int) bool {
+		if freqs[i].count != freqs[j].count {
+			return freqs[i].count > freqs[j].count
+		}
+		return freqs[i].item < freqs[j].item
+	})
+	return freqs
+}
+
+// characters splits s into grapheme clusters.
+func characters(s string) []string {
+	var chars []string
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		chars = append(chars, g.Str())
+	}
+	return chars
+}
+
+// visibleChar shows whitespace and control characters, which would be
+// invisible in a table, by symbol or code point.
+func visibleChar(c string) string {
+	switch c {
+	case " ":
+		return "␠ (space)"
+	case "\n":
+		return "↵ (line feed)"
+	case "\r\n":
+		return "␍↵ (CRLF)"
+	case "\t":
+		return "→ (tab)"
+	}
+	if r := []rune(c); len(r) == 1 && (r[0] < 0x20 || r[0] == 0x7F || r[0] == 0xA0 || r[0] == 0xFEFF || r[0] >= 0x200B && r[0] <= 0x200F) {
+		return fmt.Sprintf("U+%04X", r[0])
+	}
+	return c
+}
+
+// frequencyScreen shows how often each character or word of the focused
+// pane occurs.
+type frequencyScreen struct {
+	pane   int
+	words  bool
+	freqs  []frequency
+	total  int
+	offset int
+}
+
+func newFrequencyScreen(m *model) overlay {
+	if m.focus > 1 {
+		m.err = errNoInputPane
+		return nil
+	}
+	s := &frequencyScreen{pane: m.focus}
+	s.count(m)
+	return s
+}
+
+// count tallies the characters or words of the pane.
+func (s *frequencyScreen) count(m *model) {
+	text := m.inputs[s.pane].Value()
+	var items []string
+	if s.words {
+		for _, w := range textWords(text) {
+			items = append(items, transform.Fold(w))
+		}
+	} else {
+		items = characters(text)
+	}
+	s.freqs, s.total, s.offset = countFrequencies(items), len(items), 0
+}
+
+func (s *frequencyScreen) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	page := max(m.height-12, 1)
+	switch msg.String() {
+	case "esc", "q":
+		return true, nil
+	case "tab", "w", "c":
+		s.words = !s.words
+		s.count(m)
+	case "up", "k":
+		s.offset--
+	case "down", "j":
+		s.offset++
+	case "pgup":
+		s.offset -= page
+	case "pgdown", " ":
+		s.offset += page
+	case "home", "g":
+		s.offset = 0
+	case "end", "G":
+		s.offset = len(s.freqs)
+	}
+	s.offset = max(min(s.offset, len(s.freqs)-page), 0)
+	return false, nil
+}
+
+func (s *frequencyScreen) view(m *model) string {
+	kind, other := "Characters", "words"
+	if s.words {
+		kind, other = "Words", "characters"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", overlayTitleStyle.Render(fmt.Sprintf("%s of pane %c by frequency", kind, 'A'+s.pane)))
+	fmt.Fprintf(&b, "%d in total, %d distinct\n\n", s.total, len(s.freqs))
+	const barWidth = 20
+	end := min(s.offset+max(m.height-12, 1), len(s.freqs))
+	for i, f := range s.freqs[s.offset:end] {
+		share := float64(f.count) / float64(s.total)
+		bar := strings.Repeat("█", int(share*barWidth+0.5))
+		item := runewidth.FillRight(runewidth.Truncate(visibleChar(f.item), 20, "…"), 20)
+		fmt.Fprintf(&b, "%5d  %s %7d %6.2f%%  %s\n", s.offset+i+1, item, f.count, 100*share, selectedStyle.Render(bar))
+	}
+	if len(s.freqs) == 0 {
+		b.WriteString("Nothing to count\n")
+	}
+	fmt.Fprintf(&b, "\n↑/↓ scroll • tab count %s • esc close", other)
+	return overlayStyle.Render(b.String())
+}
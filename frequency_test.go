@@ -0,0 +1,90 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCountFrequencies(t *testing.T) {
+	got := countFrequencies([]string{"b", "a", "c", "b", "a", "b"})
+	want := []frequency{{"b", 3}, {"a", 2}, {"c", 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestVisibleChar(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"a", "a"},
+		{" ", "␠ (space)"},
+		{"\r\n", "␍↵ (CRLF)"},
+		{"\t", "→ (tab)"},
+		{"\x00", "U+0000"},
+		{"\u00a0", "U+00A0"},
+		{"\u200b", "U+200B"},
+		{"\U0001F44D\U0001F3FD", "\U0001F44D\U0001F3FD"},
+	}
+	for _, tt := range tests {
+		if got := visibleChar(tt.in); got != tt.want {
+			t.Errorf("visibleChar(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFrequencyScreen(t *testing.T) {
+	m := newModel()
+	m.width, m.height = 80, 30
+	m.inputs[0].SetValue("The cat and the hat.\nThe end")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyF9})
+	s, ok := m.overlay.(*frequencyScreen)
+	if !ok {
+		t.Fatalf("overlay %T, want the frequency screen", m.overlay)
+	}
+	if s.total != 28 || s.freqs[0] != (frequency{" ", 5}) {
+		t.Errorf("counted %d characters, first %v", s.total, s.freqs[0])
+	}
+	if v := m.View(); !strings.Contains(v, "Characters of pane A by frequency") || !strings.Contains(v, "␠ (space)") {
+		t.Errorf("shows\n%s", v)
+	}
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyTab})
+	want := []frequency{{"the", 3}, {"and", 1}, {"cat", 1}, {"end", 1}, {"hat", 1}}
+	if !s.words || s.total != 7 || !reflect.DeepEqual(s.freqs, want) {
+		t.Errorf("words %v: counted %d, %v, want %v", s.words, s.total, s.freqs, want)
+	}
+	if v := m.View(); !strings.Contains(v, "7 in total, 5 distinct") {
+		t.Errorf("shows\n%s", v)
+	}
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.overlay != nil {
+		t.Error("esc does not close the screen")
+	}
+}
+
+func TestFrequencyScreenScrolls(t *testing.T) {
+	m := newModel()
+	m.height = 15 // a page of 3 rows
+	m.inputs[0].SetValue("abcdefgh")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyF9})
+	s := m.overlay.(*frequencyScreen)
+	for _, tt := range []struct {
+		key  tea.KeyMsg
+		want int
+	}{
+		{tea.KeyMsg{Type: tea.KeyDown}, 1},
+		{tea.KeyMsg{Type: tea.KeyPgDown}, 4},
+		{tea.KeyMsg{Type: tea.KeyPgDown}, 5},
+		{tea.KeyMsg{Type: tea.KeyHome}, 0},
+		{tea.KeyMsg{Type: tea.KeyUp}, 0},
+		{tea.KeyMsg{Type: tea.KeyEnd}, 5},
+	} {
+		m, _ = update(m, tt.key)
+		if s.offset != tt.want {
+			t.Errorf("after %v: offset %d, want %d", tt.key, s.offset, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strcli/pkg/hash"
+)
+
+var selectedStyle = lipgloss.NewStyle()
+
+// hashScreen shows the digest of the focused pane in every algorithm and
+// copies the selected one.
+type hashScreen struct {
+	pane     int
+	sums     []string
+	selected int
+}
+
+func newHashScreen(m *model) overlay {
+	if m.focus > 1 {
+		m.err = errNoInputPane
+		return nil
+	}
+	s := &hashScreen{pane: m.focus}
+	for _, a := range hash.Algorithms {
+		s.sums = append(s.sums, a.Sum(m.paneText(m.focus)))
+	}
+	return s
+}
+
+func (s *hashScreen) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		return true, nil
+	case "up", "k":
+		s.selected = (s.selected + len(s.sums) - 1) % len(s.sums)
+	case "down", "j":
+		s.selected = (s.selected + 1) % len(s.sums)
+	case "enter", "c":
+		if err := copyText(s.sums[s.selected]); err != nil {
+			m.err = fmt.Errorf("copy: %w", err)
+		} else {
+			m.notice = "copied " + hash.Algorithms[s.selected].Name + " digest"
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (s *hashScreen) view(m *model) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", overlayTitleStyle.Render(fmt.Sprintf("Digests of pane %c", 'A'+s.pane)))
+	for i, a := range hash.Algorithms {
+		line := fmt.Sprintf("%-7s %s", a.Name, s.sums[i])
+		if i == s.selected {
+			line = selectedStyle.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n↑/↓ select • enter copy • esc close")
+	return overlayStyle.Render(b.String())
+}
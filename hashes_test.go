@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/hash"
+)
+
+func TestHashScreen(t *testing.T) {
+	m := newModel()
+	m.inputs[0].SetValue("abc")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyF3})
+	s, ok := m.overlay.(*hashScreen)
+	if !ok {
+		t.Fatalf("overlay = %T, want the hash screen", m.overlay)
+	}
+	view := s.view(&m)
+	for _, a := range hash.Algorithms {
+		if !strings.Contains(view, a.Sum("abc")) {
+			t.Errorf("the %s digest is not shown", a.Name)
+		}
+	}
+	tests := []struct {
+		key  tea.KeyMsg
+		want int
+	}{
+		{tea.KeyMsg{Type: tea.KeyUp}, len(hash.Algorithms) - 1},
+		{tea.KeyMsg{Type: tea.KeyDown}, 0},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}, 1},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}}, 0},
+	}
+	for _, tt := range tests {
+		m, _ = update(m, tt.key)
+		if s.selected != tt.want {
+			t.Errorf("after %s: selected %d, want %d", tt.key, s.selected, tt.want)
+		}
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.overlay != nil {
+		t.Error("esc did not close the hash screen")
+	}
+}
+
+func TestHashScreenNeedsInputPane(t *testing.T) {
+	m := newModel()
+	m.focus = 2
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyF3})
+	if m.overlay != nil || m.err != errNoInputPane {
+		t.Errorf("overlay = %v, err = %v; want no overlay and %v", m.overlay, m.err, errNoInputPane)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpGroup is a titled group of key bindings on the help screen.
+type helpGroup struct {
+	title string
+	keys  []key.Binding
+}
+
+// helpGroups returns every binding of k, grouped by what they act on.
+func helpGroups(k *keymap) []helpGroup {
+	return []helpGroup{
+		{"Panes", []key.Binding{k.next, k.prev, k.zoom, k.grow, k.shrink, k.resetLayout, k.layout, k.lockScroll, k.swap, k.hide, k.quit}},
+		{"Tabs", []key.Binding{k.newTab, k.closeTab, k.nextTab, k.prevTab}},
+		{"Comparing", []key.Binding{k.compare, k.live, k.unit, k.ignoreCase, k.ignoreEOL, k.preset, k.template, k.overlap, k.match, k.appendResults, k.roundTrip}},
+		{"Editing", []key.Binding{k.undo, k.redo, k.copy, k.paste, k.restore, k.transform, k.replace, k.clearPane, k.clearAll, k.whitespace, k.wrap}},
+		{"Searching", []key.Binding{k.search, k.nextMatch, k.prevMatch}},
+		{"Files", []key.Binding{k.open, k.save, k.export}},
+		{"Tools", []key.Binding{k.stats, k.textStats, k.hashes, k.inspect, k.frequency, k.ids, k.regexTester, k.colorCheck, k.markdown, k.present, k.theme}},
+		{"Help", []key.Binding{k.palette, k.help, k.dismissTip}},
+	}
+}
+
+// helpScreen lists every key binding, grouped, in as many columns as fit.
+type helpScreen struct {
+	offset int
+}
+
+func (h *helpScreen) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	if key.Matches(msg, m.keymap.help) {
+		return true, nil
+	}
+	page := max(m.height-8, 1)
+	switch msg.String() {
+	case "esc", "q":
+		return true, nil
+	case "up", "k":
+		h.offset--
+	case "down", "j":
+		h.offset++
+	case "pgup":
+		h.offset -= page
+	case "pgdown", " ":
+		h.offset += page
+	}
+	h.offset = max(min(h.offset, len(h.lines(m))-page), 0)
+	return false, nil
+}
+
+// lines lays out the groups in rows of columns as wide as the window
+// allows.
+func (h *helpScreen) lines(m *model) []string {
+	var blocks []string
+	for _, g := range helpGroups(&m.keymap) {
+		width := 0
+		for _, b := range g.keys {
+			width = max(width, lipgloss.Width(b.Help().Key))
+		}
+		lines := []string{overlayTitleStyle.Render(g.title)}
+		for _, b := range g.keys {
+			if b.Enabled() {
+				lines = append(lines, fmt.Sprintf("%-*s  %s", width, b.Help().Key, b.Help().Desc))
+			}
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+
+	const gap = "    "
+	var rows []string
+	var row []string
+	rowWidth := 0
+	for _, b := range blocks {
+		w := lipgloss.Width(b) + len(gap)
+		if len(row) > 0 && rowWidth+w > m.width-4 {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
+		}
+		row = append(row, b, gap)
+		rowWidth += w
+	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	return strings.Split(strings.Join(rows, "\n\n"), "\n")
+}
+
+func (h *helpScreen) view(m *model) string {
+	lines := h.lines(m)
+	end := min(h.offset+max(m.height-8, 1), len(lines))
+	return overlayStyle.Render(overlayTitleStyle.Render("Keys") + "\n\n" + strings.Join(lines[h.offset:end], "\n") + "\n\n↑/↓ scroll • esc close")
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestHelpGroupsListEveryKey(t *testing.T) {
+	m := newModel()
+	listed := map[string]int{}
+	for _, g := range helpGroups(&m.keymap) {
+		for _, b := range g.keys {
+			listed[b.Help().Desc]++
+		}
+	}
+	for name, b := range keyBindings(&m.keymap) {
+		if n := listed[b.Help().Desc]; n != 1 {
+			t.Errorf("%s is listed %d times", name, n)
+		}
+	}
+}
+
+func TestHelpScreen(t *testing.T) {
+	m := newModel()
+	m, _ = update(m, tea.WindowSizeMsg{Width: 80, Height: 20})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyF1})
+	h, ok := m.overlay.(*helpScreen)
+	if !ok {
+		t.Fatalf("overlay = %T, want the help screen", m.overlay)
+	}
+	for _, l := range h.lines(&m) {
+		if w := lipgloss.Width(l); w > 80 {
+			t.Errorf("line %q is %d wide", l, w)
+		}
+	}
+	if v := h.view(&m); !strings.Contains(v, "Tabs") || !strings.Contains(v, "new tab") {
+		t.Errorf("the first groups are not shown:\n%s", v)
+	}
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyUp})
+	if h.offset != 0 {
+		t.Errorf("scrolled above the top to %d", h.offset)
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyPgDown})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyPgDown})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyPgDown})
+	if last := len(h.lines(&m)) - (m.height - 8); h.offset != last {
+		t.Errorf("scrolled to %d, want the last page at %d", h.offset, last)
+	}
+
+	for _, k := range []tea.KeyMsg{{Type: tea.KeyEsc}, {Type: tea.KeyF1}, {Type: tea.KeyRunes, Runes: []rune{'q'}}} {
+		m.overlay = &helpScreen{}
+		m, _ = update(m, k)
+		if m.overlay != nil {
+			t.Errorf("%s does not close the help screen", k)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newHistoryCmd() *cobra.Command {
+	var of compareFlags
+	var file string
+	cmd := &cobra.Command{
+		Use:   "history [N M]",
+		Short: "Re-run two shell history entries and compare their output",
+		Long: `Re-run the shell history entries numbered N and M and compare what they
+print. Negative numbers count back from the most recent entry, so -1 is the
+last command; put -- before them so they are not read as flags, as in
+"strcli history -- -3 -1". Without arguments, the most recent entries are
+listed with their numbers.
+
+History is read from $HISTFILE, or else from ~/.zsh_history or
+~/.bash_history. Commands are run with $SHELL -c. Entries that run
+strcli history themselves are refused.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 && len(args) != 2 {
+				return errors.New("history takes no arguments or two entry numbers")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file == "" {
+				file = historyFile()
+			}
+			entries, err := readHistory(file)
+			if err != nil {
+				return err
+			}
+			if len(args) == 0 {
+				for i := max(0, len(entries)-20); i < len(entries); i++ {
+					fmt.Fprintf(cmd.OutOrStdout(), "%5d  %s\n", i+1, entries[i])
+				}
+				return nil
+			}
+			texts := make([]string, 2)
+			for i, arg := range args {
+				command, err := historyEntry(entries, arg)
+				if err != nil {
+					return err
+				}
+				if runsHistory(command) {
+					return fmt.Errorf("history entry %s runs strcli history itself: %s", arg, command)
+				}
+				if texts[i], err = runShell(command); err != nil {
+					return fmt.Errorf("%s: %w", command, err)
+				}
+			}
+			return compareTexts(cmd, texts, nil, of)
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "read history from `file`")
+	of.register(cmd)
+	return cmd
+}
+
+// historyFile guesses where the user's shell keeps its history.
+func historyFile() string {
+	if f := os.Getenv("HISTFILE"); f != "" {
+		return f
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	for _, name := range []string{".zsh_history", ".bash_history"} {
+		path := filepath.Join(home, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(home, ".bash_history")
+}
+
+// readHistory returns the commands in a bash or zsh history file, oldest
+// first.
+func readHistory(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []string
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "#") && isDigits(line[1:]):
+			// bash timestamp comment
+			continue
+		case strings.HasPrefix(line, ": "):
+			// zsh extended history: ": <start>:<elapsed>;<command>"
+			if i := strings.IndexByte(line, ';'); i >= 0 {
+				line = line[i+1:]
+			}
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			entries = append(entries, line)
+		}
+	}
+	return entries, sc.Err()
+}
+
+// historyEntry returns the entry numbered n, counting from 1, or from the
+// end if n is negative.
+func historyEntry(entries []string, n string) (string, error) {
+	i, err := strconv.Atoi(n)
+	if err != nil {
+		return "", fmt.Errorf("invalid history entry %q", n)
+	}
+	if i < 0 {
+		i += len(entries) + 1
+	}
+	if i < 1 || i > len(entries) {
+		return "", fmt.Errorf("no history entry %s", n)
+	}
+	return entries[i-1], nil
+}
+
+// runsHistory reports whether command runs "strcli history", which would
+// re-run history entries over and over.
+func runsHistory(command string) bool {
+	self := filepath.Base(os.Args[0])
+	simple := strings.FieldsFunc(command, func(r rune) bool { return strings.ContainsRune(";|&()`\n", r) })
+	for _, c := range simple {
+		words := strings.Fields(c)
+		for len(words) > 0 && strings.Contains(words[0], "=") {
+			// variable assignments before the command
+			words = words[1:]
+		}
+		if len(words) == 0 {
+			continue
+		}
+		if name := filepath.Base(words[0]); name != "strcli" && name != self {
+			continue
+		}
+		for _, w := range words[1:] {
+			if w == "history" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// runShell runs command with the user's shell and returns what it printed
+// to standard output and standard error. A non-zero exit status is part of
+// the output, not an error.
+func runShell(command string) (string, error) {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+	out, err := exec.Command(shell, "-c", command).CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Sprintf("%s[exit status %d]\n", out, exitErr.ExitCode()), nil
+	}
+	return string(out), err
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
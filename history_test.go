@@ -0,0 +1,130 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadHistory(t *testing.T) {
+	tests := []struct {
+		name, file string
+		want       []string
+	}{
+		{"bash", "ls\necho a\n\necho b\n", []string{"ls", "echo a", "echo b"}},
+		{"bash with timestamps", "#1700000000\nls\n#1700000001\necho a\n", []string{"ls", "echo a"}},
+		{"zsh extended", ": 1700000000:0;ls -l\n: 1700000001:2;echo a; echo b\n", []string{"ls -l", "echo a; echo b"}},
+		{"comment that is not a timestamp", "#todo\n", []string{"#todo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "history")
+			os.WriteFile(path, []byte(tt.file), 0o644)
+			got, err := readHistory(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryEntry(t *testing.T) {
+	entries := []string{"one", "two", "three"}
+	tests := []struct {
+		n, want, wantErr string
+	}{
+		{"1", "one", ""},
+		{"3", "three", ""},
+		{"-1", "three", ""},
+		{"-3", "one", ""},
+		{"0", "", "no history entry 0"},
+		{"4", "", "no history entry 4"},
+		{"-4", "", "no history entry -4"},
+		{"last", "", `invalid history entry "last"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.n, func(t *testing.T) {
+			got, err := historyEntry(entries, tt.n)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryCommand(t *testing.T) {
+	t.Setenv("SHELL", "/bin/sh")
+	path := filepath.Join(t.TempDir(), "history")
+	os.WriteFile(path, []byte("echo same\necho same\necho other\nexit 3\nstrcli history -- -1 -2\n"), 0o644)
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     string
+		wantCode int
+	}{
+		{"list", nil, "    2  echo same\n", exitSame},
+		{"same output", []string{"1", "2"}, "", exitSame},
+		{"different output", []string{"--", "-2", "-3"}, "@@ -1,1 +1,1 @@ modified", exitDiffer},
+		{"itself", []string{"1", "5"}, "", exitError},
+		{"one number", []string{"1"}, "", exitError},
+		{"no such entry", []string{"1", "9"}, "", exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, code := runMain(t, "", append([]string{"history", "--file", path}, tt.args...)...)
+			if code != tt.wantCode {
+				t.Errorf("exit status = %d, want %d", code, tt.wantCode)
+			}
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("printed\n%s\nwant %q in it", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunsHistory(t *testing.T) {
+	for command, want := range map[string]bool{
+		"strcli history 1 2":                           true,
+		"/usr/local/bin/strcli history":                true,
+		"cd x && strcli --theme dark history -- -1 -2": true,
+		"FOO=1 strcli history":                         true,
+		"strcli compare a b":                           false,
+		"history | grep strcli":                        false,
+		"echo strcli history":                          false,
+	} {
+		if got := runsHistory(command); got != want {
+			t.Errorf("runsHistory(%q) = %v, want %v", command, got, want)
+		}
+	}
+}
+
+func TestRunShell(t *testing.T) {
+	t.Setenv("SHELL", "/bin/sh")
+	tests := []struct {
+		command, want string
+	}{
+		{"echo out", "out\n"},
+		{"echo err >&2", "err\n"},
+		{"echo partial; exit 3", "partial\n[exit status 3]\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			got, err := runShell(tt.command)
+			if err != nil || got != tt.want {
+				t.Errorf("got %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/id"
+)
+
+// idScreen generates identifiers and inserts them into the focused pane or
+// copies them.
+type idScreen struct {
+	selected int
+	// count is the number of identifiers to generate as typed so far.
+	count string
+}
+
+func newIDScreen(m *model) overlay {
+	if m.focus > 1 {
+		m.err = errNoInputPane
+		return nil
+	}
+	return &idScreen{}
+}
+
+func (s *idScreen) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch k := msg.String(); k {
+	case "esc", "q":
+		return true, nil
+	case "up", "k":
+		s.selected = (s.selected + len(id.Kinds) - 1) % len(id.Kinds)
+	case "down", "j":
+		s.selected = (s.selected + 1) % len(id.Kinds)
+	case "backspace":
+		if s.count != "" {
+			s.count = s.count[:len(s.count)-1]
+		}
+	case "enter":
+		m.inputs[m.focus].InsertString(s.generate())
+		return true, nil
+	case "c":
+		if err := copyText(s.generate()); err != nil {
+			m.err = fmt.Errorf("copy: %w", err)
+		} else {
+			m.notice = fmt.Sprintf("copied %d %s", s.n(), id.Kinds[s.selected].Name)
+		}
+		return true, nil
+	default:
+		if len(k) == 1 && k[0] >= '0' && k[0] <= '9' && len(s.count) < 5 {
+			s.count = strings.TrimLeft(s.count+k, "0")
+		}
+	}
+	return false, nil
+}
+
+// n returns the number of identifiers to generate.
+func (s *idScreen) n() int {
+	n, _ := strconv.Atoi(s.count)
+	return max(n, 1)
+}
+
+// generate returns the identifiers one per line, ending with a line break
+// if there is more than one.
+func (s *idScreen) generate() string {
+	return generateIDs(id.Kinds[s.selected], s.n())
+}
+
+func generateIDs(k id.Kind, n int) string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = k.New()
+	}
+	if n == 1 {
+		return ids[0]
+	}
+	return strings.Join(ids, "\n") + "\n"
+}
+
+func (s *idScreen) view(m *model) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", overlayTitleStyle.Render(fmt.Sprintf("Generate IDs into pane %c", 'A'+m.focus)))
+	for i, k := range id.Kinds {
+		line := fmt.Sprintf("%-6s %s", k.Name, k.Description)
+		if i == s.selected {
+			line = selectedStyle.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(line + "\n")
+	}
+	fmt.Fprintf(&b, "\nHow many: %d\n", s.n())
+	b.WriteString("\n↑/↓ select • 0-9 count • enter insert • c copy • esc close")
+	return overlayStyle.Render(b.String())
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestIDScreen(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  []string
+		lines int
+		// length is that of each identifier expected.
+		length int
+	}{
+		{"one uuid", nil, 1, 36},
+		{"three ulids", []string{"down", "down", "3"}, 3, 26},
+		{"leading zeros", []string{"0", "2"}, 2, 36},
+		{"corrected count", []string{"1", "2", "backspace"}, 1, 36},
+		{"wraps around", []string{"up"}, 1, 26},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyF6})
+			for _, k := range tt.keys {
+				var msg tea.KeyMsg
+				switch k {
+				case "up":
+					msg = tea.KeyMsg{Type: tea.KeyUp}
+				case "down":
+					msg = tea.KeyMsg{Type: tea.KeyDown}
+				case "backspace":
+					msg = tea.KeyMsg{Type: tea.KeyBackspace}
+				default:
+					msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+				}
+				m, _ = update(m, msg)
+			}
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+			if m.overlay != nil {
+				t.Fatal("the screen is still open")
+			}
+			ids := strings.Fields(m.inputs[0].Value())
+			if len(ids) != tt.lines {
+				t.Fatalf("inserted %q, want %d identifiers", ids, tt.lines)
+			}
+			for _, s := range ids {
+				if len(s) != tt.length {
+					t.Errorf("inserted %q, want %d characters", s, tt.length)
+				}
+			}
+		})
+	}
+}
+
+func TestIDScreenNeedsInputPane(t *testing.T) {
+	m := newModel()
+	m.focus = 2
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyF6})
+	if m.overlay != nil || m.err != errNoInputPane {
+		t.Errorf("overlay %v, error %v", m.overlay, m.err)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/transform"
+)
+
+// inspector lists the characters of the line under the cursor with their
+// code points, names and UTF-8 bytes.
+type inspector struct {
+	pane, line int
+	text       string
+	runes      []rune
+	offset     int
+}
+
+func newInspector(m *model) overlay {
+	if m.focus > 1 {
+		m.err = errNoInputPane
+		return nil
+	}
+	t := m.inputs[m.focus]
+	line := strings.Split(t.Value(), "\n")[t.Line()]
+	return &inspector{pane: m.focus, line: t.Line(), text: line, runes: []rune(line)}
+}
+
+func (in *inspector) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	page := max(m.height-10, 1)
+	switch msg.String() {
+	case "esc", "q":
+		return true, nil
+	case "up", "k":
+		in.offset--
+	case "down", "j":
+		in.offset++
+	case "pgup":
+		in.offset -= page
+	case "pgdown", " ":
+		in.offset += page
+	}
+	in.offset = max(min(in.offset, len(in.runes)-page), 0)
+	return false, nil
+}
+
+func (in *inspector) view(m *model) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", overlayTitleStyle.Render(fmt.Sprintf("Characters of line %d in pane %c", in.line+1, 'A'+in.pane)))
+	fmt.Fprintf(&b, "%d code points, %d bytes · normalized as %s\n\n", len(in.runes), len(in.text), transform.NormalizationForms(in.text))
+	end := min(in.offset+max(m.height-10, 1), len(in.runes))
+	for _, r := range in.runes[in.offset:end] {
+		b.WriteString(transform.DescribeRune(r) + "\n")
+	}
+	if len(in.runes) == 0 {
+		b.WriteString("The line is empty\n")
+	}
+	b.WriteString("\n↑/↓ scroll • esc close")
+	return overlayStyle.Render(b.String())
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestInspector(t *testing.T) {
+	m := newModel()
+	m.width, m.height = 100, 30
+	m.inputs[0].SetValue("first\nca\u0301t")
+	m, _ = update(m, alt('u'))
+	in, ok := m.overlay.(*inspector)
+	if !ok {
+		t.Fatalf("overlay %T, want the inspector", m.overlay)
+	}
+	if in.line != 1 || in.text != "ca\u0301t" {
+		t.Errorf("inspects line %d, %q, want the line with the cursor", in.line+1, in.text)
+	}
+	v := m.View()
+	for _, want := range []string{"Characters of line 2 in pane A", "4 code points, 5 bytes · normalized as NFD, NFKD", "COMBINING ACUTE ACCENT (cc 81)"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("shows\n%s\nwant %q in it", v, want)
+		}
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.overlay != nil {
+		t.Error("esc does not close the inspector")
+	}
+}
+
+func TestInspectorNeedsInputPane(t *testing.T) {
+	m := newModel()
+	m.focus = 2
+	m, _ = update(m, alt('u'))
+	if m.overlay != nil || m.err != errNoInputPane {
+		t.Errorf("overlay %v, error %v", m.overlay, m.err)
+	}
+}
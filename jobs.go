@@ -0,0 +1,76 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/compare"
+)
+
+// Background work runs inside tea.Cmds and reports back through messages.
+// Every message carries the generation it was started for; the model bumps
+// its counter whenever newer work supersedes older work, so a slow job that
+// finishes late can never overwrite a fresher result.
+
+// diffMsg delivers a comparison started at generation gen.
+type diffMsg struct {
+	gen int
+	res compare.Result
+}
+
+// loadMsg delivers new content for a pane, started at the pane's generation
+// gen. With insert set the text goes in at the cursor instead of replacing
+// the pane's content.
+type loadMsg struct {
+	pane   int
+	gen    int
+	text   string
+	insert bool
+	err    error
+}
+
+// compareCmd compares a with b in the background.
+func compareCmd(gen int, a, b string, opts compare.Options) tea.Cmd {
+	return func() tea.Msg {
+		return diffMsg{gen: gen, res: compare.Compare(a, b, opts)}
+	}
+}
+
+// startCompare supersedes any comparison in flight and starts a new one for
+// the current pane contents, with the spinner turning until it is done.
+func (m *model) startCompare() tea.Cmd {
+	m.gen++
+	m.running = m.gen
+	m.compared = m.edits
+	a, b := m.paneText(0), m.paneText(1)
+	m.stats.recordCompare(len(a), len(b))
+	if m.matching {
+		return tea.Batch(matchCmd(m.gen, a, b, m.syntax), m.spinner.Tick)
+	}
+	return tea.Batch(compareCmd(m.gen, a, b, m.options), m.spinner.Tick)
+}
+
+// comparing reports whether the latest comparison is still running.
+func (m *model) comparing() bool {
+	return m.running != 0 && m.running == m.gen
+}
+
+// startLoad supersedes any load in flight for pane and runs fn in the
+// background to produce its new content.
+func (m *model) startLoad(pane int, fn func() (string, error)) tea.Cmd {
+	m.paneGen[pane]++
+	gen := m.paneGen[pane]
+	return func() tea.Msg {
+		text, err := fn()
+		return loadMsg{pane: pane, gen: gen, text: text, err: err}
+	}
+}
+
+// startInsert is like startLoad, but fn produces text to insert at the
+// cursor.
+func (m *model) startInsert(pane int, fn func() (string, error)) tea.Cmd {
+	cmd := m.startLoad(pane, fn)
+	return func() tea.Msg {
+		msg := cmd().(loadMsg)
+		msg.insert = true
+		return msg
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// compareNow compares the panes of m and applies the result.
+func compareNow(m model) model {
+	for _, msg := range runCmd(m.startCompare()) {
+		m, _ = update(m, msg)
+	}
+	return m
+}
+
+func TestStaleComparisonsAreDropped(t *testing.T) {
+	m := newModel()
+	m.inputs[0].SetValue("a")
+	m.inputs[1].SetValue("b")
+	first := m.startCompare()
+	m.inputs[1].SetValue("a")
+	second := m.startCompare()
+
+	m, _ = update(m, runCmd(second)[0])
+	if !m.diff.Equal() {
+		t.Fatalf("latest comparison not applied: %+v", m.diff)
+	}
+	m, _ = update(m, runCmd(first)[0])
+	if !m.diff.Equal() {
+		t.Errorf("stale comparison overwrote the latest one: %+v", m.diff)
+	}
+}
+
+func TestLoads(t *testing.T) {
+	fail := errors.New("no such file")
+	tests := []struct {
+		name     string
+		loads    []func() (string, error)
+		apply    []int
+		wantText string
+		wantErr  error
+	}{
+		{"latest load wins", []func() (string, error){
+			func() (string, error) { return "old", nil },
+			func() (string, error) { return "new", nil },
+		}, []int{1, 0}, "new", nil},
+		{"error is shown", []func() (string, error){
+			func() (string, error) { return "", fail },
+		}, []int{0}, "", fail},
+		{"stale error is dropped", []func() (string, error){
+			func() (string, error) { return "", fail },
+			func() (string, error) { return "text", nil },
+		}, []int{1, 0}, "text", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			var msgs []any
+			for _, fn := range tt.loads {
+				msgs = append(msgs, m.startLoad(0, fn)())
+			}
+			for _, i := range tt.apply {
+				m, _ = update(m, msgs[i])
+			}
+			if got := m.inputs[0].Value(); got != tt.wantText {
+				t.Errorf("pane = %q, want %q", got, tt.wantText)
+			}
+			if m.err != tt.wantErr {
+				t.Errorf("err = %v, want %v", m.err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpinner(t *testing.T) {
+	m := newModel()
+	m.inputs[0].SetValue("a")
+	if m.comparing() || strings.Contains(m.statusView(), "comparing") {
+		t.Fatal("spinning before a comparison")
+	}
+	first := m.startCompare()
+	second := m.startCompare()
+	if !m.comparing() || !strings.Contains(m.statusView(), "comparing") {
+		t.Fatalf("not spinning while comparing: %q", m.statusView())
+	}
+	if _, cmd := update(m, m.spinner.Tick()); cmd == nil {
+		t.Error("the spinner stops turning while comparing")
+	}
+
+	m, _ = update(m, runCmd(first)[0])
+	if !m.comparing() {
+		t.Error("a stale comparison stops the spinner")
+	}
+	m, _ = update(m, runCmd(second)[0])
+	if m.comparing() || strings.Contains(m.statusView(), "comparing") {
+		t.Errorf("spinning after the comparison: %q", m.statusView())
+	}
+	if _, cmd := update(m, m.spinner.Tick()); cmd != nil {
+		t.Error("the spinner keeps turning after the comparison")
+	}
+}
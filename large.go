@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"strcli/pkg/diff"
+	"strcli/pkg/render"
+)
+
+// largeFileSize is the size from which files are compared with
+// diff.ComputeLarge, which does not load them into memory, even without
+// --large.
+const largeFileSize = 64 << 20
+
+// isLarge reports whether either of the files at paths is large enough to
+// be compared without loading it.
+func isLarge(paths []string) bool {
+	for _, p := range paths {
+		if p == "-" {
+			return false
+		}
+	}
+	for _, p := range paths {
+		if fi, err := os.Stat(p); err == nil && fi.Mode().IsRegular() && fi.Size() >= largeFileSize {
+			return true
+		}
+	}
+	return false
+}
+
+// compareLarge writes the diff of the files at paths, comparing them a line
+// at a time with diff.ComputeLarge. They are never shown in the
+// interactive view, which would need them in memory.
+func compareLarge(cmd *cobra.Command, paths []string, of compareFlags) error {
+	if len(paths) != 2 || paths[0] == "-" || paths[1] == "-" {
+		return errors.New("comparing large inputs needs two files")
+	}
+	if of.preset != "" || of.ignoreCase || of.ignoreEOL || of.template || of.overlap {
+		return errors.New("large files are compared as they are: --preset, --ignore-case, --ignore-eol, --template and --overlap do not apply")
+	}
+	if of.porcelain {
+		of.format = "porcelain"
+	}
+	r, ok := render.Lookup(of.format, diffStyles)
+	if !ok {
+		return fmt.Errorf("unknown format %q", of.format)
+	}
+	opts, err := of.options()
+	if err != nil {
+		return err
+	}
+	a, err := os.Open(paths[0])
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+	b, err := os.Open(paths[1])
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	// Options give no timeout as a negative one, diff as 0.
+	d, err := diff.ComputeLarge(a, b, opts.Unit, max(opts.Timeout, 0))
+	if err != nil {
+		return err
+	}
+	if !of.quiet {
+		out, err := r(d)
+		if err != nil {
+			return err
+		}
+		if err := writeOutput(cmd, of.output, out); err != nil {
+			return err
+		}
+	}
+	if !d.Equal() {
+		return errDiffer
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsLarge(t *testing.T) {
+	dir := t.TempDir()
+	small := filepath.Join(dir, "small")
+	os.WriteFile(small, []byte("x\n"), 0o644)
+	big := filepath.Join(dir, "big")
+	f, err := os.Create(big)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Truncate(largeFileSize); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  bool
+	}{
+		{"small files", []string{small, small}, false},
+		{"one large file", []string{small, big}, true},
+		{"standard input", []string{big, "-"}, false},
+		{"missing file", []string{filepath.Join(dir, "missing"), small}, false},
+		{"directory", []string{dir, small}, false},
+		{"no files", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isLarge(tt.paths); got != tt.want {
+				t.Errorf("isLarge(%q) = %v, want %v", tt.paths, got, tt.want)
+			}
+		})
+	}
+}
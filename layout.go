@@ -0,0 +1,87 @@
+package main
+
+const (
+	// defaultSplit is the share of the width, in percent, given to the
+	// first input pane.
+	defaultSplit = 50
+	// minSplit is the smallest share of the width, in percent, an input
+	// pane can be shrunk to, and minPaneHeight the fewest rows any pane
+	// can be shrunk to.
+	minSplit      = 10
+	minPaneHeight = 3
+	// splitStep and rowStep are how much a pane grows or shrinks by at a
+	// time: a share of the width in percent, or a number of rows.
+	splitStep = 5
+	rowStep   = 2
+)
+
+// resize grows the focused pane by delta steps, or shrinks it if delta is
+// negative. The input panes trade width, or height when stacked, with each
+// other; the result pane trades height, or width when beside them, with
+// both of them.
+func (m *model) resize(delta int) {
+	switch m.focus {
+	case 0, 1:
+		if m.focus == 1 {
+			delta = -delta
+		}
+		m.split = min(max(m.split+delta*splitStep, minSplit), 100-minSplit)
+	default:
+		if m.vertical {
+			m.sideSplit = min(max(m.sideSplit+delta*splitStep, minSplit), 100-minSplit)
+			break
+		}
+		rows := m.resultRows + delta*rowStep
+		frame := focusedBorderStyle.GetVerticalFrameSize()
+		m.resultRows = min(max(rows, minPaneHeight), max(m.height-helpHeight-2*frame-minPaneHeight, minPaneHeight))
+	}
+	m.sizeInputs()
+}
+
+// resetLayout gives the panes back their initial sizes.
+func (m *model) resetLayout() {
+	m.split = defaultSplit
+	m.sideSplit = defaultSplit
+	m.resultRows = resultHeight
+	m.sizeInputs()
+}
+
+// toggleLayout switches between the input panes side by side above the
+// result pane and the input panes stacked beside it.
+func (m *model) toggleLayout() {
+	m.vertical = !m.vertical
+	m.sizeInputs()
+}
+
+// toggleZoom switches between the focused pane taking the whole window and
+// the layout.
+func (m *model) toggleZoom() {
+	m.zoomed = !m.zoomed
+	m.sizeInputs()
+}
+
+// sizeZoomed gives the focused pane the whole window but for the help.
+func (m *model) sizeZoomed() {
+	frame := focusedBorderStyle.GetVerticalFrameSize()
+	height := max(m.height-helpHeight-frame, minPaneHeight)
+	m.inputs[m.focus].SetWidth(m.width)
+	m.inputs[m.focus].SetHeight(height)
+	if m.focus == len(m.inputs)-1 {
+		m.sizeResult(m.width, height)
+	}
+}
+
+// sizeStacked sizes the panes for the vertical layout.
+func (m *model) sizeStacked() {
+	side := m.width * m.sideSplit / 100
+	frame := m.inputs[0].FocusedStyle.Base.GetVerticalFrameSize()
+	height := max(m.height-helpHeight-frame, 2*minPaneHeight+frame)
+	first := (height - frame) * m.split / 100
+	m.inputs[0].SetWidth(m.width - side)
+	m.inputs[0].SetHeight(first)
+	m.inputs[1].SetWidth(m.width - side)
+	m.inputs[1].SetHeight(height - frame - first)
+	m.inputs[2].SetWidth(side)
+	m.inputs[2].SetHeight(height)
+	m.sizeResult(side, height)
+}
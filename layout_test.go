@@ -0,0 +1,107 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name       string
+		focus      int
+		keys       []rune
+		split      int
+		resultRows int
+	}{
+		{"grow A", 0, []rune{'='}, 55, resultHeight},
+		{"grow B", 1, []rune{'=', '='}, 40, resultHeight},
+		{"shrink A to the minimum", 0, []rune{'-', '-', '-', '-', '-', '-', '-', '-', '-', '-'}, minSplit, resultHeight},
+		{"grow B to the maximum", 1, []rune{'=', '=', '=', '=', '=', '=', '=', '=', '=', '='}, minSplit, resultHeight},
+		{"grow the result", 2, []rune{'=', '='}, defaultSplit, 9},
+		{"grow the result to the maximum", 2, []rune{'=', '=', '=', '=', '=', '=', '=', '=', '=', '='}, defaultSplit, 18},
+		{"shrink the result", 2, []rune{'-', '-'}, defaultSplit, minPaneHeight},
+		{"reset", 0, []rune{'=', '0'}, defaultSplit, resultHeight},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m, _ = update(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+			m.focus = tt.focus
+			for _, k := range tt.keys {
+				m, _ = update(m, alt(k))
+			}
+			if m.split != tt.split || m.resultRows != tt.resultRows {
+				t.Errorf("split %d, result rows %d, want %d and %d", m.split, m.resultRows, tt.split, tt.resultRows)
+			}
+			if w := m.inputs[0].Width() + m.inputs[1].Width(); w > 100 {
+				t.Errorf("the input panes are %d wide together", w)
+			}
+		})
+	}
+}
+
+func TestStackedLayout(t *testing.T) {
+	m := newModel()
+	m, _ = update(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+	resultWidth := m.inputs[2].Width()
+	m, _ = update(m, alt('y'))
+	if !m.vertical {
+		t.Fatal("alt+y does not stack the input panes")
+	}
+	a, b, r := m.inputs[0], m.inputs[1], m.inputs[2]
+	if a.Width() != b.Width() || a.Width()+r.Width() > 100 {
+		t.Errorf("widths A %d, B %d, result %d", a.Width(), b.Width(), r.Width())
+	}
+	if a.Height()+b.Height() > r.Height() {
+		t.Errorf("heights A %d, B %d, result %d", a.Height(), b.Height(), r.Height())
+	}
+
+	m.focus = 2
+	m, _ = update(m, alt('='))
+	if m.sideSplit != defaultSplit+splitStep || m.resultRows != resultHeight {
+		t.Errorf("growing the result: side split %d, result rows %d", m.sideSplit, m.resultRows)
+	}
+	m, _ = update(m, alt('0'))
+	if m.sideSplit != defaultSplit || !m.vertical {
+		t.Errorf("after a reset: side split %d, vertical %v", m.sideSplit, m.vertical)
+	}
+	m, _ = update(m, alt('y'))
+	if m.vertical || m.inputs[2].Width() != resultWidth {
+		t.Errorf("after switching back: vertical %v, result width %d, want %d", m.vertical, m.inputs[2].Width(), resultWidth)
+	}
+}
+
+func TestZoom(t *testing.T) {
+	for focus := 0; focus < 3; focus++ {
+		m := newModel()
+		m, _ = update(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+		before := m.inputs[focus].Width()
+		m.focus = focus
+		m, _ = update(m, alt('z'))
+		if !m.zoomed {
+			t.Fatal("alt+z does not zoom")
+		}
+		v := m.view()
+		if h := lipgloss.Height(v); h > 30 {
+			t.Errorf("pane %d: zoomed view is %d high", focus, h)
+		}
+		if !strings.Contains(v, "zoomed") {
+			t.Errorf("pane %d: the help does not say the pane is zoomed", focus)
+		}
+		for i, r := range m.paneRects() {
+			if shown := r.w > 0; shown != (i == focus) {
+				t.Errorf("pane %d zoomed: pane %d takes %dx%d", focus, i, r.w, r.h)
+			}
+		}
+		if r := m.paneRects()[focus]; r.w != 100 {
+			t.Errorf("pane %d: zoomed to %d wide", focus, r.w)
+		}
+		m, _ = update(m, alt('z'))
+		if m.zoomed || m.inputs[focus].Width() != before {
+			t.Errorf("pane %d: unzoomed to width %d, want %d", focus, m.inputs[focus].Width(), before)
+		}
+	}
+}
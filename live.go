@@ -0,0 +1,55 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// liveDelay is how long the panes must be left alone before live
+// comparison compares them.
+const liveDelay = 300 * time.Millisecond
+
+// liveMsg asks for the live comparison scheduled as seq. Only the latest
+// one compares, so a burst of edits is compared once, when it ends.
+type liveMsg struct {
+	seq int
+}
+
+func liveCmd(seq int) tea.Cmd {
+	return tea.Tick(liveDelay, func(time.Time) tea.Msg { return liveMsg{seq: seq} })
+}
+
+// toggleLive switches live comparison, which compares the panes shortly
+// after each edit, on or off.
+func (m *model) toggleLive() tea.Cmd {
+	m.live = !m.live
+	if !m.live {
+		m.notice = "comparing on " + m.keymap.compare.Help().Key
+		return nil
+	}
+	m.notice = "comparing while typing"
+	if m.edits == m.compared {
+		return nil
+	}
+	return m.startCompare()
+}
+
+// scheduleLive schedules a live comparison if live comparison is on and
+// the panes were edited since prev.
+func (m *model) scheduleLive(prev *model) tea.Cmd {
+	if !m.live || m.edits == prev.edits || m.edits == m.compared {
+		return nil
+	}
+	m.liveSeq++
+	return liveCmd(m.liveSeq)
+}
+
+// compareLive compares the panes for the live comparison scheduled as seq,
+// unless a later edit scheduled another or they were compared since.
+func (m *model) compareLive(seq int) tea.Cmd {
+	if !m.live || seq != m.liveSeq || m.edits == m.compared {
+		return nil
+	}
+	return m.startCompare()
+}
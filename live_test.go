@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var liveKey = tea.KeyMsg{Type: tea.KeyCtrlR, Alt: true}
+
+func TestLive(t *testing.T) {
+	m := newModel()
+	m, _ = update(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = typeText(m, "a")
+	if m.liveSeq != 0 {
+		t.Fatal("editing schedules a live comparison while it is off")
+	}
+
+	m, cmd := update(m, liveKey)
+	if !m.live || cmd == nil || m.compared != m.edits {
+		t.Fatalf("turning live comparison on after an edit: live %v, compared %d of %d edits", m.live, m.compared, m.edits)
+	}
+	if !strings.Contains(m.statusView(), "live") {
+		t.Errorf("status %q", m.statusView())
+	}
+
+	m = typeText(m, "bc")
+	if m.liveSeq != 2 {
+		t.Fatalf("%d live comparisons scheduled, want 2", m.liveSeq)
+	}
+	if m, cmd = update(m, liveMsg{seq: 1}); cmd != nil || m.compared == m.edits {
+		t.Error("a superseded live comparison compares")
+	}
+	if m, cmd = update(m, liveMsg{seq: 2}); cmd == nil || m.compared != m.edits {
+		t.Error("the latest live comparison does not compare")
+	}
+	if _, cmd = update(m, liveMsg{seq: 2}); cmd != nil {
+		t.Error("compared panes are compared again")
+	}
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRight})
+	if m.liveSeq != 2 {
+		t.Error("moving the cursor schedules a live comparison")
+	}
+
+	m, _ = update(m, liveKey)
+	if m.live || !strings.Contains(m.notice, m.keymap.compare.Help().Key) {
+		t.Errorf("turning live comparison off: live %v, notice %q", m.live, m.notice)
+	}
+	m = typeText(m, "d")
+	if m.liveSeq != 2 {
+		t.Error("editing schedules a live comparison after it is turned off")
+	}
+}
+
+func TestLiveUnchanged(t *testing.T) {
+	m := newModel()
+	if _, cmd := update(m, liveKey); cmd != nil {
+		t.Error("turning live comparison on compares panes that were not edited")
+	}
+}
+
+func TestLiveCompareConfig(t *testing.T) {
+	m := newModel()
+	config{LiveCompare: true}.apply(&m)
+	if !m.live {
+		t.Error("live_compare does not turn live comparison on")
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+// toggleLockScroll locks the input panes to scroll together, or unlocks
+// them.
+func (m *model) toggleLockScroll() {
+	m.lockScroll = !m.lockScroll
+	if m.lockScroll {
+		m.notice = "scrolling the input panes together"
+		m.followScroll(m.focus)
+		return
+	}
+	m.notice = "scrolling the input panes apart"
+}
+
+// followScroll scrolls the other input pane along with pane i while their
+// scrolling is locked. Text areas scroll to their cursor, so the other
+// pane's cursor is moved to the line of pane i's.
+func (m *model) followScroll(i int) {
+	if !m.lockScroll || i > 1 {
+		return
+	}
+	other := 1 - i
+	line := min(m.inputs[i].Line(), m.inputs[other].LineCount()-1)
+	if m.inputs[other].Line() != line {
+		m.moveCursor(other, panePos{line: line})
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLockScroll(t *testing.T) {
+	lines := strings.Repeat("line\n", 40)
+	tests := []struct {
+		name  string
+		lock  bool
+		msgs  []tea.Msg
+		other int
+	}{
+		{"arrow keys", true, []tea.Msg{tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyDown}}, 2},
+		{"unlocked", false, []tea.Msg{tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyDown}}, 0},
+		{"end of the text", true, []tea.Msg{tea.KeyMsg{Type: tea.KeyCtrlEnd}}, -1},
+		{"wheel", true, []tea.Msg{tea.MouseMsg{X: 2, Y: 2, Button: tea.MouseButtonWheelDown}}, wheelLines},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m, _ = update(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+			m.setPane(0, lines)
+			m.setPane(1, lines+"more\n")
+			for i := 0; i < 2; i++ {
+				m.moveCursor(i, panePos{})
+			}
+			if tt.lock {
+				m, _ = update(m, alt('j'))
+			}
+			for _, msg := range tt.msgs {
+				m, _ = update(m, msg)
+			}
+			want := tt.other
+			if want < 0 {
+				// The same line as pane A, wherever that is.
+				want = m.inputs[0].Line()
+				if want == 0 {
+					t.Fatal("pane A did not move")
+				}
+			}
+			if got := m.inputs[1].Line(); got != want {
+				t.Errorf("pane B at line %d, want %d; pane A at %d", got, want, m.inputs[0].Line())
+			}
+		})
+	}
+}
+
+func TestLockScrollShorterPane(t *testing.T) {
+	m := newModel()
+	m.setPane(0, strings.Repeat("line\n", 20))
+	m.setPane(1, "one\ntwo")
+	m, _ = update(m, alt('j'))
+	if m.inputs[1].Line() != 1 {
+		t.Errorf("pane B at line %d, want its last", m.inputs[1].Line())
+	}
+	if !strings.Contains(m.statusView(), "scroll locked") {
+		t.Error("the status bar does not show the lock")
+	}
+	m, _ = update(m, alt('j'))
+	if m.lockScroll || m.notice != "scrolling the input panes apart" {
+		t.Errorf("unlocked %v, notice %q", !m.lockScroll, m.notice)
+	}
+}
@@ -1,15 +1,20 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/sergi/go-diff/diffmatchpatch"
 	"os"
-	"strings"
+	"strcli/pkg/compare"
+	"strcli/pkg/diff"
+	"strcli/pkg/render"
+	"time"
 )
 
 const (
@@ -18,32 +23,29 @@ const (
 	helpHeight    = 5
 )
 
+// The styles of the TUI are colored by applyTheme.
 var (
-	cursorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
+	cursorStyle = lipgloss.NewStyle()
 
-	cursorLineStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("57")).
-			Foreground(lipgloss.Color("230"))
+	cursorLineStyle = lipgloss.NewStyle()
 
-	placeholderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("238"))
+	placeholderStyle = lipgloss.NewStyle()
 
-	endOfBufferStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("235"))
+	endOfBufferStyle = lipgloss.NewStyle()
 
-	focusedPlaceholderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("99"))
+	focusedPlaceholderStyle = lipgloss.NewStyle()
 
 	focusedBorderStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("238"))
+				Border(lipgloss.RoundedBorder())
 
 	blurredBorderStyle = lipgloss.NewStyle().
 				Border(lipgloss.HiddenBorder())
+
+	errorStyle = lipgloss.NewStyle()
 )
 
 type keymap = struct {
-	next, prev, quit, compare key.Binding
+	next, prev, quit, compare, restore, transform, export, dismissTip, stats, preset, roundTrip, ignoreCase, unit, match, hashes, template, overlap, colorCheck, textStats, hide, ids, present, replace, regexTester, appendResults, frequency, markdown, whitespace, ignoreEOL, inspect, grow, shrink, resetLayout, layout, theme, search, nextMatch, prevMatch, undo, redo, copy, paste, save, open, newTab, closeTab, nextTab, prevTab, zoom, help, lockScroll, wrap, palette, swap, clearPane, clearAll, live key.Binding
 }
 
 func newTextarea() textarea.Model {
@@ -51,76 +53,459 @@ func newTextarea() textarea.Model {
 	t.Prompt = ""
 	t.Placeholder = "Type something"
 	t.ShowLineNumbers = true
-	t.Cursor.Style = cursorStyle
-	t.FocusedStyle.Placeholder = focusedPlaceholderStyle
-	t.BlurredStyle.Placeholder = placeholderStyle
-	t.FocusedStyle.CursorLine = cursorLineStyle
-	t.FocusedStyle.Base = focusedBorderStyle
-	t.BlurredStyle.Base = blurredBorderStyle
-	t.FocusedStyle.EndOfBuffer = endOfBufferStyle
-	t.BlurredStyle.EndOfBuffer = endOfBufferStyle
+	// Panes hold whole files and pasted or generated text of any length.
+	t.CharLimit = 0
+	t.MaxHeight = 0
+	styleTextarea(&t)
 	t.KeyMap.DeleteWordBackward.SetEnabled(false)
+	// Pasting is done by the model, in one go; see paste.
+	t.KeyMap.Paste.SetEnabled(false)
 	t.KeyMap.LineNext = key.NewBinding(key.WithKeys("down"))
 	t.KeyMap.LinePrevious = key.NewBinding(key.WithKeys("up"))
 	t.Blur()
 	return t
 }
 
+// newKeymap returns the default key bindings.
+func newKeymap() keymap {
+	return keymap{
+		next: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next"),
+		),
+		prev: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "prev"),
+		),
+		quit: key.NewBinding(
+			key.WithKeys("esc", "ctrl+c"),
+			key.WithHelp("esc", "quit"),
+		),
+		compare: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "compare"),
+		),
+		restore: key.NewBinding(
+			key.WithKeys("alt+r"),
+			key.WithHelp("alt+r", "restore previous"),
+		),
+		transform: key.NewBinding(
+			key.WithKeys("f10"),
+			key.WithHelp("f10", "transforms"),
+		),
+		export: key.NewBinding(
+			key.WithKeys("ctrl+x"),
+			key.WithHelp("ctrl+x", "export diff"),
+		),
+		save: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", "save pane"),
+		),
+		open: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "open file"),
+		),
+		newTab: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", "new tab"),
+		),
+		closeTab: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "close tab"),
+		),
+		nextTab: key.NewBinding(
+			key.WithKeys("ctrl+right"),
+			key.WithHelp("ctrl+→", "next tab"),
+		),
+		prevTab: key.NewBinding(
+			key.WithKeys("ctrl+left"),
+			key.WithHelp("ctrl+←", "prev tab"),
+		),
+		zoom: key.NewBinding(
+			key.WithKeys("alt+z"),
+			key.WithHelp("alt+z", "zoom"),
+		),
+		help: key.NewBinding(
+			key.WithKeys("f1"),
+			key.WithHelp("f1", "all keys"),
+		),
+		dismissTip: key.NewBinding(
+			key.WithKeys("ctrl+g"),
+			key.WithHelp("ctrl+g", "dismiss tip"),
+		),
+		stats: key.NewBinding(
+			key.WithKeys("f2"),
+			key.WithHelp("f2", "stats"),
+		),
+		preset: key.NewBinding(
+			key.WithKeys("alt+p"),
+			key.WithHelp("alt+p", "preset"),
+		),
+		roundTrip: key.NewBinding(
+			key.WithKeys("alt+v"),
+			key.WithHelp("alt+v", "round trip check"),
+		),
+		ignoreCase: key.NewBinding(
+			key.WithKeys("alt+i"),
+			key.WithHelp("alt+i", "ignore case"),
+		),
+		unit: key.NewBinding(
+			key.WithKeys("alt+g"),
+			key.WithHelp("alt+g", "diff unit"),
+		),
+		match: key.NewBinding(
+			key.WithKeys("alt+m"),
+			key.WithHelp("alt+m", "match pattern"),
+		),
+		hashes: key.NewBinding(
+			key.WithKeys("f3"),
+			key.WithHelp("f3", "hashes"),
+		),
+		template: key.NewBinding(
+			key.WithKeys("alt+t"),
+			key.WithHelp("alt+t", "template"),
+		),
+		overlap: key.NewBinding(
+			key.WithKeys("alt+o"),
+			key.WithHelp("alt+o", "overlap only"),
+		),
+		colorCheck: key.NewBinding(
+			key.WithKeys("f4"),
+			key.WithHelp("f4", "color check"),
+		),
+		textStats: key.NewBinding(
+			key.WithKeys("f5"),
+			key.WithHelp("f5", "text stats"),
+		),
+		ids: key.NewBinding(
+			key.WithKeys("f6"),
+			key.WithHelp("f6", "generate IDs"),
+		),
+		replace: key.NewBinding(
+			key.WithKeys("alt+s"),
+			key.WithHelp("alt+s", "find and replace"),
+		),
+		regexTester: key.NewBinding(
+			key.WithKeys("f8"),
+			key.WithHelp("f8", "regex tester"),
+		),
+		appendResults: key.NewBinding(
+			key.WithKeys("alt+a"),
+			key.WithHelp("alt+a", "append results"),
+		),
+		frequency: key.NewBinding(
+			key.WithKeys("f9"),
+			key.WithHelp("f9", "frequencies"),
+		),
+		markdown: key.NewBinding(
+			key.WithKeys("alt+k"),
+			key.WithHelp("alt+k", "markdown preview"),
+		),
+		whitespace: key.NewBinding(
+			key.WithKeys("alt+w"),
+			key.WithHelp("alt+w", "show whitespace"),
+		),
+		ignoreEOL: key.NewBinding(
+			key.WithKeys("alt+l"),
+			key.WithHelp("alt+l", "ignore line endings"),
+		),
+		inspect: key.NewBinding(
+			key.WithKeys("alt+u"),
+			key.WithHelp("alt+u", "inspect line"),
+		),
+		grow: key.NewBinding(
+			key.WithKeys("alt+="),
+			key.WithHelp("alt+=", "grow pane"),
+		),
+		shrink: key.NewBinding(
+			key.WithKeys("alt+-"),
+			key.WithHelp("alt+-", "shrink pane"),
+		),
+		resetLayout: key.NewBinding(
+			key.WithKeys("alt+0"),
+			key.WithHelp("alt+0", "reset sizes"),
+		),
+		layout: key.NewBinding(
+			key.WithKeys("alt+y"),
+			key.WithHelp("alt+y", "toggle layout"),
+		),
+		theme: key.NewBinding(
+			key.WithKeys("alt+c"),
+			key.WithHelp("alt+c", "next theme"),
+		),
+		search: key.NewBinding(
+			key.WithKeys("ctrl+f"),
+			key.WithHelp("ctrl+f", "search"),
+		),
+		nextMatch: key.NewBinding(
+			key.WithKeys("alt+n"),
+			key.WithHelp("alt+n", "next match"),
+		),
+		prevMatch: key.NewBinding(
+			key.WithKeys("alt+N"),
+			key.WithHelp("alt+N", "previous match"),
+		),
+		undo: key.NewBinding(
+			key.WithKeys("ctrl+z"),
+			key.WithHelp("ctrl+z", "undo"),
+		),
+		redo: key.NewBinding(
+			key.WithKeys("ctrl+y"),
+			key.WithHelp("ctrl+y", "redo"),
+		),
+		copy: key.NewBinding(
+			key.WithKeys("alt+x"),
+			key.WithHelp("alt+x", "copy pane"),
+		),
+		paste: key.NewBinding(
+			key.WithKeys("ctrl+v"),
+			key.WithHelp("ctrl+v", "paste"),
+		),
+		lockScroll: key.NewBinding(
+			key.WithKeys("alt+j"),
+			key.WithHelp("alt+j", "lock scrolling"),
+		),
+		wrap: key.NewBinding(
+			key.WithKeys("alt+e"),
+			key.WithHelp("alt+e", "wrap lines"),
+		),
+		palette: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "commands"),
+		),
+		swap: key.NewBinding(
+			key.WithKeys("alt+S"),
+			key.WithHelp("alt+S", "swap panes"),
+		),
+		clearPane: key.NewBinding(
+			key.WithKeys("ctrl+l"),
+			key.WithHelp("ctrl+l", "clear pane"),
+		),
+		clearAll: key.NewBinding(
+			key.WithKeys("alt+ctrl+l"),
+			key.WithHelp("alt+ctrl+l", "clear all"),
+		),
+		live: key.NewBinding(
+			key.WithKeys("alt+ctrl+r"),
+			key.WithHelp("alt+ctrl+r", "live compare"),
+		),
+		present: key.NewBinding(
+			key.WithKeys("f7"),
+			key.WithHelp("f7", "present"),
+		),
+		hide: key.NewBinding(
+			key.WithKeys("alt+h"),
+			key.WithHelp("alt+h", "hide"),
+		),
+	}
+}
+
 type model struct {
 	width  int
 	height int
 	keymap keymap
 	help   help.Model
-	inputs []textarea.Model
-	focus  int
-	diff   string
+	// session is the comparison in the current tab; see tabs.go.
+	session
+	tabs []session
+	tab  int
+	err  error
+	// notice is a message about the last action, shown until the next one.
+	notice string
+	// format is the output format used when exporting the diff.
+	format string
+	// showTextStats shows counts of the focused pane below the help.
+	showTextStats bool
+	// title shows the outcome of the comparison in the window title.
+	title bool
+	// lockAfter, if set, hides the panes when no key was pressed for that
+	// long. lastKey is when the last key was pressed.
+	lockAfter time.Duration
+	lastKey   time.Time
+	// draft, if set, is the state file the panes are autosaved to; see
+	// drafts.go. draftFailed is set while saving it fails.
+	draft       string
+	draftFailed bool
+	// showWhitespace draws spaces, tabs and invisible characters as
+	// visible glyphs in the input panes and the result.
+	showWhitespace bool
+	// preview, if set, shows the focused input pane rendered as Markdown
+	// in place of the other one.
+	preview *markdownPreview
+	// split is the share of the width, in percent, taken by the first
+	// input pane, and resultRows the height of the result pane. With
+	// vertical set the input panes are stacked instead, split shares out
+	// their height and sideSplit is the share of the width taken by the
+	// result pane beside them.
+	split      int
+	resultRows int
+	vertical   bool
+	sideSplit  int
+	// zoomed shows only the focused pane, in the whole window.
+	zoomed bool
+	// lockScroll scrolls the input panes together.
+	lockScroll bool
+	// live compares the panes shortly after each edit; liveSeq numbers
+	// the live comparisons scheduled. See live.go.
+	live    bool
+	liveSeq int
+	// vim, if set, edits the input panes the way vim does.
+	vim *vim
+	// drag is the press of the mouse button being dragged, if any.
+	drag *drag
+	// tabWidth is the distance between the tab stops tabs are expanded to
+	// in the input panes.
+	tabWidth int
+	// recorder, if set, records every screen of the session.
+	recorder *recorder
+	tips     *tipStore
+	stats    *usageStats
+
+	watch *watcher
+
+	// overlay, when set, is drawn instead of the panes and receives all
+	// key presses.
+	overlay overlay
+
+	// gen is the generation of the latest comparison and paneGen that of the
+	// latest load per pane; see jobs.go.
+	gen     int
+	paneGen []int
+	// running is the generation of the comparison in flight, shown by
+	// spinner while it equals gen.
+	running int
+	spinner spinner.Model
 }
 
 func newModel() model {
 	m := model{
-		inputs: make([]textarea.Model, initialInputs),
-		help:   help.New(),
-		keymap: keymap{
-			next: key.NewBinding(
-				key.WithKeys("tab"),
-				key.WithHelp("tab", "next"),
-			),
-			prev: key.NewBinding(
-				key.WithKeys("shift+tab"),
-				key.WithHelp("shift+tab", "prev"),
-			),
-			quit: key.NewBinding(
-				key.WithKeys("esc", "ctrl+c"),
-				key.WithHelp("esc", "quit"),
-			),
-			compare: key.NewBinding(
-				key.WithKeys("ctrl+r"),
-				key.WithHelp("ctrl+r", "compare"),
-			),
-		},
-	}
-	for i := 0; i < initialInputs-1; i++ { // Only create editable textareas for the first two
-		m.inputs[i] = newTextarea()
-	}
-	m.inputs[m.focus].Focus()
-
-	// Create a new textarea for the result
-	t := newTextarea()
-	m.inputs[initialInputs-1] = t // Add it to the inputs
-
+		session:    newSession(),
+		tabs:       make([]session, 1),
+		paneGen:    make([]int, initialInputs),
+		help:       help.New(),
+		format:     "plain",
+		split:      defaultSplit,
+		resultRows: resultHeight,
+		sideSplit:  defaultSplit,
+		tabWidth:   defaultTabWidth,
+		tips:       &tipStore{Seen: map[string]bool{}},
+		stats:      &usageStats{Transforms: map[string]int{}},
+		keymap:     newKeymap(),
+		spinner:    spinner.New(spinner.WithSpinner(spinner.MiniDot)),
+	}
+	settings.apply(&m)
 	return m
 }
 
+// setInputs fills the input panes with texts loaded from paths. "-" stands
+// for standard input and is not remembered as a path.
+func (m *model) setInputs(texts, paths []string) {
+	for i, text := range texts {
+		m.setPane(i, text)
+	}
+	for i, path := range paths {
+		if path != "-" {
+			m.paths[i] = path
+		}
+	}
+}
+
 func (m model) Init() tea.Cmd {
-	return textarea.Blink
+	status := ""
+	if m.result != "" {
+		status = m.diffStatus()
+	}
+	cmds := []tea.Cmd{textarea.Blink, m.titleCmd(status)}
+	if m.watch != nil {
+		cmds = append(cmds, m.watch.waitForChange())
+	}
+	if m.lockAfter > 0 {
+		cmds = append(cmds, idleCmd(m.lockAfter))
+	}
+	if m.draft != "" {
+		cmds = append(cmds, draftCmd())
+	}
+	return tea.Batch(cmds...)
 }
 
+// Update handles msg with update, then schedules a live comparison if that
+// edited the panes.
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	n := next.(model)
+	if live := n.scheduleLive(&m); live != nil {
+		cmd = tea.Batch(cmd, live)
+	}
+	return n, cmd
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		m.notice = ""
+		m.lastKey = time.Now()
+		if o := m.overlay; o != nil {
+			done, cmd := o.update(&m, msg)
+			if done && m.overlay == o {
+				m.overlay = nil
+			}
+			return m, cmd
+		}
+		if m.search != nil && m.search.editing {
+			return m, m.search.update(&m, msg)
+		}
+		if m.vim != nil {
+			if done, cmd := m.vim.update(&m, msg); done {
+				m.followScroll(m.focus)
+				return m, cmd
+			}
+		}
+		if m.search != nil && msg.String() == "esc" {
+			m.search = nil
+			m.refreshResult()
+			return m, nil
+		}
+		if m.focus == len(m.inputs)-1 {
+			// The result pane takes no text, so it has the keys of a pager.
+			switch msg.String() {
+			case "/":
+				msg = tea.KeyMsg{Type: tea.KeyCtrlF}
+			case "n":
+				msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}, Alt: true}
+			case "N":
+				msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}, Alt: true}
+			}
+		}
 		switch {
+		case key.Matches(msg, m.keymap.copy):
+			m.copyFocused()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.paste):
+			return m, m.paste()
+
+		case key.Matches(msg, m.keymap.undo):
+			m.err = m.undo()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.redo):
+			m.err = m.redo()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.search):
+			m.search = newSearch()
+			return m, textinput.Blink
+
+		case key.Matches(msg, m.keymap.nextMatch):
+			m.jumpToMatch(1)
+			return m, nil
+
+		case key.Matches(msg, m.keymap.prevMatch):
+			m.jumpToMatch(-1)
+			return m, nil
+
 		case key.Matches(msg, m.keymap.quit):
 			for i := range m.inputs {
 				m.inputs[i].Blur()
@@ -146,119 +531,454 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 
 		case key.Matches(msg, m.keymap.compare):
-			// Get the text from the two textareas
-			text1 := m.inputs[0].Value()
-			text2 := m.inputs[1].Value()
+			// Compare the two textareas in the background
+			cmds = append(cmds, m.startCompare())
+
+		case key.Matches(msg, m.keymap.restore):
+			m.restorePane(m.focus)
+			return m, nil
+
+		case key.Matches(msg, m.keymap.transform):
+			m.overlay = transformMenu()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.export):
+			m.overlay = newExportPrompt("Export diff to file", "diff."+m.format, (*model).exportedResult)
+			return m, nil
+
+		case key.Matches(msg, m.keymap.save):
+			m.overlay = m.savePrompt()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.open):
+			var cmd tea.Cmd
+			m.overlay, cmd = newFilePicker(&m)
+			return m, cmd
+
+		case key.Matches(msg, m.keymap.newTab):
+			return m, m.newTab()
+
+		case key.Matches(msg, m.keymap.closeTab):
+			return m, m.closeTab()
+
+		case key.Matches(msg, m.keymap.nextTab):
+			return m, m.switchTab(1)
+
+		case key.Matches(msg, m.keymap.prevTab):
+			return m, m.switchTab(-1)
+
+		case key.Matches(msg, m.keymap.zoom):
+			m.toggleZoom()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.help):
+			m.overlay = &helpScreen{}
+			return m, nil
+
+		case key.Matches(msg, m.keymap.lockScroll):
+			m.toggleLockScroll()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.wrap):
+			m.toggleWrap()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.palette):
+			m.overlay = paletteMenu(&m)
+			return m, nil
+
+		case key.Matches(msg, m.keymap.swap):
+			return m, m.swapPanes()
+
+		case key.Matches(msg, m.keymap.clearPane):
+			m.overlay = m.confirmClearPane()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.clearAll):
+			m.overlay = m.confirmClearAll()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.live):
+			return m, m.toggleLive()
+
+		case key.Matches(msg, m.keymap.dismissTip):
+			m.dismissTip()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.stats):
+			m.overlay = statsScreen{}
+			return m, nil
+
+		case key.Matches(msg, m.keymap.preset):
+			m.overlay = presetMenu()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.roundTrip):
+			m.overlay = roundTripMenu()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.ignoreCase):
+			m.options.IgnoreCase = !m.options.IgnoreCase
+			return m, m.startCompare()
+
+		case key.Matches(msg, m.keymap.unit):
+			m.options.Unit = diff.Units[(int(m.options.Unit)+1)%len(diff.Units)]
+			return m, m.startCompare()
+
+		case key.Matches(msg, m.keymap.template):
+			m.options.Template = !m.options.Template
+			return m, m.startCompare()
+
+		case key.Matches(msg, m.keymap.ignoreEOL):
+			m.options.IgnoreLineEndings = !m.options.IgnoreLineEndings
+			return m, m.startCompare()
+
+		case key.Matches(msg, m.keymap.overlap):
+			m.options.Overlap = !m.options.Overlap
+			return m, m.startCompare()
+
+		case key.Matches(msg, m.keymap.match):
+			return m, m.cycleMatch()
+
+		case key.Matches(msg, m.keymap.appendResults):
+			m.toggleAppend()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.ids):
+			m.overlay = newIDScreen(&m)
+			return m, nil
+
+		case key.Matches(msg, m.keymap.replace):
+			m.overlay = newFindReplace(&m)
+			return m, nil
+
+		case key.Matches(msg, m.keymap.regexTester):
+			m.overlay = newRegexTester(&m)
+			return m, nil
+
+		case key.Matches(msg, m.keymap.frequency):
+			m.overlay = newFrequencyScreen(&m)
+			return m, nil
 
-			// Use diffmatchpatch to compare the texts
-			dmp := diffmatchpatch.New()
-			diffs := dmp.DiffMain(text1, text2, false)
+		case key.Matches(msg, m.keymap.whitespace):
+			m.showWhitespace = !m.showWhitespace
+			return m, m.startCompare()
 
-			// Colorize the diffs
-			coloredDiff := colorizeDiffs(diffs)
+		case key.Matches(msg, m.keymap.grow):
+			m.resize(1)
+			return m, nil
 
-			// Set the colored diff in the third textarea
-			m.inputs[2].SetValue(coloredDiff)
+		case key.Matches(msg, m.keymap.shrink):
+			m.resize(-1)
+			return m, nil
 
-			// Update m.diff
-			m.diff = coloredDiff
+		case key.Matches(msg, m.keymap.theme):
+			return m, m.cycleTheme()
+
+		case key.Matches(msg, m.keymap.layout):
+			m.toggleLayout()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.resetLayout):
+			m.resetLayout()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.inspect):
+			m.overlay = newInspector(&m)
+			return m, nil
+
+		case key.Matches(msg, m.keymap.markdown):
+			if m.preview != nil {
+				m.preview = nil
+			} else {
+				m.preview = &markdownPreview{}
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keymap.present):
+			m.overlay = newPresentation(&m)
+			return m, nil
+
+		case key.Matches(msg, m.keymap.hide):
+			m.overlay = privacyScreen{}
+			return m, nil
+
+		case key.Matches(msg, m.keymap.textStats):
+			m.showTextStats = !m.showTextStats
+			return m, nil
+
+		case key.Matches(msg, m.keymap.colorCheck):
+			m.overlay = colorCheck{}
+			return m, nil
+
+		case key.Matches(msg, m.keymap.hashes):
+			m.overlay = newHashScreen(&m)
+			return m, nil
+		}
+	case diffMsg:
+		if msg.gen != m.gen {
+			return m, nil
+		}
+		m.running = 0
+		m.setDiff(msg.res)
+		return m, m.titleCmd(m.diffStatus())
+	case matchMsg:
+		if msg.gen != m.gen {
+			return m, nil
+		}
+		m.running = 0
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.setMatch(msg.res)
+		if msg.res.Matched {
+			return m, m.titleCmd("matches")
+		}
+		return m, m.titleCmd("does not match")
+	case loadMsg:
+		if msg.gen != m.paneGen[msg.pane] {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		if msg.insert {
+			m.insertText(msg.pane, msg.text)
+			return m, nil
 		}
+		m.replacePane(msg.pane, msg.text)
+		if m.watch != nil {
+			return m, m.startCompare()
+		}
+		return m, nil
+	case tea.MouseMsg:
+		if m.overlay != nil {
+			return m, nil
+		}
+		return m, m.mouse(msg)
+	case idleMsg:
+		return m, m.checkIdle()
+	case draftMsg:
+		return m, m.saveDraft()
+	case liveMsg:
+		return m, m.compareLive(msg.seq)
+	case spinner.TickMsg:
+		if !m.comparing() {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	case fileChangedMsg:
+		return m, tea.Batch(m.fileChanged(msg.path), m.watch.waitForChange())
 	case tea.WindowSizeMsg:
 		m.height = msg.Height
 		m.width = msg.Width
 	}
 
+	if r, ok := m.overlay.(receiver); ok {
+		cmds = append(cmds, r.receive(msg))
+	}
+
 	m.sizeInputs()
 
-	// Update all textareas
+	// Keys for the result pane scroll it
+	if msg, ok := msg.(tea.KeyMsg); ok && m.focus == len(m.inputs)-1 {
+		return m, tea.Batch(append(cmds, m.scrollResult(msg))...)
+	}
+
+	// Update all textareas, keeping what the focused input pane held
+	// before a key edited it
+	_, isKey := msg.(tea.KeyMsg)
+	edits := isKey && m.focus < len(m.history)
+	var before string
+	if edits {
+		before = m.inputs[m.focus].Value()
+	}
 	for i := range m.inputs {
 		newModel, cmd := m.inputs[i].Update(msg)
 		m.inputs[i] = newModel
 		cmds = append(cmds, cmd)
 	}
+	if edits && m.inputs[m.focus].Value() != before {
+		m.keep(m.focus, m.withLineEndings(m.focus, before), true)
+		m.edits++
+	}
+	if isKey {
+		m.followScroll(m.focus)
+	}
 
 	return m, tea.Batch(cmds...)
 }
 
+// render renders d in the selected output format. An error rendering it
+// is shown and nothing is rendered.
+func (m *model) render(d diff.Diff) string {
+	r, ok := render.Lookup(m.format, diffStyles)
+	if !ok {
+		r, _ = render.Lookup("plain", diffStyles)
+	}
+	out, err := r(d)
+	if err != nil {
+		m.err = err
+	}
+	return out
+}
+
+// setDiff shows res as the result of the comparison.
+func (m *model) setDiff(res compare.Result) {
+	m.diff = res.Diff
+	if m.appending {
+		m.setResult(m.appendResult(res))
+		return
+	}
+	m.setResult(res.Report + m.colorDiff(res.Diff))
+}
+
+// setResult shows s, already colored, in the result pane.
+func (m *model) setResult(s string) {
+	m.result = s
+	m.refreshResult()
+}
+
+// replacePane overwrites the content of pane i, keeping what was there so
+// restorePane can bring it back.
+func (m *model) replacePane(i int, text string) {
+	prev := m.paneText(i)
+	m.previous[i] = &prev
+	if i < len(m.history) {
+		m.keep(i, prev, false)
+	}
+	m.setPane(i, text)
+}
+
+// restorePane swaps pane i with its content from before the last
+// replacePane, so restoring twice undoes the restore.
+func (m *model) restorePane(i int) {
+	if m.previous[i] == nil {
+		m.err = fmt.Errorf("nothing to restore")
+		return
+	}
+	m.err = nil
+	m.replacePane(i, *m.previous[i])
+}
+
 func (m *model) sizeInputs() {
-	for i := 0; i < len(m.inputs)-1; i++ { // Only size the first two textareas
-		m.inputs[i].SetWidth(m.width / (len(m.inputs) - 1))
-		m.inputs[i].SetHeight((m.height - helpHeight - resultHeight) / 2)
+	if m.zoomed {
+		m.sizeZoomed()
+		return
+	}
+	if m.vertical {
+		m.sizeStacked()
+		return
+	}
+	// Split the width between the first two textareas
+	first := m.width * m.split / 100
+	m.inputs[0].SetWidth(first)
+	m.inputs[1].SetWidth(m.width - first)
+	// and give them the height the result pane leaves
+	frame := focusedBorderStyle.GetVerticalFrameSize()
+	for i := 0; i < len(m.inputs)-1; i++ {
+		m.inputs[i].SetHeight(m.height - helpHeight - m.resultRows - 2*frame)
 	}
 
 	// Size the result textarea
 	m.inputs[len(m.inputs)-1].SetWidth(m.width)
-	m.inputs[len(m.inputs)-1].SetHeight(resultHeight)
+	m.inputs[len(m.inputs)-1].SetHeight(m.resultRows)
+	m.sizeResult(m.width, m.resultRows)
 }
 
 func (m model) View() string {
+	v := m.view()
+	if m.recorder != nil {
+		m.recorder.frame(v, m.width, m.height)
+	}
+	return v
+}
+
+func (m model) view() string {
+	if m.overlay != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.overlay.view(&m))
+	}
+
 	help := m.help.ShortHelpView([]key.Binding{
 		m.keymap.next,
-		m.keymap.prev,
 		m.keymap.quit,
 		m.keymap.compare,
+		m.keymap.palette,
+		m.keymap.help,
 	})
 
 	var views []string
 	for i := 0; i < len(m.inputs)-1; i++ { // Only join the first two textareas horizontally
+		if m.vim != nil && m.vim.mode == vimVisual && i == m.focus {
+			views = append(views, m.vim.selectionView(&m))
+			continue
+		}
+		if m.preview != nil && m.focus <= 1 && i != m.focus {
+			views = append(views, m.previewView(m.focus, i))
+			continue
+		}
+		if m.search != nil && m.search.re != nil && i == m.focus {
+			views = append(views, m.linesView(i, m.search.highlight))
+			continue
+		}
+		if m.showWhitespace {
+			views = append(views, m.visibleView(i))
+			continue
+		}
+		if m.unwrapped[i] != nil {
+			views = append(views, m.unwrappedView(i))
+			continue
+		}
 		views = append(views, m.inputs[i].View())
 	}
 
-	// Wrap the diff result to the terminal width
-	diff := wrapText(m.diff, m.width)
-
-	return lipgloss.JoinHorizontal(lipgloss.Top, views...) + "\n" + m.inputs[len(m.inputs)-1].View() + "\n" + " " + help + "\n\n" + diff
-}
-
-func colorizeDiffs(diffs []diffmatchpatch.Diff) string {
-	var coloredDiff string
-	for _, diff := range diffs {
-		switch diff.Type {
-		case diffmatchpatch.DiffInsert:
-			// Green for insertions
-			coloredDiff += lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Render(diff.Text)
-		case diffmatchpatch.DiffDelete:
-			// Red for deletions
-			coloredDiff += lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Render(diff.Text)
-		case diffmatchpatch.DiffEqual:
-			coloredDiff += diff.Text
+	if m.err != nil {
+		help += "  " + errorStyle.Render(m.err.Error())
+	} else if m.notice != "" {
+		help += "  " + m.notice
+	} else if tip := m.tipView(); tip != "" {
+		help += "  " + tip
+	}
+	if m.search != nil {
+		help += "  " + m.search.view(&m)
+	}
+	for _, n := range m.diff.Notes {
+		if n.Kind == diff.Truncated || n.Kind == diff.TimedOut {
+			help += "  " + errorStyle.Render("⚠ "+n.Text)
 		}
-		coloredDiff += "\n"
 	}
-	return coloredDiff
-}
 
-// Wrap text to terminal width
-func wrapText(input string, limit int) string {
-	words := strings.Fields(input)
-	if len(words) == 0 {
-		return input
-	}
-	wrapped := words[0]
-	remain := limit - len(wrapped)
-	for _, word := range words[1:] {
-		if len(word)+1 > remain {
-			wrapped += "\n" + word
-			remain = limit - len(word)
-		} else {
-			wrapped += " " + word
-			remain -= len(word) + 1
-		}
+	if stats := m.textStatsView(); stats != "" {
+		help += "\n " + stats
 	}
-	return wrapped
+
+	var panes string
+	switch {
+	case m.zoomed && m.focus < len(views):
+		panes = views[m.focus]
+	case m.zoomed:
+		panes = m.resultView()
+	case m.vertical:
+		panes = lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.JoinVertical(lipgloss.Left, views...), m.resultView())
+	default:
+		panes = lipgloss.JoinHorizontal(lipgloss.Top, views...) + "\n" + m.resultView()
+	}
+	return panes + "\n" + m.statusView() + "\n" + " " + help
 }
+
 func main() {
-	if _, err := tea.NewProgram(newModel(), tea.WithAltScreen()).Run(); err != nil {
-		fmt.Println("Error while running program:", err)
-		os.Exit(1)
-	}
-	//dmp := diffmatchpatch.New()
-	//
-	//str1 := "Hello"
-	//str2 := "Hello Go bro "
-	//
-	//diffs := dmp.DiffMain(str1, str2, false)
-	//fmt.Println(diffs)
-	//
-	//fmt.Println(dmp.DiffPrettyText(diffs))
+	err := newRootCmd().Execute()
+	switch {
+	case errors.Is(err, errDiffer):
+		os.Exit(exitDiffer)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(exitError)
+	}
+	os.Exit(exitSame)
 }
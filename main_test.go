@@ -0,0 +1,215 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/diff"
+)
+
+// TestMain runs main instead of the tests when runMain starts the test binary
+// again, so tests can check what the command prints and how it exits. Either
+// way state files go to a temporary directory rather than the user's.
+func TestMain(m *testing.M) {
+	if os.Getenv("STRCLI_RUN_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	dir, err := os.MkdirTemp("", "strcli-test")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("XDG_CONFIG_HOME", dir)
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// runMain runs strcli with args and stdin and returns what it printed to
+// standard output and its exit status.
+func runMain(t *testing.T, stdin string, args ...string) (string, int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "STRCLI_RUN_MAIN=1")
+	cmd.Stdin = strings.NewReader(stdin)
+	out, err := cmd.Output()
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		return string(out), exit.ExitCode()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out), 0
+}
+
+// update passes msg to m and returns the updated model and its command.
+func update(m model, msg tea.Msg) (model, tea.Cmd) {
+	next, cmd := m.Update(msg)
+	return next.(model), cmd
+}
+
+// settle passes msg to m, then the messages of the commands that returns,
+// and so on, as the program would. Commands that do not finish within a
+// moment, such as timers and waits for file changes, are dropped.
+func settle(m model, msg tea.Msg) model {
+	return settleDepth(m, msg, 0)
+}
+
+func settleDepth(m model, msg tea.Msg, depth int) model {
+	m, cmd := update(m, msg)
+	if depth == 20 {
+		return m
+	}
+	for _, msg := range runCmd(cmd) {
+		m = settleDepth(m, msg, depth+1)
+	}
+	return m
+}
+
+// runCmd runs cmd and returns its messages, expanding batches.
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	ch := make(chan tea.Msg, 1)
+	go func() { ch <- cmd() }()
+	select {
+	case msg := <-ch:
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			var msgs []tea.Msg
+			for _, c := range batch {
+				msgs = append(msgs, runCmd(c)...)
+			}
+			return msgs
+		}
+		if msg == nil {
+			return nil
+		}
+		return []tea.Msg{msg}
+	case <-time.After(50 * time.Millisecond):
+		return nil
+	}
+}
+
+// alt returns the key message for alt and the key r.
+func alt(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}, Alt: true}
+}
+
+func TestRestorePane(t *testing.T) {
+	tests := []struct {
+		name     string
+		loads    []string
+		restores int
+		want     string
+		wantErr  bool
+	}{
+		{"nothing loaded", nil, 1, "typed", true},
+		{"undo a load", []string{"loaded"}, 1, "typed", false},
+		{"restore twice redoes it", []string{"loaded"}, 2, "loaded", false},
+		{"only the last load is kept", []string{"first", "second"}, 1, "first", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.inputs[0].SetValue("typed")
+			for _, text := range tt.loads {
+				m, _ = update(m, m.startLoad(0, func() (string, error) { return text, nil })())
+			}
+			for i := 0; i < tt.restores; i++ {
+				m, _ = update(m, alt('r'))
+			}
+			if got := m.inputs[0].Value(); got != tt.want {
+				t.Errorf("pane = %q, want %q", got, tt.want)
+			}
+			if (m.err != nil) != tt.wantErr {
+				t.Errorf("err = %v", m.err)
+			}
+		})
+	}
+}
+
+func TestIgnoreCaseKey(t *testing.T) {
+	m := newModel()
+	m.inputs[0].SetValue("Straße")
+	m.inputs[1].SetValue("STRASSE")
+	for i, want := range []bool{true, false} {
+		m = settle(m, alt('i'))
+		if m.options.IgnoreCase != want || m.diff.Equal() != want {
+			t.Errorf("after %d presses: IgnoreCase = %v, Equal() = %v, want %v", i+1, m.options.IgnoreCase, m.diff.Equal(), want)
+		}
+		if got := strings.Contains(m.View(), "ignoring case"); got != want {
+			t.Errorf("after %d presses: help mentions ignoring case = %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestOverlapKey(t *testing.T) {
+	m := newModel()
+	m.inputs[0].SetValue("hello world")
+	m.inputs[1].SetValue("hello")
+	m = settle(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	if !strings.Contains(m.View(), "⚠ B is truncated") {
+		t.Error("the help line does not warn that B is truncated")
+	}
+	for i, want := range []bool{true, false} {
+		m = settle(m, alt('o'))
+		if m.options.Overlap != want || m.diff.Equal() != want {
+			t.Errorf("after %d presses: Overlap = %v, Equal() = %v, want %v", i+1, m.options.Overlap, m.diff.Equal(), want)
+		}
+	}
+}
+
+func TestUnitKey(t *testing.T) {
+	m := newModel()
+	m.inputs[0].SetValue("e\u0301")
+	m.inputs[1].SetValue("e\u0300")
+	for _, want := range []diff.Unit{diff.Rune, diff.Byte, diff.Grapheme} {
+		m = settle(m, alt('g'))
+		if m.options.Unit != want || m.diff.Unit != want {
+			t.Errorf("unit = %v, diffed by %v, want %v", m.options.Unit, m.diff.Unit, want)
+		}
+		if !strings.Contains(m.View(), want.String()+" diff") {
+			t.Errorf("the status bar does not mention the %v unit", want)
+		}
+	}
+}
+
+func TestExportPrompt(t *testing.T) {
+	for _, format := range []string{"plain", "json"} {
+		t.Run(format, func(t *testing.T) {
+			m := newModel()
+			m.format = format
+			m.inputs[0].SetValue("a")
+			m = compareNow(m)
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlX})
+			p, ok := m.overlay.(*exportPrompt)
+			if !ok {
+				t.Fatalf("overlay = %T, want the export prompt", m.overlay)
+			}
+			if got := p.input.Value(); got != "diff."+format {
+				t.Errorf("suggested %q", got)
+			}
+			path := filepath.Join(t.TempDir(), "out")
+			p.input.SetValue(path)
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+			if m.err != nil {
+				t.Fatal(m.err)
+			}
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := m.render(m.diff); string(b) != want {
+				t.Errorf("exported %q, want %q", b, want)
+			}
+		})
+	}
+}
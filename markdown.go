@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// markdownPreview renders the focused pane as Markdown in place of the
+// other input pane. It keeps the last rendering, since the view is drawn
+// far more often than the text changes.
+type markdownPreview struct {
+	src    string
+	width  int
+	out    string
+	failed error
+}
+
+// render returns src rendered for a pane width cells wide.
+func (p *markdownPreview) render(src string, width int) (string, error) {
+	if src == p.src && width == p.width && p.out != "" {
+		return p.out, p.failed
+	}
+	p.src, p.width = src, width
+	// The background was detected before the program took over the
+	// terminal; asking again now would race it for input.
+	style := "light"
+	if lipgloss.HasDarkBackground() {
+		style = "dark"
+	}
+	if currentTheme.Plain {
+		style = "notty"
+	}
+	r, err := glamour.NewTermRenderer(glamour.WithStandardStyle(style), glamour.WithWordWrap(width))
+	if err == nil {
+		p.out, err = r.Render(src)
+	}
+	p.failed = err
+	return p.out, err
+}
+
+// previewView draws the Markdown preview of pane src in place of pane dst,
+// at the same size.
+func (m *model) previewView(src, dst int) string {
+	pane := m.inputs[dst].View()
+	style := focusedBorderStyle.Copy().BorderForeground(currentTheme.Subtle)
+	width := lipgloss.Width(pane) - style.GetHorizontalFrameSize()
+	height := lipgloss.Height(pane) - style.GetVerticalFrameSize()
+	out, err := m.preview.render(m.inputs[src].Value(), width)
+	if err != nil {
+		out = errorStyle.Render("markdown: " + err.Error())
+	}
+	lines := strings.Split(strings.Trim(out, "\n"), "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return style.Width(width).Height(height).Render(strings.Join(lines, "\n"))
+}
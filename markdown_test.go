@@ -0,0 +1,53 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// sgrPattern matches the escape sequences glamour colors its output with,
+// whatever the terminal.
+var sgrPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func TestMarkdownPreview(t *testing.T) {
+	var p markdownPreview
+	out, err := p.render("Some **bold** text\n\n* one\n* two\n", 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out = sgrPattern.ReplaceAllString(out, "")
+	for _, want := range []string{"Some bold text", "• one", "• two"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered\n%s\nwant %q in it", out, want)
+		}
+	}
+	if strings.Contains(out, "**") {
+		t.Errorf("rendered\n%s\nwith the markup", out)
+	}
+	// The same text at the same width is not rendered again.
+	p.out = "cached"
+	if again, _ := p.render("Some **bold** text\n\n* one\n* two\n", 40); again != "cached" {
+		t.Error("rendered the same text again")
+	}
+	if other, _ := p.render("Some **bold** text\n\n* one\n* two\n", 30); other == "cached" {
+		t.Error("did not render again for a new width")
+	}
+}
+
+func TestMarkdownKey(t *testing.T) {
+	m := newModel()
+	m.width, m.height = 100, 30
+	m.sizeInputs()
+	m.inputs[0].SetValue("Plain *emphasis* here")
+	m.inputs[1].SetValue("pane B text")
+	m, _ = update(m, alt('k'))
+	v := sgrPattern.ReplaceAllString(m.View(), "")
+	if !strings.Contains(v, "Plain emphasis here") || strings.Contains(v, "pane B text") {
+		t.Errorf("pane B is not replaced by the preview:\n%s", v)
+	}
+	m, _ = update(m, alt('k'))
+	if v := m.View(); !strings.Contains(v, "pane B text") {
+		t.Errorf("pane B is not back after switching off:\n%s", v)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"strcli/pkg/diff"
+	"strcli/pkg/pattern"
+)
+
+var matchStyle = lipgloss.NewStyle()
+
+func newMatchCmd() *cobra.Command {
+	var syntaxName string
+	var quiet bool
+	cmd := &cobra.Command{
+		Use:   "match PATTERN [FILE]",
+		Short: "Check whether a file matches the pattern held in another file",
+		Long: `Check whether the whole of FILE matches the regular expression or glob in the
+file PATTERN. If it does not, the first point where FILE stops matching is
+shown. The exit status is 0 if FILE matches, 1 if it does not and 2 on error.
+
+In a glob, * matches any run of characters within a line, ? any one
+character and [...] one character of a class; \ escapes the next character.
+A template is literal text with placeholders: {{any}}, {{number}}, {{hex}},
+{{uuid}} and {{timestamp}}.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := pattern.ParseSyntax(syntaxName)
+			if err != nil {
+				return err
+			}
+			texts, err := readInputs([]string{args[0], fileArg(args[1:])})
+			if err != nil {
+				return err
+			}
+			p, err := pattern.Compile(texts[0], s)
+			if err != nil {
+				return err
+			}
+			text := texts[1]
+			res := p.Match(text)
+			if !quiet {
+				fmt.Fprint(cmd.OutOrStdout(), matchReport(text, res, func(s string) string { return s }))
+			}
+			if !res.Matched {
+				return errDiffer
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&syntaxName, "syntax", pattern.Regex.String(), "pattern syntax: regex, glob or template")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "print nothing; only set the exit status")
+	cmd.RegisterFlagCompletionFunc("syntax", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		var names []string
+		for _, s := range pattern.Syntaxes {
+			names = append(names, s.String())
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+// matchReport describes res, the result of matching text. When text does
+// not match, the line where it stops matching is shown with a caret under
+// the first character that does not fit, both rendered by mark.
+func matchReport(text string, res pattern.Result, mark func(string) string) string {
+	if res.Matched {
+		return "matches\n"
+	}
+	start := strings.LastIndex(text[:res.Offset], "\n") + 1
+	end := strings.IndexByte(text[res.Offset:], '\n')
+	if end < 0 {
+		end = len(text)
+	} else {
+		end += res.Offset
+	}
+	what := "does not match"
+	if res.Offset == len(text) {
+		what = "ends before the pattern does"
+	}
+	return fmt.Sprintf("line %d, column %d: %s\n%s%s\n%s\n",
+		res.Line, res.Column, what,
+		text[start:res.Offset], mark(text[res.Offset:end]),
+		mark(strings.Repeat(" ", res.Column-1)+"^"))
+}
+
+// cycleMatch switches from comparing to matching the second pane against a
+// pattern in the first, through each pattern syntax, and back.
+func (m *model) cycleMatch() tea.Cmd {
+	switch {
+	case !m.matching:
+		m.matching, m.syntax = true, pattern.Syntaxes[0]
+	case int(m.syntax)+1 < len(pattern.Syntaxes):
+		m.syntax++
+	default:
+		m.matching = false
+	}
+	return m.startCompare()
+}
+
+// matchMsg delivers a match started at generation gen.
+type matchMsg struct {
+	gen int
+	res pattern.Result
+	err error
+}
+
+// matchCmd matches text against the pattern src in the background.
+func matchCmd(gen int, src, text string, s pattern.Syntax) tea.Cmd {
+	return func() tea.Msg {
+		p, err := pattern.Compile(src, s)
+		if err != nil {
+			return matchMsg{gen: gen, err: err}
+		}
+		return matchMsg{gen: gen, res: p.Match(text)}
+	}
+}
+
+// setMatch shows res, the result of matching the second pane, as the
+// result.
+func (m *model) setMatch(res pattern.Result) {
+	m.diff = diff.Diff{}
+	report := matchReport(m.inputs[1].Value(), res, func(s string) string { return errorStyle.Render(s) })
+	if res.Matched {
+		report = matchStyle.Render("✓ " + m.syntax.String() + " matches")
+	}
+	m.setResult(report)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"strcli/pkg/pattern"
+)
+
+func TestMatchCommand(t *testing.T) {
+	dir := t.TempDir()
+	re := filepath.Join(dir, "re")
+	os.WriteFile(re, []byte(`took \d+ ms`), 0o644)
+	glob := filepath.Join(dir, "glob")
+	os.WriteFile(glob, []byte(`took * ms`), 0o644)
+	tmpl := filepath.Join(dir, "tmpl")
+	os.WriteFile(tmpl, []byte(`took {{number}} ms`), 0o644)
+
+	tests := []struct {
+		name  string
+		stdin string
+		args  []string
+		// want is the output expected.
+		want     string
+		wantCode int
+	}{
+		{"regex matches", "took 12 ms", []string{"match", re}, "matches\n", exitSame},
+		{"regex does not match", "took ten ms", []string{"match", re}, "line 1, column 6: does not match\ntook ten ms\n     ^\n", exitDiffer},
+		{"text ends early", "took 12", []string{"match", re}, "line 1, column 8: ends before the pattern does\ntook 12\n       ^\n", exitDiffer},
+		{"glob", "took ten ms", []string{"match", "--syntax", "glob", glob}, "matches\n", exitSame},
+		{"template", "took 12 ms", []string{"match", "--syntax", "template", tmpl}, "matches\n", exitSame},
+		{"template mismatch", "took ten ms", []string{"match", "--syntax", "template", tmpl}, "line 1, column 6: does not match\ntook ten ms\n     ^\n", exitDiffer},
+		{"quiet", "took ten ms", []string{"match", "-q", re}, "", exitDiffer},
+		{"unknown syntax", "", []string{"match", "--syntax", "pcre", re}, "", exitError},
+		{"invalid pattern", "", []string{"match", "--syntax", "glob", re + "x"}, "", exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, code := runMain(t, tt.stdin, tt.args...)
+			if code != tt.wantCode {
+				t.Errorf("exit status = %d, want %d", code, tt.wantCode)
+			}
+			if tt.wantCode != exitError && out != tt.want {
+				t.Errorf("printed\n%q\nwant\n%q", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateKey(t *testing.T) {
+	m := newModel()
+	m.inputs[0].SetValue("took {{number}} ms\n")
+	m.inputs[1].SetValue("took 12 ms\n")
+	for i, want := range []bool{true, false} {
+		m = settle(m, alt('t'))
+		if m.options.Template != want || m.diff.Equal() != want {
+			t.Errorf("after %d presses: Template = %v, Equal() = %v, want %v", i+1, m.options.Template, m.diff.Equal(), want)
+		}
+	}
+}
+
+func TestMatchKey(t *testing.T) {
+	m := newModel()
+	m.inputs[0].SetValue("a*")
+	m.inputs[1].SetValue("abc")
+	tests := []struct {
+		matching bool
+		syntax   pattern.Syntax
+		want     string
+	}{
+		{true, pattern.Regex, "column 2: does not match"},
+		{true, pattern.Glob, "✓ glob matches"},
+		{true, pattern.Template, "column 2: does not match"},
+		{false, pattern.Template, ""},
+	}
+	for i, tt := range tests {
+		m = settle(m, alt('m'))
+		if m.matching != tt.matching || (tt.matching && m.syntax != tt.syntax) {
+			t.Errorf("after %d presses: matching %v %v, want %v %v", i+1, m.matching, m.syntax, tt.matching, tt.syntax)
+		}
+		if !strings.Contains(m.result, tt.want) {
+			t.Errorf("after %d presses: result %q, want %q in it", i+1, m.result, tt.want)
+		}
+	}
+	if m.diff.Equal() {
+		t.Error("comparing again did not restore the diff")
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// wheelLines is how many lines a turn of the scroll wheel moves.
+const wheelLines = 3
+
+var escapePattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// rect is the area of the screen a pane is drawn in.
+type rect struct{ x, y, w, h int }
+
+func (r rect) contains(x, y int) bool {
+	return x >= r.x && x < r.x+r.w && y >= r.y && y < r.y+r.h
+}
+
+// drag is a press of the left button on a line of a pane, kept until the
+// button is released.
+type drag struct {
+	pane, line int
+}
+
+// paneRects returns where each pane is drawn by view. Panes hidden by a
+// zoom take no room.
+func (m *model) paneRects() []rect {
+	rs := make([]rect, len(m.inputs))
+	for i := range m.inputs {
+		if m.zoomed && i != m.focus {
+			continue
+		}
+		v := m.inputs[i].View()
+		if i == len(m.inputs)-1 {
+			v = m.resultView()
+		}
+		rs[i] = rect{w: lipgloss.Width(v), h: lipgloss.Height(v)}
+	}
+	if m.zoomed {
+		return rs
+	}
+	a, b, res := &rs[0], &rs[1], &rs[2]
+	if m.vertical {
+		b.y = a.h
+		res.x = max(a.w, b.w)
+	} else {
+		b.x = a.w
+		res.y = max(a.h, b.h)
+	}
+	return rs
+}
+
+// paneAt returns the pane drawn at x, y.
+func (m *model) paneAt(x, y int) (int, bool) {
+	for i, r := range m.paneRects() {
+		if r.contains(x, y) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// panePos is a place in the text of a pane: a line, the row of that line
+// when it wraps, and a column within the row.
+type panePos struct {
+	line, row, col int
+}
+
+// positionAt returns the position in the text of input pane i drawn at x,
+// y, read from the line numbers the pane shows.
+func (m *model) positionAt(i, x, y int) (panePos, bool) {
+	if i >= len(m.inputs)-1 {
+		return panePos{}, false
+	}
+	r := m.paneRects()[i]
+	if m.unwrapped[i] != nil {
+		return m.unwrappedPositionAt(i, x, y, r)
+	}
+	rows := strings.Split(m.inputs[i].View(), "\n")
+	frame := m.inputs[i].FocusedStyle.Base.GetBorderLeftSize()
+	row := y - r.y
+	if row < frame || row >= len(rows)-frame {
+		return panePos{}, false
+	}
+	// Rows past the first of a wrapped line have no number.
+	for wrapped := 0; row >= frame; row, wrapped = row-1, wrapped+1 {
+		text := []rune(escapePattern.ReplaceAllString(rows[row], ""))
+		if len(text) <= frame {
+			return panePos{}, false
+		}
+		gutter := strings.TrimLeft(string(text[frame:]), " ")
+		digits := len(gutter) - len(strings.TrimLeft(gutter, "0123456789"))
+		n, err := strconv.Atoi(gutter[:digits])
+		if err != nil {
+			continue
+		}
+		start := len(text) - frame - len([]rune(gutter)) + digits + 1
+		return panePos{line: n - 1, row: wrapped, col: max(x-r.x-frame-start, 0)}, true
+	}
+	return panePos{}, false
+}
+
+// moveCursor puts the cursor of pane i at p.
+func (m *model) moveCursor(i int, p panePos) {
+	t := &m.inputs[i]
+	for t.Line() < p.line && t.Line() < t.LineCount()-1 {
+		t.CursorDown()
+	}
+	for t.Line() > p.line {
+		t.CursorUp()
+	}
+	t.SetCursor(0)
+	for r := 0; r < p.row && t.LineInfo().RowOffset < t.LineInfo().Height-1; r++ {
+		t.CursorDown()
+	}
+	t.SetCursor(t.LineInfo().StartColumn + p.col)
+}
+
+// focusPane moves the focus to pane i.
+func (m *model) focusPane(i int) tea.Cmd {
+	if i == m.focus {
+		return nil
+	}
+	m.inputs[m.focus].Blur()
+	m.focus = i
+	return m.inputs[i].Focus()
+}
+
+// scrollPane scrolls pane i by n lines. Input panes scroll by moving their
+// cursor.
+func (m *model) scrollPane(i, n int) {
+	if i == len(m.inputs)-1 {
+		if n > 0 {
+			m.results.view.LineDown(n)
+		} else {
+			m.results.view.LineUp(-n)
+		}
+		return
+	}
+	for ; n > 0; n-- {
+		m.inputs[i].CursorDown()
+	}
+	for ; n < 0; n++ {
+		m.inputs[i].CursorUp()
+	}
+	m.followScroll(i)
+}
+
+// mouse handles a mouse event: a click focuses a pane and moves its cursor
+// there, the wheel scrolls the pane under the pointer, and dragging over
+// lines of a pane copies them.
+func (m *model) mouse(msg tea.MouseMsg) tea.Cmd {
+	i, ok := m.paneAt(msg.X, msg.Y)
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp && ok:
+		m.scrollPane(i, -wheelLines)
+	case msg.Button == tea.MouseButtonWheelDown && ok:
+		m.scrollPane(i, wheelLines)
+	case msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress && ok:
+		m.notice = ""
+		cmd := m.focusPane(i)
+		if p, ok := m.positionAt(i, msg.X, msg.Y); ok {
+			m.moveCursor(i, p)
+			m.followScroll(i)
+			m.drag = &drag{pane: i, line: p.line}
+		}
+		return cmd
+	case msg.Action == tea.MouseActionMotion && m.drag != nil:
+		if p, ok := m.positionAt(m.drag.pane, msg.X, msg.Y); ok && i == m.drag.pane {
+			from, to := min(m.drag.line, p.line), max(m.drag.line, p.line)
+			m.notice = fmt.Sprintf("selecting lines %d–%d", from+1, to+1)
+		}
+	case msg.Action == tea.MouseActionRelease && m.drag != nil:
+		d := *m.drag
+		m.drag = nil
+		p, ok := m.positionAt(d.pane, msg.X, msg.Y)
+		if !ok || i != d.pane || p.line == d.line {
+			m.notice = ""
+			return nil
+		}
+		m.copyLines(d.pane, min(d.line, p.line), max(d.line, p.line))
+	}
+	return nil
+}
+
+// copyLines copies lines from through to of pane i to the clipboard.
+func (m *model) copyLines(i, from, to int) {
+	lines := strings.Split(m.inputs[i].Value(), "\n")
+	to = min(to, len(lines)-1)
+	if err := copyText(strings.Join(lines[from:to+1], "\n")); err != nil {
+		m.err = fmt.Errorf("copy: %w", err)
+		return
+	}
+	m.notice = fmt.Sprintf("copied lines %d–%d", from+1, to+1)
+}
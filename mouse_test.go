@@ -0,0 +1,118 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mouseModel returns a sized model with a few lines in pane A.
+func mouseModel(t *testing.T) model {
+	t.Helper()
+	m := newModel()
+	m, _ = update(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+	m.inputs[0].SetValue("one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\neleven\ntwelve")
+	return m
+}
+
+func click(x, y int, button tea.MouseButton, action tea.MouseAction) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Button: button, Action: action}
+}
+
+func TestMouseFocus(t *testing.T) {
+	m := mouseModel(t)
+	rs := m.paneRects()
+	for _, i := range []int{1, 2, 0} {
+		r := rs[i]
+		m, _ = update(m, click(r.x+r.w/2, r.y+r.h/2, tea.MouseButtonLeft, tea.MouseActionPress))
+		if m.focus != i {
+			t.Errorf("clicking pane %d focuses pane %d", i, m.focus)
+		}
+		m, _ = update(m, click(r.x+r.w/2, r.y+r.h/2, tea.MouseButtonLeft, tea.MouseActionRelease))
+	}
+}
+
+func TestMouseCursor(t *testing.T) {
+	m := mouseModel(t)
+	m.inputs[0].CursorStart()
+	r := m.paneRects()[0]
+	tests := []struct {
+		name      string
+		row, col  int
+		line, pos int
+	}{
+		{"first line", 1, 10, 0, 3},
+		{"third line", 3, 0, 2, 0},
+		{"within a line", 3, 2, 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The text starts at the last column still counted as column 0.
+			start := r.x
+			for ; start < r.x+r.w; start++ {
+				if q, _ := m.positionAt(0, start+1, r.y+tt.row); q.col > 0 {
+					break
+				}
+			}
+			x := start + tt.col
+			m, _ := update(m, click(x, r.y+tt.row, tea.MouseButtonLeft, tea.MouseActionPress))
+			if got := m.inputs[0].Line(); got != tt.line {
+				t.Errorf("line %d, want %d", got, tt.line)
+			}
+			if got := m.inputs[0].LineInfo().ColumnOffset; got != tt.pos {
+				t.Errorf("column %d, want %d", got, tt.pos)
+			}
+		})
+	}
+}
+
+func TestMouseOutsideText(t *testing.T) {
+	m := mouseModel(t)
+	r := m.paneRects()[0]
+	// The top border is not a line of text.
+	if _, ok := m.positionAt(0, r.x+5, r.y); ok {
+		t.Error("the border has a position")
+	}
+	if _, ok := m.paneAt(1000, 1000); ok {
+		t.Error("a point off the screen is in a pane")
+	}
+}
+
+func TestMouseWheel(t *testing.T) {
+	m := mouseModel(t)
+	m.inputs[0].CursorStart()
+	for m.inputs[0].Line() > 0 {
+		m.inputs[0].CursorUp()
+	}
+	r := m.paneRects()[0]
+	m, _ = update(m, click(r.x+1, r.y+1, tea.MouseButtonWheelDown, tea.MouseActionPress))
+	if got := m.inputs[0].Line(); got != wheelLines {
+		t.Errorf("the wheel moved to line %d, want %d", got, wheelLines)
+	}
+	m, _ = update(m, click(r.x+1, r.y+1, tea.MouseButtonWheelUp, tea.MouseActionPress))
+	if got := m.inputs[0].Line(); got != 0 {
+		t.Errorf("the wheel moved back to line %d", got)
+	}
+	if m.focus != 0 {
+		t.Error("the wheel moved the focus")
+	}
+}
+
+func TestMouseDrag(t *testing.T) {
+	m := mouseModel(t)
+	r := m.paneRects()[0]
+	x := r.x + r.w/2
+	m, _ = update(m, click(x, r.y+1, tea.MouseButtonLeft, tea.MouseActionPress))
+	if m.drag == nil || m.drag.line != 0 {
+		t.Fatalf("drag %+v", m.drag)
+	}
+	m, _ = update(m, click(x, r.y+3, tea.MouseButtonLeft, tea.MouseActionMotion))
+	if m.notice != "selecting lines 1–3" {
+		t.Errorf("notice %q", m.notice)
+	}
+	// Releasing on the line pressed copies nothing.
+	m, _ = update(m, click(x, r.y+1, tea.MouseButtonLeft, tea.MouseActionRelease))
+	if m.drag != nil || m.notice != "" {
+		t.Errorf("drag %+v, notice %q", m.drag, m.notice)
+	}
+}
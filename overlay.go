@@ -0,0 +1,167 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// An overlay takes over the screen and the keyboard until it is closed.
+type overlay interface {
+	// update handles a key press and reports whether the overlay is done.
+	update(m *model, msg tea.KeyMsg) (done bool, cmd tea.Cmd)
+	view(m *model) string
+}
+
+// A receiver is an overlay that is also handed the messages other than
+// keys.
+type receiver interface {
+	receive(msg tea.Msg) tea.Cmd
+}
+
+var (
+	overlayStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			Padding(0, 1)
+
+	overlayTitleStyle = lipgloss.NewStyle().Bold(true)
+)
+
+// prompt asks for a single line of text and hands it to submit.
+type prompt struct {
+	title  string
+	input  textinput.Model
+	submit func(m *model, value string) tea.Cmd
+}
+
+func newPrompt(title, value string, submit func(m *model, value string) tea.Cmd) *prompt {
+	t := textinput.New()
+	t.SetValue(value)
+	t.Focus()
+	return &prompt{title: title, input: t, submit: submit}
+}
+
+func (p *prompt) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		return true, nil
+	case tea.KeyEnter:
+		return true, p.submit(m, p.input.Value())
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return false, cmd
+}
+
+func (p *prompt) view(m *model) string {
+	p.input.Width = m.width - 8
+	return overlayStyle.Render(overlayTitleStyle.Render(p.title) + "\n" + p.input.View())
+}
+
+// confirm asks before doing something that is hard to take back, and
+// calls yes if it is confirmed.
+type confirm struct {
+	title, text string
+	yes         func(m *model) tea.Cmd
+}
+
+func (c *confirm) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		return true, c.yes(m)
+	case "n", "esc":
+		return true, nil
+	}
+	return false, nil
+}
+
+func (c *confirm) view(m *model) string {
+	return overlayStyle.Render(overlayTitleStyle.Render(c.title) + "\n\n" + c.text + "\n\ny/enter yes • n/esc no")
+}
+
+// menuItem is an entry of a menu.
+type menuItem struct {
+	title, desc string
+	// run is called with split set when the entry was chosen with the split
+	// modifier.
+	run func(m *model, split bool) tea.Cmd
+}
+
+func (i menuItem) Title() string       { return i.title }
+func (i menuItem) Description() string { return i.desc }
+func (i menuItem) FilterValue() string { return i.title + " " + i.desc }
+
+var (
+	menuChoose = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "apply"))
+	menuSplit  = key.NewBinding(key.WithKeys("alt+enter"), key.WithHelp("alt+enter", "to other pane"))
+)
+
+// menu is a filterable list of actions.
+type menu struct {
+	list list.Model
+	// typeToFilter makes typing filter the menu straight away and enter
+	// choose while filtering, as in a command palette.
+	typeToFilter bool
+}
+
+func newMenu(title string, items []menuItem) *menu {
+	listItems := make([]list.Item, len(items))
+	for i, it := range items {
+		listItems[i] = it
+	}
+	l := list.New(listItems, list.NewDefaultDelegate(), 0, 0)
+	l.Title = title
+	l.DisableQuitKeybindings()
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{menuChoose, menuSplit}
+	}
+	return &menu{list: l}
+}
+
+func (mn *menu) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	if mn.typeToFilter && mn.list.FilterState() == list.Filtering {
+		switch {
+		case msg.Type == tea.KeyEsc:
+			return true, nil
+		case key.Matches(msg, menuChoose), key.Matches(msg, menuSplit):
+			it, ok := mn.list.SelectedItem().(menuItem)
+			if !ok {
+				return false, nil
+			}
+			return true, it.run(m, key.Matches(msg, menuSplit))
+		}
+	}
+	if mn.list.FilterState() != list.Filtering {
+		switch {
+		case msg.Type == tea.KeyEsc && mn.list.FilterState() == list.Unfiltered:
+			return true, nil
+		case key.Matches(msg, menuChoose), key.Matches(msg, menuSplit):
+			it, ok := mn.list.SelectedItem().(menuItem)
+			if !ok {
+				return true, nil
+			}
+			return true, it.run(m, key.Matches(msg, menuSplit))
+		}
+	}
+	var cmd tea.Cmd
+	mn.list, cmd = mn.list.Update(msg)
+	return false, cmd
+}
+
+// receive passes the list the results of filtering it, which arrive as
+// messages.
+func (mn *menu) receive(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(list.FilterMatchesMsg); !ok {
+		return nil
+	}
+	var cmd tea.Cmd
+	mn.list, cmd = mn.list.Update(msg)
+	return cmd
+}
+
+func (mn *menu) view(m *model) string {
+	mn.list.SetSize(m.width-4, m.height-2)
+	return overlayStyle.Render(mn.list.View())
+}
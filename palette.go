@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/theme"
+)
+
+// keyTypes maps the names of keys, such as "ctrl+r" or "f2", to their
+// types.
+var keyTypes = func() map[string]tea.KeyType {
+	types := map[string]tea.KeyType{}
+	for t := tea.KeyType(-200); t < 200; t++ {
+		if name := t.String(); name != "" {
+			types[name] = t
+		}
+	}
+	return types
+}()
+
+// keyMsg returns the press of the key named s, as key bindings name it.
+func keyMsg(s string) (tea.KeyMsg, bool) {
+	alt := strings.HasPrefix(s, "alt+")
+	name := strings.TrimPrefix(s, "alt+")
+	if t, ok := keyTypes[name]; ok {
+		return tea.KeyMsg{Type: t, Alt: alt}, true
+	}
+	if utf8.RuneCountInString(name) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name), Alt: alt}, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// paletteMenu lists every action: those bound to keys, which it runs by
+// pressing their key, and the entries of the other menus.
+func paletteMenu(m *model) *menu {
+	var items []menuItem
+	for _, g := range helpGroups(&m.keymap) {
+		for _, b := range g.keys {
+			if !b.Enabled() || len(b.Keys()) == 0 || b.Keys()[0] == m.keymap.palette.Keys()[0] {
+				continue
+			}
+			msg, ok := keyMsg(b.Keys()[0])
+			if !ok {
+				continue
+			}
+			items = append(items, menuItem{
+				title: b.Help().Desc,
+				desc:  g.title + " · " + b.Help().Key,
+				run: func(m *model, _ bool) tea.Cmd {
+					m.overlay = nil
+					next, cmd := m.Update(msg)
+					*m = next.(model)
+					return cmd
+				},
+			})
+		}
+	}
+	for _, group := range []struct {
+		prefix string
+		items  []menuItem
+	}{
+		{"transform", transformItems()},
+		{"preset", presetItems()},
+		{"round trip", roundTripItems()},
+		{"theme", themeItems()},
+	} {
+		for _, it := range group.items {
+			it.title = group.prefix + ": " + it.title
+			items = append(items, it)
+		}
+	}
+	mn := newMenu("Commands", items)
+	mn.list.AdditionalShortHelpKeys = nil
+	mn.typeToFilter = true
+	mn.list, _ = mn.list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	return mn
+}
+
+// themeItems returns an entry switching to each theme.
+func themeItems() []menuItem {
+	var items []menuItem
+	for _, t := range theme.Themes {
+		t := t
+		items = append(items, menuItem{
+			title: t.Name,
+			desc:  "Color the panes and the diff with the " + t.Name + " theme",
+			run: func(m *model, _ bool) tea.Cmd {
+				m.setTheme(t)
+				m.notice = "theme: " + t.Name
+				return m.startCompare()
+			},
+		})
+	}
+	return items
+}
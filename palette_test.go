@@ -0,0 +1,102 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/theme"
+)
+
+func TestKeyMsg(t *testing.T) {
+	tests := []struct {
+		name string
+		want tea.KeyMsg
+		ok   bool
+	}{
+		{"ctrl+r", tea.KeyMsg{Type: tea.KeyCtrlR}, true},
+		{"f2", tea.KeyMsg{Type: tea.KeyF2}, true},
+		{"tab", tea.KeyMsg{Type: tea.KeyTab}, true},
+		{"alt+z", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}, Alt: true}, true},
+		{"alt+N", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}, Alt: true}, true},
+		{"alt+=", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'='}, Alt: true}, true},
+		{"ctrl+right", tea.KeyMsg{Type: tea.KeyCtrlRight}, true},
+		{"hyper+x", tea.KeyMsg{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := keyMsg(tt.name)
+		if ok != tt.ok || ok && got.String() != tt.want.String() {
+			t.Errorf("keyMsg(%q) = %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPaletteListsEveryKey(t *testing.T) {
+	m := newModel()
+	titles := map[string]bool{}
+	for _, it := range paletteMenu(&m).list.Items() {
+		titles[it.(menuItem).title] = true
+	}
+	for name, b := range keyBindings(&m.keymap) {
+		if !titles[b.Help().Desc] && name != "palette" {
+			t.Errorf("the palette does not list %s", name)
+		}
+	}
+	for _, title := range []string{"transform: upper", "preset: json", "theme: dracula"} {
+		if !titles[title] {
+			t.Errorf("the palette does not list %q", title)
+		}
+	}
+}
+
+func TestPalette(t *testing.T) {
+	t.Cleanup(func() { applyTheme(theme.Default) })
+	tests := []struct {
+		title string
+		check func(m model) bool
+	}{
+		{"zoom", func(m model) bool { return m.zoomed }},
+		{"ignore case", func(m model) bool { return m.options.IgnoreCase }},
+		{"new tab", func(m model) bool { return len(m.tabs) == 2 }},
+		{"transform: upper", func(m model) bool { return m.inputs[0].Value() == "HELLO" }},
+		{"theme: dracula", func(m model) bool { return currentTheme.Name == "dracula" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			m := newModel()
+			m, _ = update(m, tea.WindowSizeMsg{Width: 80, Height: 40})
+			m.setPane(0, "hello")
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+			mn, ok := m.overlay.(*menu)
+			if !ok {
+				t.Fatalf("overlay = %T, want the palette", m.overlay)
+			}
+			selectItem(t, mn, tt.title)
+			m = settle(m, tea.KeyMsg{Type: tea.KeyEnter})
+			if m.overlay != nil {
+				t.Error("the palette is still open")
+			}
+			if !tt.check(m) {
+				t.Error("the action did not run")
+			}
+		})
+	}
+}
+
+func TestPaletteFilter(t *testing.T) {
+	m := newModel()
+	m, _ = update(m, tea.WindowSizeMsg{Width: 80, Height: 40})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	for _, r := range "zoom" {
+		m = settle(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m = settle(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.overlay != nil || !m.zoomed {
+		t.Errorf("typing zoom and enter: overlay %T, zoomed %v", m.overlay, m.zoomed)
+	}
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.overlay != nil {
+		t.Error("esc does not close the palette")
+	}
+}
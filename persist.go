@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/theme"
+)
+
+// sessionFile keeps the panes of the last TUI session, so they are not lost
+// to an accidental quit.
+const sessionFile = "session.json"
+
+// savedSession is what sessionFile holds: the tabs and the layout and
+// theme they were shown with.
+type savedSession struct {
+	SavedAt        time.Time  `json:"saved_at"`
+	Tabs           []savedTab `json:"tabs"`
+	Tab            int        `json:"tab"`
+	Theme          string     `json:"theme"`
+	Vertical       bool       `json:"vertical"`
+	Split          int        `json:"split"`
+	ResultRows     int        `json:"result_rows"`
+	SideSplit      int        `json:"side_split"`
+	ShowWhitespace bool       `json:"show_whitespace"`
+}
+
+// savedTab is a tab of a savedSession, with the comparison options by the
+// names the flags use.
+type savedTab struct {
+	Panes             [2]savedPane `json:"panes"`
+	Focus             int          `json:"focus"`
+	Unit              string       `json:"unit"`
+	Preset            string       `json:"preset,omitempty"`
+	IgnoreCase        bool         `json:"ignore_case,omitempty"`
+	IgnoreLineEndings bool         `json:"ignore_line_endings,omitempty"`
+	Template          bool         `json:"template,omitempty"`
+	Overlap           bool         `json:"overlap,omitempty"`
+}
+
+// savedPane is the text of an input pane and where its cursor was.
+type savedPane struct {
+	Text   string `json:"text"`
+	Path   string `json:"path,omitempty"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// loadSession reads the saved session, or returns nil if there is none.
+func loadSession() (*savedSession, error) {
+	var s savedSession
+	if err := loadState(sessionFile, &s); err != nil {
+		return nil, err
+	}
+	if len(s.Tabs) == 0 {
+		return nil, nil
+	}
+	return &s, nil
+}
+
+// saveSession saves the tabs of m, or removes the saved session if all its
+// panes are empty.
+func saveSession(m *model) error {
+	s, empty := m.snapshot()
+	if empty {
+		return removeState(sessionFile)
+	}
+	return saveState(sessionFile, s)
+}
+
+// snapshot returns the tabs of m as a savedSession, and whether all their
+// panes are empty.
+func (m *model) snapshot() (savedSession, bool) {
+	m.tabs[m.tab] = m.session
+	s := savedSession{
+		SavedAt:        time.Now(),
+		Tab:            m.tab,
+		Theme:          currentTheme.Name,
+		Vertical:       m.vertical,
+		Split:          m.split,
+		ResultRows:     m.resultRows,
+		SideSplit:      m.sideSplit,
+		ShowWhitespace: m.showWhitespace,
+	}
+	empty := true
+	for i := range m.tabs {
+		t := &m.tabs[i]
+		st := savedTab{
+			Focus:             t.focus,
+			Unit:              t.options.Unit.String(),
+			Preset:            t.options.Preset.Name,
+			IgnoreCase:        t.options.IgnoreCase,
+			IgnoreLineEndings: t.options.IgnoreLineEndings,
+			Template:          t.options.Template,
+			Overlap:           t.options.Overlap,
+		}
+		for p := range st.Panes {
+			li := t.inputs[p].LineInfo()
+			st.Panes[p] = savedPane{
+				Text:   t.paneText(p),
+				Path:   t.paths[p],
+				Line:   t.inputs[p].Line(),
+				Column: li.StartColumn + li.ColumnOffset,
+			}
+			empty = empty && st.Panes[p].Text == ""
+		}
+		s.Tabs = append(s.Tabs, st)
+	}
+	return s, empty
+}
+
+// restoreSession replaces the tabs of m with those of s.
+func (m *model) restoreSession(s *savedSession) tea.Cmd {
+	if t, ok := theme.Lookup(s.Theme); ok {
+		m.setTheme(t)
+	}
+	m.vertical = s.Vertical
+	if s.Split > 0 {
+		m.split, m.resultRows, m.sideSplit = s.Split, s.ResultRows, s.SideSplit
+	}
+	m.showWhitespace = s.ShowWhitespace
+
+	defaults := m.options
+	m.tabs = make([]session, len(s.Tabs))
+	for n, t := range s.Tabs {
+		m.session = newSession()
+		of := optionFlags{unit: t.Unit, preset: t.Preset, ignoreCase: t.IgnoreCase, ignoreEOL: t.IgnoreLineEndings, template: t.Template, overlap: t.Overlap}
+		var err error
+		if m.options, err = of.options(); err != nil {
+			m.options = defaults
+		}
+		m.options.Timeout = defaults.Timeout
+		m.sizeInputs()
+		for i, p := range t.Panes {
+			m.setPane(i, p.Text)
+			m.paths[i] = p.Path
+			m.moveCursor(i, panePos{line: p.Line, col: p.Column})
+		}
+		if t.Focus > 0 && t.Focus < len(m.inputs) {
+			m.inputs[0].Blur()
+			m.focus = t.Focus
+			m.inputs[m.focus].Focus()
+		}
+		m.tabs[n] = m.session
+	}
+	m.notice = "restored the session of " + s.SavedAt.Format(time.DateTime)
+	return m.showTab(min(max(s.Tab, 0), len(m.tabs)-1))
+}
+
+// restorePrompt offers to restore the saved session when the TUI starts
+// with empty panes.
+type restorePrompt struct {
+	saved *savedSession
+}
+
+func (p restorePrompt) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		return true, m.restoreSession(p.saved)
+	case "n", "esc":
+		return true, nil
+	}
+	return false, nil
+}
+
+func (p restorePrompt) view(m *model) string {
+	tabs := "1 tab"
+	if n := len(p.saved.Tabs); n != 1 {
+		tabs = fmt.Sprintf("%d tabs", n)
+	}
+	return overlayStyle.Render(fmt.Sprintf("%s\n\nThe session of %s, with %s, was saved on quit.\n\ny/enter restore • n/esc start empty",
+		overlayTitleStyle.Render("Restore the last session?"), p.saved.SavedAt.Format(time.DateTime), tabs))
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/diff"
+)
+
+func TestSessionRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	m := newModel()
+	m, _ = update(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+	m.eols[0] = "CRLF"
+	m.setPane(0, "one\r\ntwo")
+	m.setPane(1, "three")
+	m.paths[1] = "three.txt"
+	m.moveCursor(0, panePos{line: 1, col: 2})
+	m.options.Unit = diff.Byte
+	m.vertical = true
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlT})
+	m.setPane(0, "second tab")
+	m.options.IgnoreCase = true
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlLeft})
+	if err := saveSession(&m); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := loadSession()
+	if err != nil || s == nil {
+		t.Fatalf("loading the session: %v, %v", s, err)
+	}
+	r := newModel()
+	r, _ = update(r, tea.WindowSizeMsg{Width: 100, Height: 30})
+	r.restoreSession(s)
+	if len(r.tabs) != 2 || r.tab != 0 || !r.vertical {
+		t.Fatalf("%d tabs at %d, vertical %v", len(r.tabs), r.tab, r.vertical)
+	}
+	if r.paneText(0) != "one\r\ntwo" || r.paneText(1) != "three" || r.paths[1] != "three.txt" {
+		t.Errorf("panes %q and %q from %q", r.paneText(0), r.paneText(1), r.paths[1])
+	}
+	if li := r.inputs[0].LineInfo(); r.inputs[0].Line() != 1 || li.ColumnOffset != 2 {
+		t.Errorf("cursor at %d:%d", r.inputs[0].Line(), li.ColumnOffset)
+	}
+	if r.options.Unit != diff.Byte || r.options.IgnoreCase {
+		t.Errorf("options of the first tab %+v", r.options)
+	}
+	r, _ = update(r, tea.KeyMsg{Type: tea.KeyCtrlRight})
+	if r.paneText(0) != "second tab" || !r.options.IgnoreCase {
+		t.Errorf("second tab %q with options %+v", r.paneText(0), r.options)
+	}
+}
+
+func TestSaveEmptySession(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	path := filepath.Join(dir, "strcli", sessionFile)
+
+	m := newModel()
+	m.setPane(0, "text")
+	if err := saveSession(&m); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Fatalf("saved session: %v, %v", fi, err)
+	}
+	m.setPane(0, "")
+	if err := saveSession(&m); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("saving empty panes keeps the session file: %v", err)
+	}
+	if s, err := loadSession(); s != nil || err != nil {
+		t.Errorf("without a session file: %v, %v", s, err)
+	}
+}
+
+func TestRestorePrompt(t *testing.T) {
+	saved := &savedSession{Tabs: []savedTab{{Panes: [2]savedPane{{Text: "a"}, {Text: "b"}}, Unit: "rune"}}}
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"y", "a"},
+		{"enter", "a"},
+		{"n", ""},
+		{"esc", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			m := newModel()
+			m.overlay = restorePrompt{saved: saved}
+			k := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)}
+			switch tt.key {
+			case "enter":
+				k = tea.KeyMsg{Type: tea.KeyEnter}
+			case "esc":
+				k = tea.KeyMsg{Type: tea.KeyEsc}
+			}
+			m, _ = update(m, k)
+			if m.overlay != nil {
+				t.Fatal("the prompt is still open")
+			}
+			if got := m.inputs[0].Value(); got != tt.want {
+				t.Errorf("pane A holds %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSavesSession(t *testing.T) {
+	no := false
+	if !(config{}).savesSession() || (config{SaveSession: &no}).savesSession() {
+		t.Error("sessions are saved unless save_session is false")
+	}
+}
@@ -0,0 +1,84 @@
+// Package compare is the entry point for embedding strcli's comparisons in
+// other programs: it prepares two inputs as selected by Options and computes
+// their diff.
+//
+//	res := compare.Compare(a, b, compare.Options{})
+//	if !res.Equal() {
+//		fmt.Print(render.Plain(res.Diff))
+//	}
+package compare
+
+import (
+	"time"
+
+	"strcli/pkg/diff"
+	"strcli/pkg/pattern"
+	"strcli/pkg/preset"
+	"strcli/pkg/transform"
+)
+
+// Options select how inputs are compared. The zero value compares them as
+// they are.
+type Options struct {
+	// Preset, if set, prepares both inputs before they are compared.
+	Preset preset.Preset
+	// IgnoreCase case folds both inputs, so differences in case only are
+	// not reported.
+	IgnoreCase bool
+	// Template treats placeholders such as {{number}} in the first input
+	// as matching whatever the second input has in their place. See
+	// pattern.Placeholders.
+	Template bool
+	// IgnoreLineEndings converts CRLF and CR line endings to LF, so
+	// differences in line endings only are not reported.
+	IgnoreLineEndings bool
+	// Overlap compares only the region both inputs cover, cutting the
+	// longer one to the length of the shorter.
+	Overlap bool
+	// Unit is the smallest piece of text the diff inserts or deletes.
+	Unit diff.Unit
+	// Timeout is how long to look for the smallest diff before settling
+	// for a larger one: diff.DefaultTimeout if zero, and as long as it
+	// takes if negative.
+	Timeout time.Duration
+}
+
+// Result is the outcome of a comparison.
+type Result struct {
+	diff.Diff
+	// A and B are the inputs as they were compared, after any preparation.
+	A, B string
+	// Report is the preset's summary of the inputs, if it has one.
+	Report string
+}
+
+// Compare compares a with b.
+func Compare(a, b string, opts Options) Result {
+	var report string
+	if opts.Preset.Report != nil {
+		report = opts.Preset.Report(a, b)
+	}
+	if opts.IgnoreLineEndings {
+		a, b = transform.ToLF(a), transform.ToLF(b)
+	}
+	if opts.Preset.Normalize != nil {
+		a, b = opts.Preset.Normalize(a), opts.Preset.Normalize(b)
+	}
+	if opts.IgnoreCase {
+		a, b = transform.Fold(a), transform.Fold(b)
+	}
+	if opts.Overlap {
+		a, b = diff.Overlap(a, b)
+	}
+	if opts.Template {
+		b = pattern.MaskTemplate(a, b)
+	}
+	timeout := opts.Timeout
+	switch {
+	case timeout == 0:
+		timeout = diff.DefaultTimeout
+	case timeout < 0:
+		timeout = 0
+	}
+	return Result{Diff: diff.ComputeWithin(a, b, opts.Unit, timeout), A: a, B: b, Report: report}
+}
@@ -0,0 +1,55 @@
+package compare
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"strcli/pkg/preset"
+)
+
+func TestCompare(t *testing.T) {
+	upper := preset.Preset{Name: "upper", Normalize: strings.ToUpper}
+	tests := []struct {
+		name      string
+		a, b      string
+		opts      Options
+		wantA     string
+		wantB     string
+		wantEqual bool
+	}{
+		{"as they are", "abc", "ABC", Options{}, "abc", "ABC", false},
+		{"prepared", "abc", "ABC", Options{Preset: upper}, "ABC", "ABC", true},
+		{"identical", "x\n", "x\n", Options{}, "x\n", "x\n", true},
+		{"ignoring case", "Straße", "STRASSE", Options{IgnoreCase: true}, "strasse", "strasse", true},
+		{"template", "took {{number}} ms", "took 12 ms", Options{Template: true}, "took {{number}} ms", "took {{number}} ms", true},
+		{"template off", "took {{number}} ms", "took 12 ms", Options{}, "took {{number}} ms", "took 12 ms", false},
+		{"ignoring line endings", "a\r\nb\r\n", "a\nb\r", Options{IgnoreLineEndings: true}, "a\nb\n", "a\nb\n", true},
+		{"line endings", "a\r\nb\r\n", "a\nb\n", Options{}, "a\r\nb\r\n", "a\nb\n", false},
+		{"overlap", "hello world", "hello", Options{Overlap: true}, "hello", "hello", true},
+		{"ignoring case after preset", "ab", "AB", Options{Preset: upper, IgnoreCase: true}, "ab", "ab", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Compare(tt.a, tt.b, tt.opts)
+			if res.A != tt.wantA || res.B != tt.wantB {
+				t.Errorf("compared %q and %q, want %q and %q", res.A, res.B, tt.wantA, tt.wantB)
+			}
+			if res.Equal() != tt.wantEqual {
+				t.Errorf("Equal() = %v, want %v", res.Equal(), tt.wantEqual)
+			}
+		})
+	}
+}
+
+func TestCompareReport(t *testing.T) {
+	p := preset.Preset{Name: "lengths", Report: func(a, b string) string {
+		return fmt.Sprintf("%d -> %d\n", len(a), len(b))
+	}}
+	if got := Compare("ab", "abc", Options{Preset: p}).Report; got != "2 -> 3\n" {
+		t.Errorf("Report = %q, want %q", got, "2 -> 3\n")
+	}
+	if got := Compare("ab", "abc", Options{}).Report; got != "" {
+		t.Errorf("Report without a preset = %q, want none", got)
+	}
+}
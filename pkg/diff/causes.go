@@ -0,0 +1,173 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// cause is a heuristic that recognizes a common reason for two inputs to
+// differ. It returns a description of the cause, or "" if it does not apply.
+type cause func(a, b string) string
+
+// causes are tried in order, most specific first; only the first that
+// applies is reported.
+var causes = []cause{
+	onlyBOM,
+	onlyLineEndings,
+	onlyTrailingWhitespace,
+	onlyWhitespace,
+	onlyCase,
+	onlyJSONLayout,
+	onlyLineOrder,
+}
+
+// causeNotes labels the difference between a and b with its probable cause.
+func causeNotes(a, b string) []Note {
+	if a == b {
+		return nil
+	}
+	if text := truncated(a, b); text != "" {
+		return []Note{{Kind: Truncated, Text: text}}
+	}
+	for _, c := range causes {
+		if text := c(a, b); text != "" {
+			return []Note{{Kind: ProbableCause, Text: text}}
+		}
+	}
+	return nil
+}
+
+const bom = "\uFEFF"
+
+func onlyBOM(a, b string) string {
+	if strings.TrimPrefix(a, bom) != strings.TrimPrefix(b, bom) {
+		return ""
+	}
+	if strings.HasPrefix(a, bom) {
+		return "only a byte order mark at the start of A differs"
+	}
+	return "only a byte order mark at the start of B differs"
+}
+
+func onlyLineEndings(a, b string) string {
+	if strings.ReplaceAll(a, "\r\n", "\n") != strings.ReplaceAll(b, "\r\n", "\n") {
+		return ""
+	}
+	return fmt.Sprintf("only line endings differ (%s in A, %s in B)", LineEndings(a), LineEndings(b))
+}
+
+// LineEndings names the line endings used in s: LF, CRLF or mixed.
+func LineEndings(s string) string {
+	crlf := strings.Count(s, "\r\n")
+	switch lf := strings.Count(s, "\n") - crlf; {
+	case crlf > 0 && lf > 0:
+		return "mixed"
+	case crlf > 0:
+		return "CRLF"
+	default:
+		return "LF"
+	}
+}
+
+func onlyTrailingWhitespace(a, b string) string {
+	trim := func(s string) string {
+		lines := strings.Split(s, "\n")
+		for i, l := range lines {
+			lines[i] = strings.TrimRight(l, " \t\r")
+		}
+		return strings.Join(lines, "\n")
+	}
+	if trim(a) != trim(b) {
+		return ""
+	}
+	return "only trailing whitespace differs"
+}
+
+func onlyWhitespace(a, b string) string {
+	if strings.Join(strings.Fields(a), " ") != strings.Join(strings.Fields(b), " ") {
+		return ""
+	}
+	return "only whitespace differs"
+}
+
+func onlyCase(a, b string) string {
+	if !strings.EqualFold(a, b) {
+		return ""
+	}
+	return "only letter case differs"
+}
+
+func onlyJSONLayout(a, b string) string {
+	var va, vb any
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return ""
+	}
+	if !reflect.DeepEqual(va, vb) {
+		return ""
+	}
+	// Equal values that compact differently must have their keys in a
+	// different order.
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, []byte(a)) == nil && json.Compact(&cb, []byte(b)) == nil && ca.String() == cb.String() {
+		return "only JSON formatting differs"
+	}
+	return "only the order of JSON object keys differs"
+}
+
+func onlyLineOrder(a, b string) string {
+	la, lb := strings.Split(a, "\n"), strings.Split(b, "\n")
+	if len(la) != len(lb) {
+		return ""
+	}
+	sort.Strings(la)
+	sort.Strings(lb)
+	if !reflect.DeepEqual(la, lb) {
+		return ""
+	}
+	return "the same lines in a different order"
+}
+
+// truncated reports whether one input appears to be cut off: apart from
+// trailing whitespace it is the start of the other.
+func truncated(a, b string) string {
+	describe := func(side, short, long string) string {
+		short = strings.TrimRight(short, " \t\r\n")
+		if short == "" || !strings.HasPrefix(long, short) || len(short) == len(strings.TrimRight(long, " \t\r\n")) {
+			return ""
+		}
+		return fmt.Sprintf("%s is truncated after line %d, byte %d (%d more bytes in the other)",
+			side, lineCount(short), len(short), len(long)-len(short))
+	}
+	if len(b) <= len(a) {
+		return describe("B", b, a)
+	}
+	return describe("A", a, b)
+}
+
+// Overlap cuts the longer of a and b to the length of the shorter, so only
+// the region both cover is compared.
+func Overlap(a, b string) (string, string) {
+	n := min(len(a), len(b))
+	cut := func(s string) string {
+		for n > 0 && n < len(s) && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		return s[:n]
+	}
+	return cut(a), cut(b)
+}
+
+// lineCount counts the lines of s, including a last line without a line
+// break.
+func lineCount(s string) int {
+	n := strings.Count(s, "\n")
+	if !strings.HasSuffix(s, "\n") {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,55 @@
+package diff
+
+import "testing"
+
+func TestCauseNotes(t *testing.T) {
+	tests := []struct {
+		name, a, b string
+		want       string
+	}{
+		{"equal", "a\n", "a\n", ""},
+		{"byte order mark", "\uFEFFa\n", "a\n", "probable-cause: only a byte order mark at the start of A differs"},
+		{"line endings", "a\r\nb\r\n", "a\nb\n", "probable-cause: only line endings differ (CRLF in A, LF in B)"},
+		{"mixed line endings", "a\nb\n", "a\r\nb\n", "probable-cause: only line endings differ (LF in A, mixed in B)"},
+		{"trailing whitespace", "a \nb\n", "a\nb\t\n", "probable-cause: only trailing whitespace differs"},
+		{"whitespace", "a  b\n", "a b\n", "probable-cause: only whitespace differs"},
+		{"case", "Hello\n", "hello\n", "probable-cause: only letter case differs"},
+		{"JSON formatting", `{"a": 1}`, "{\n  \"a\": 1\n}", "probable-cause: only JSON formatting differs"},
+		{"JSON key order", `{"a":1,"b":2}`, `{"b":2,"a":1}`, "probable-cause: only the order of JSON object keys differs"},
+		{"line order", "a\nb\nc", "c\na\nb", "probable-cause: the same lines in a different order"},
+		{"B truncated", "a\nb\nc\n", "a\nb", "truncated: B is truncated after line 2, byte 3 (3 more bytes in the other)"},
+		{"A truncated", "a\n", "a\nb\n", "truncated: A is truncated after line 1, byte 1 (3 more bytes in the other)"},
+		{"only trailing whitespace missing", "a\n\n", "a", "probable-cause: only whitespace differs"},
+		{"unrelated", "apple\n", "pear\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			for _, n := range causeNotes(tt.a, tt.b) {
+				got = n.Kind + ": " + n.Text
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverlap(t *testing.T) {
+	tests := []struct {
+		a, b         string
+		wantA, wantB string
+	}{
+		{"hello world", "hello", "hello", "hello"},
+		{"ab", "abcd", "ab", "ab"},
+		{"same", "same", "same", "same"},
+		{"xé", "xy", "x", "x"},
+		{"", "abc", "", ""},
+	}
+	for _, tt := range tests {
+		a, b := Overlap(tt.a, tt.b)
+		if a != tt.wantA || b != tt.wantB {
+			t.Errorf("Overlap(%q, %q) = %q, %q, want %q, %q", tt.a, tt.b, a, b, tt.wantA, tt.wantB)
+		}
+	}
+}
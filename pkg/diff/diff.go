@@ -0,0 +1,466 @@
+// Package diff compares two strings and exposes the result as a typed model of
+// changes grouped into hunks, so renderers and exporters share one structure.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// Op says how a Change relates the two inputs.
+type Op int
+
+const (
+	// Equal text is present in both inputs.
+	Equal Op = iota
+	// Insert text is only present in the second input.
+	Insert
+	// Delete text is only present in the first input.
+	Delete
+)
+
+// MarshalText encodes o as its name.
+func (o Op) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+func (o Op) String() string {
+	switch o {
+	case Insert:
+		return "insert"
+	case Delete:
+		return "delete"
+	default:
+		return "equal"
+	}
+}
+
+// Hint is a set of rendering hints attached to a Change.
+type Hint uint8
+
+const (
+	// Whitespace marks a change made up only of whitespace, which renderers
+	// should make visible.
+	Whitespace Hint = 1 << iota
+	// LineBreak marks a change that contains a line break.
+	LineBreak
+)
+
+// MarshalJSON encodes h as a list of hint names.
+func (h Hint) MarshalJSON() ([]byte, error) {
+	names := []string{}
+	if h.Has(Whitespace) {
+		names = append(names, "whitespace")
+	}
+	if h.Has(LineBreak) {
+		names = append(names, "line-break")
+	}
+	return json.Marshal(names)
+}
+
+// Has reports whether all hints in h2 are set in h.
+func (h Hint) Has(h2 Hint) bool {
+	return h&h2 == h2
+}
+
+// Change is a run of text that is equal in both inputs, or inserted or
+// deleted when going from the first input to the second.
+type Change struct {
+	Op   Op     `json:"op"`
+	Text string `json:"text"`
+	Hint Hint   `json:"hint,omitempty"`
+	// ALine and BLine are the 1-based lines of each input the change starts on.
+	ALine int `json:"a_line"`
+	BLine int `json:"b_line"`
+	// AOffset and BOffset are the 0-based byte offsets of each input the
+	// change starts at.
+	AOffset int `json:"a_offset"`
+	BOffset int `json:"b_offset"`
+}
+
+// Kind classifies a Hunk.
+type Kind int
+
+const (
+	// Added hunks only insert text.
+	Added Kind = iota
+	// Removed hunks only delete text.
+	Removed
+	// Modified hunks both insert and delete text.
+	Modified
+)
+
+// MarshalText encodes k as its name.
+func (k Kind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	default:
+		return "modified"
+	}
+}
+
+// Range is a half-open, 1-based range of lines: from Start up to but not
+// including End. An empty range marks where the other input has lines that
+// this one lacks: before line Start.
+type Range struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Len returns the number of lines in r.
+func (r Range) Len() int {
+	return r.End - r.Start
+}
+
+// Hunk groups the changes that touch the same lines. Changes starts and ends
+// with a non-equal change and keeps any equal text between them.
+type Hunk struct {
+	Kind    Kind     `json:"kind"`
+	A       Range    `json:"a"`
+	B       Range    `json:"b"`
+	Changes []Change `json:"changes"`
+}
+
+// Note is a difference between the inputs as a whole that is easy to miss
+// in the changes themselves, such as a missing final newline.
+type Note struct {
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+}
+
+// Kinds of Note.
+const (
+	// FinalNewline notes that only one input ends with a line break.
+	FinalNewline = "final-newline"
+	// TrailingBlankLines notes that the inputs end with different numbers
+	// of blank lines.
+	TrailingBlankLines = "trailing-blank-lines"
+	// ProbableCause labels the difference with a likely reason for it,
+	// such as only the line endings differing.
+	ProbableCause = "probable-cause"
+	// Truncated notes that one input looks like the start of the other.
+	Truncated = "truncated"
+	// TimedOut notes that the search for the smallest diff was cut short,
+	// so the changes are correct but may be more than needed.
+	TimedOut = "timed-out"
+)
+
+// Diff is the result of comparing two inputs.
+type Diff struct {
+	Unit    Unit     `json:"unit"`
+	Changes []Change `json:"changes"`
+	Hunks   []Hunk   `json:"hunks"`
+	Notes   []Note   `json:"notes,omitempty"`
+}
+
+// Equal reports whether the inputs were identical.
+func (d Diff) Equal() bool {
+	return len(d.Hunks) == 0 && len(d.Notes) == 0
+}
+
+// Compute compares a with b one grapheme cluster at a time.
+func Compute(a, b string) Diff {
+	return ComputeUnit(a, b, Grapheme)
+}
+
+// DefaultTimeout is how long ComputeUnit looks for the smallest diff before
+// settling for a larger one.
+const DefaultTimeout = time.Second
+
+// ComputeUnit compares a with b one u at a time.
+func ComputeUnit(a, b string, u Unit) Diff {
+	return ComputeWithin(a, b, u, DefaultTimeout)
+}
+
+// ComputeWithin is like ComputeUnit, but looks for the smallest diff for
+// only as long as timeout, or for as long as it takes if timeout is 0.
+// A diff settled for when the time ran out is noted as TimedOut.
+func ComputeWithin(a, b string, u Unit, timeout time.Duration) Diff {
+	df := &differ{dmp: diffmatchpatch.New()}
+	df.dmp.DiffTimeout = timeout
+	var diffs []diffmatchpatch.Diff
+	switch u {
+	case Rune:
+		diffs = df.main(a, b)
+	case Byte:
+		diffs = diffBytes(df, a, b)
+	default:
+		diffs = diffClusters(df, a, b)
+	}
+	d := build(diffs)
+	d.Unit = u
+	d.Notes = append(causeNotes(a, b), eofNotes(a, b)...)
+	if df.timedOut {
+		d.Notes = append(d.Notes, timedOutNote(timeout))
+	}
+	return d
+}
+
+// timedOut reports whether d has a TimedOut note.
+func (d Diff) timedOut() bool {
+	for _, n := range d.Notes {
+		if n.Kind == TimedOut {
+			return true
+		}
+	}
+	return false
+}
+
+// timedOutNote is the note of a diff that timeout cut short.
+func timedOutNote(timeout time.Duration) Note {
+	return Note{
+		Kind: TimedOut,
+		Text: fmt.Sprintf("Gave up looking for the smallest diff after %s; this one may show more changes than needed", timeout),
+	}
+}
+
+// differ runs diffmatchpatch and notes whether its timeout cut a diff
+// short. Only the diffing itself is timed, as diffmatchpatch times only
+// that against its timeout.
+type differ struct {
+	dmp      *diffmatchpatch.DiffMatchPatch
+	timedOut bool
+}
+
+func (df *differ) main(a, b string) []diffmatchpatch.Diff {
+	start := time.Now()
+	defer df.check(start)
+	return df.dmp.DiffMain(a, b, false)
+}
+
+func (df *differ) mainRunes(a, b []rune) []diffmatchpatch.Diff {
+	start := time.Now()
+	defer df.check(start)
+	return df.dmp.DiffMainRunes(a, b, false)
+}
+
+// check notes a timeout if the diffing that started at start took as long
+// as the timeout.
+func (df *differ) check(start time.Time) {
+	if t := df.dmp.DiffTimeout; t > 0 && time.Since(start) >= t {
+		df.timedOut = true
+	}
+}
+
+// eofNotes describes differences in how a and b end.
+func eofNotes(a, b string) []Note {
+	var notes []Note
+	aNL, bNL := strings.HasSuffix(a, "\n"), strings.HasSuffix(b, "\n")
+	switch {
+	case a == "" || b == "":
+	case aNL && !bNL:
+		notes = append(notes, Note{Kind: FinalNewline, Text: "No newline at end of B"})
+	case !aNL && bNL:
+		notes = append(notes, Note{Kind: FinalNewline, Text: "No newline at end of A"})
+	}
+	if aBlank, bBlank := trailingBlankLines(a), trailingBlankLines(b); aBlank != bBlank {
+		notes = append(notes, Note{
+			Kind: TrailingBlankLines,
+			Text: fmt.Sprintf("A ends with %s, B with %s", plural(aBlank, "blank line"), plural(bBlank, "blank line")),
+		})
+	}
+	return notes
+}
+
+// trailingBlankLines counts the empty or whitespace-only lines at the end of
+// s, not counting the final line break.
+func trailingBlankLines(s string) int {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	n := 0
+	for i := len(lines) - 1; i > 0 && strings.TrimSpace(lines[i]) == ""; i-- {
+		n++
+	}
+	return n
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+func build(diffs []diffmatchpatch.Diff) Diff {
+	var d Diff
+	aLine, bLine := 1, 1
+	aOff, bOff := 0, 0
+	for _, df := range diffs {
+		c := Change{Text: df.Text, ALine: aLine, BLine: bLine, AOffset: aOff, BOffset: bOff}
+		n := strings.Count(df.Text, "\n")
+		switch df.Type {
+		case diffmatchpatch.DiffInsert:
+			c.Op = Insert
+			bLine += n
+			bOff += len(df.Text)
+		case diffmatchpatch.DiffDelete:
+			c.Op = Delete
+			aLine += n
+			aOff += len(df.Text)
+		default:
+			c.Op = Equal
+			aLine += n
+			bLine += n
+			aOff += len(df.Text)
+			bOff += len(df.Text)
+		}
+		c.Hint = hints(df.Text)
+		d.Changes = append(d.Changes, c)
+	}
+	d.Hunks = group(d.Changes)
+	return d
+}
+
+// hints returns the rendering hints of a change with text.
+func hints(text string) Hint {
+	var h Hint
+	if strings.Contains(text, "\n") {
+		h |= LineBreak
+	}
+	if strings.TrimSpace(text) == "" {
+		h |= Whitespace
+	}
+	return h
+}
+
+// group splits changes into hunks. Two non-equal changes share a hunk unless
+// the equal text between them crosses a line break.
+func group(changes []Change) []Hunk {
+	at := positions(changes)
+	var hunks []Hunk
+	start := -1
+	last := -1
+	flush := func() {
+		if start >= 0 {
+			hunks = append(hunks, newHunk(changes[start:last+1], at[start:last+2]))
+		}
+		start, last = -1, -1
+	}
+	for i, c := range changes {
+		if c.Op == Equal {
+			if c.Hint.Has(LineBreak) {
+				flush()
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		last = i
+	}
+	flush()
+	return hunks
+}
+
+// position tells, for each input, whether a change starts at the start of a
+// line and whether any text of the input follows where it starts.
+type position struct {
+	lineStart, more [2]bool
+}
+
+// positions returns the position of each change, and one for the end.
+func positions(changes []Change) []position {
+	at := make([]position, len(changes)+1)
+	at[0].lineStart = [2]bool{true, true}
+	for i, c := range changes {
+		at[i+1].lineStart = at[i].lineStart
+		for side := range at[i].lineStart {
+			if c.in(side) {
+				at[i+1].lineStart[side] = strings.HasSuffix(c.Text, "\n")
+			}
+		}
+	}
+	for i := len(changes) - 1; i >= 0; i-- {
+		for side := range at[i].more {
+			at[i].more[side] = at[i+1].more[side] || changes[i].in(side)
+		}
+	}
+	return at
+}
+
+// in reports whether c has text in input side, 0 for the first and 1 for
+// the second.
+func (c Change) in(side int) bool {
+	return c.Text != "" && c.Op != [2]Op{Insert, Delete}[side]
+}
+
+// newHunk returns the hunk of changes, whose positions are at followed by
+// the position after them. Its ranges hold the lines that the changes
+// alter in each input.
+func newHunk(changes []Change, at []position) Hunk {
+	h := Hunk{Changes: changes}
+	var lines [2]Range
+	touch := func(side, from, to int) {
+		if lines[side].Len() == 0 {
+			lines[side] = Range{from, to}
+			return
+		}
+		lines[side] = Range{min(lines[side].Start, from), max(lines[side].End, to)}
+	}
+	var inserts, deletes bool
+	for i, c := range changes {
+		own, line, otherLine := 0, c.ALine, c.BLine
+		switch c.Op {
+		case Equal:
+			continue
+		case Insert:
+			inserts = true
+			own, line, otherLine = 1, c.BLine, c.ALine
+		case Delete:
+			deletes = true
+		}
+		other := 1 - own
+		// The text alters the lines it spans, and the line after a line
+		// break that runs on into the same line of the other input.
+		end := line + strings.Count(strings.TrimSuffix(c.Text, "\n"), "\n") + 1
+		if strings.HasSuffix(c.Text, "\n") && runsOn(changes, at, i, own) {
+			end++
+		}
+		touch(own, line, end)
+		// In the other input it alters the line it falls within, unless
+		// it is whole lines between two lines there.
+		if !at[i].lineStart[other] || !strings.HasSuffix(c.Text, "\n") && at[i].more[other] {
+			touch(other, otherLine, otherLine+1)
+		}
+	}
+	first := changes[0]
+	h.A, h.B = lines[0], lines[1]
+	if h.A.Len() == 0 {
+		h.A = Range{first.ALine, first.ALine}
+	}
+	if h.B.Len() == 0 {
+		h.B = Range{first.BLine, first.BLine}
+	}
+	switch {
+	case inserts && deletes:
+		h.Kind = Modified
+	case inserts:
+		h.Kind = Added
+	default:
+		h.Kind = Removed
+	}
+	return h
+}
+
+// runsOn reports whether the text of input side that follows change i,
+// which ends with a line break, lies within a line of the other input, so
+// that the line it starts in side runs on from the change.
+func runsOn(changes []Change, at []position, i, side int) bool {
+	for j := i + 1; j < len(changes); j++ {
+		if changes[j].in(side) {
+			return !at[j].lineStart[1-side]
+		}
+	}
+	n := len(changes)
+	return at[n].more[side] && !at[n].lineStart[1-side]
+}
@@ -0,0 +1,238 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// notEqual returns the changes of d that insert or delete text.
+func notEqual(d Diff) []Change {
+	var changes []Change
+	for _, c := range d.Changes {
+		if c.Op != Equal {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// summary lists the hunks, the changes that insert or delete text and the
+// notes of d, one per line, for comparing with what a test wants.
+func summary(d Diff) []string {
+	var lines []string
+	for _, h := range d.Hunks {
+		lines = append(lines, fmt.Sprintf("%s A%d-%d B%d-%d", h.Kind, h.A.Start, h.A.End, h.B.Start, h.B.End))
+	}
+	for _, c := range notEqual(d) {
+		lines = append(lines, fmt.Sprintf("%s %q A%d@%d B%d@%d", c.Op, c.Text, c.ALine, c.AOffset, c.BLine, c.BOffset))
+	}
+	for _, n := range d.Notes {
+		lines = append(lines, n.Kind+": "+n.Text)
+	}
+	return lines
+}
+
+func TestComputeUnit(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		unit Unit
+		want []string
+	}{
+		{"identical", "one\ntwo\n", "one\ntwo\n", Grapheme, nil},
+		{"within a line", "abc", "abd", Grapheme, []string{
+			`modified A1-2 B1-2`,
+			`delete "c" A1@2 B1@2`,
+			`insert "d" A1@3 B1@2`,
+		}},
+		{"line changed", "one\ntwo\nthree\n", "one\n2\nthree\n", Grapheme, []string{
+			`modified A2-3 B2-3`,
+			`delete "two" A2@4 B2@4`,
+			`insert "2" A2@7 B2@4`,
+		}},
+		{"two hunks", "x\ny\n", "X\ny\nz\n", Grapheme, []string{
+			`modified A1-2 B1-2`,
+			`added A3-3 B3-4`,
+			`delete "x" A1@0 B1@0`,
+			`insert "X" A1@1 B1@0`,
+			`insert "z\n" A3@4 B3@4`,
+		}},
+		{"lines inserted", "a\nc\n", "a\nb\nc\n", Grapheme, []string{
+			`added A2-2 B2-3`,
+			`insert "b\n" A2@2 B2@2`,
+		}},
+		{"line endings", "a\r\nb\r\n", "a\nb\n", Grapheme, []string{
+			`modified A1-3 B1-3`,
+			`delete "\r\n" A1@1 B1@1`,
+			`insert "\n" A2@3 B1@1`,
+			`delete "\r\n" A2@4 B2@3`,
+			`insert "\n" A3@6 B2@3`,
+			`probable-cause: only line endings differ (CRLF in A, LF in B)`,
+		}},
+		{"lines removed", "a\nb\nc\nd\n", "a\nd\n", Grapheme, []string{
+			`removed A2-4 B2-2`,
+			`delete "b\nc\n" A2@2 B2@2`,
+		}},
+		{"no newline at end", "a\n", "a", Grapheme, []string{
+			`removed A1-2 B1-2`,
+			`delete "\n" A1@1 B1@1`,
+			`probable-cause: only whitespace differs`,
+			`final-newline: No newline at end of B`,
+		}},
+		{"trailing blank lines", "a\n", "a\n\n\n", Grapheme, []string{
+			`added A2-2 B2-4`,
+			`insert "\n\n" A2@2 B2@2`,
+			`probable-cause: only whitespace differs`,
+			`trailing-blank-lines: A ends with 0 blank lines, B with 2 blank lines`,
+		}},
+		{"truncated", "hello world", "hello", Grapheme, []string{
+			`removed A1-2 B1-2`,
+			`delete " world" A1@5 B1@5`,
+			`truncated: B is truncated after line 1, byte 5 (6 more bytes in the other)`,
+		}},
+		{"bytes", "\u00e9", "\u00e8", Byte, []string{
+			`modified A1-2 B1-2`,
+			`delete "\xa9" A1@1 B1@1`,
+			`insert "\xa8" A1@2 B1@1`,
+		}},
+		{"runes split a combining mark from its letter", "e\u0301", "e\u0300", Rune, []string{
+			"modified A1-2 B1-2",
+			"delete \"\u0301\" A1@1 B1@1",
+			"insert \"\u0300\" A1@3 B1@1",
+		}},
+		{"graphemes keep a combining mark with its letter", "e\u0301", "e\u0300", Grapheme, []string{
+			"modified A1-2 B1-2",
+			"delete \"e\u0301\" A1@0 B1@0",
+			"insert \"e\u0300\" A1@3 B1@0",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := ComputeUnit(tt.a, tt.b, tt.unit)
+			if got := summary(d); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got\n%#v\nwant\n%#v", got, tt.want)
+			}
+			if d.Unit != tt.unit {
+				t.Errorf("Unit = %v, want %v", d.Unit, tt.unit)
+			}
+			if d.Equal() != (tt.want == nil) {
+				t.Errorf("Equal() = %v", d.Equal())
+			}
+		})
+	}
+}
+
+func TestParseUnit(t *testing.T) {
+	for _, u := range Units {
+		got, err := ParseUnit(u.String())
+		if err != nil || got != u {
+			t.Errorf("ParseUnit(%q) = %v, %v", u, got, err)
+		}
+	}
+	if _, err := ParseUnit("word"); err == nil {
+		t.Error("ParseUnit accepted an unknown unit")
+	}
+}
+
+func TestHintsAndKinds(t *testing.T) {
+	d := Compute("a b\n", "a  b\n")
+	changes := notEqual(d)
+	if len(changes) != 1 || !changes[0].Hint.Has(Whitespace) || changes[0].Hint.Has(LineBreak) {
+		t.Errorf("changes = %+v, want one whitespace-only insert", changes)
+	}
+	d = Compute("a\n", "a\nb\n")
+	changes = notEqual(d)
+	if len(changes) != 1 || !changes[0].Hint.Has(LineBreak) {
+		t.Errorf("changes = %+v, want one insert with a line break", changes)
+	}
+	if got := (Range{Start: 2, End: 4}).Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+	for k, want := range map[Kind]string{Added: "added", Removed: "removed", Modified: "modified"} {
+		if k.String() != want {
+			t.Errorf("%d.String() = %q, want %q", k, k, want)
+		}
+	}
+}
+
+func TestEOFNotes(t *testing.T) {
+	tests := []struct {
+		name, a, b string
+		want       []string
+	}{
+		{"same ending", "a\n", "b\n", nil},
+		{"no newline at end of A", "a", "a\n", []string{"No newline at end of A"}},
+		{"no newline at end of B", "a\n", "a", []string{"No newline at end of B"}},
+		{"empty input", "", "a\n", nil},
+		{"one blank line", "a\n \n", "a\n", []string{"A ends with 1 blank line, B with 0 blank lines"}},
+		{"both", "a", "a\n\n", []string{"No newline at end of A", "A ends with 0 blank lines, B with 1 blank line"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, n := range eofNotes(tt.a, tt.b) {
+				got = append(got, n.Text)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeWithin(t *testing.T) {
+	a := strings.Repeat("the quick brown fox\n", 200)
+	b := strings.Repeat("the quick brown cat\n", 200)
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    bool
+	}{
+		{"without a timeout", 0, false},
+		{"generous timeout", time.Hour, false},
+		{"timeout cut short", time.Nanosecond, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := ComputeWithin(a, b, Grapheme, tt.timeout)
+			var timedOut bool
+			for _, n := range d.Notes {
+				timedOut = timedOut || n.Kind == TimedOut
+			}
+			if timedOut != tt.want {
+				t.Errorf("timed out %v, want %v: %v", timedOut, tt.want, d.Notes)
+			}
+			if d.Equal() {
+				t.Error("no changes")
+			}
+		})
+	}
+}
+
+func TestDifferCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		took    time.Duration
+		want    bool
+	}{
+		{"in time", time.Hour, time.Millisecond, false},
+		{"out of time", time.Hour, 2 * time.Hour, true},
+		{"without a timeout", 0, 2 * time.Hour, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := &differ{dmp: diffmatchpatch.New()}
+			df.dmp.DiffTimeout = tt.timeout
+			df.check(time.Now().Add(-tt.took))
+			if df.timedOut != tt.want {
+				t.Errorf("timed out %v, want %v", df.timedOut, tt.want)
+			}
+		})
+	}
+}
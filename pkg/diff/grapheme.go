@@ -0,0 +1,70 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// clusters maps grapheme clusters, the characters a reader sees, to runes
+// so that the diff can treat each one as a unit and never splits an emoji
+// sequence or a letter from its combining marks.
+type clusters struct {
+	index map[string]rune
+	text  []string
+}
+
+// maxClusters is the number of runes available to stand for clusters.
+const maxClusters = 0x10FFFF - 0x800
+
+// encode returns s as one rune per grapheme cluster. It returns false if
+// there are more distinct clusters than runes to stand for them.
+func (c *clusters) encode(s string) ([]rune, bool) {
+	var rs []rune
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		str := g.Str()
+		r, ok := c.index[str]
+		if !ok {
+			if len(c.text) >= maxClusters {
+				return nil, false
+			}
+			r = rune(len(c.text))
+			if r >= 0xD800 {
+				r += 0x800 // skip the surrogates, which are not valid runes
+			}
+			c.index[str] = r
+			c.text = append(c.text, str)
+		}
+		rs = append(rs, r)
+	}
+	return rs, true
+}
+
+// decode reverses encode.
+func (c *clusters) decode(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= 0xE000 {
+			r -= 0x800
+		}
+		b.WriteString(c.text[r])
+	}
+	return b.String()
+}
+
+// diffClusters diffs a and b one grapheme cluster at a time.
+func diffClusters(df *differ, a, b string) []diffmatchpatch.Diff {
+	c := clusters{index: map[string]rune{}}
+	ra, okA := c.encode(a)
+	rb, okB := c.encode(b)
+	if !okA || !okB {
+		return df.main(a, b)
+	}
+	diffs := df.mainRunes(ra, rb)
+	for i := range diffs {
+		diffs[i].Text = c.decode(diffs[i].Text)
+	}
+	return diffs
+}
@@ -0,0 +1,45 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeKeepsClusters(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []string
+	}{
+		{"emoji skin tone", "hi 👍🏻", "hi 👍🏿", []string{`delete 👍🏻`, `insert 👍🏿`}},
+		{"family emoji", "👨‍👩‍👧", "👨‍👩‍👦", []string{`delete 👨‍👩‍👧`, `insert 👨‍👩‍👦`}},
+		{"combining mark", "café", "cafe", []string{`delete é`, `insert e`}},
+		{"flags", "🇩🇪🇫🇷", "🇩🇪🇮🇹", []string{`delete 🇫🇷`, `insert 🇮🇹`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range notEqual(Compute(tt.a, tt.b)) {
+				got = append(got, c.Op.String()+" "+c.Text)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClustersRoundTrip(t *testing.T) {
+	c := clusters{index: map[string]rune{}}
+	const s = "a👍🏻é\nb"
+	rs, ok := c.encode(s)
+	if !ok {
+		t.Fatal("encode failed")
+	}
+	if len(rs) != 5 {
+		t.Errorf("encoded %d clusters, want 5", len(rs))
+	}
+	if got := c.decode(string(rs)); got != s {
+		t.Errorf("decode = %q, want %q", got, s)
+	}
+}
@@ -0,0 +1,367 @@
+package diff
+
+import (
+	"bufio"
+	"errors"
+	"hash/fnv"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Large inputs are compared a line at a time. Each input is read through
+// once, keeping only a hash of every line and the offset it starts at; the
+// hashes are matched up and only the regions around lines that differ are
+// read back and compared with ComputeWithin.
+
+const (
+	// streamContext is the number of equal lines shown around each changed
+	// region.
+	streamContext = 3
+	// maxRegion is the size in bytes above which a changed region is shown
+	// as whole lines deleted and inserted rather than compared within the
+	// lines.
+	maxRegion = 1 << 20
+	// maxEdits bounds the number of lines inserted or deleted that lines
+	// without a unique match are matched up across. Regions that need more
+	// are shown as replaced.
+	maxEdits = 1000
+)
+
+// lineIndex is what is kept of an input: the hash of each line, the offset
+// each line starts at followed by the size of the input, and whether the
+// input ends with a line break.
+type lineIndex struct {
+	hashes  []uint64
+	offsets []int64
+	newline bool
+}
+
+// indexLines reads r through and indexes its lines.
+func indexLines(r io.Reader) (lineIndex, error) {
+	var ix lineIndex
+	br := bufio.NewReaderSize(r, 64<<10)
+	h := fnv.New64a()
+	var off, line int64
+	ix.offsets = append(ix.offsets, 0)
+	for {
+		chunk, err := br.ReadSlice('\n')
+		h.Write(chunk)
+		line += int64(len(chunk))
+		if errors.Is(err, bufio.ErrBufferFull) {
+			// The line goes on past the buffer.
+			continue
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return ix, err
+		}
+		if line > 0 {
+			off += line
+			ix.hashes = append(ix.hashes, h.Sum64())
+			ix.offsets = append(ix.offsets, off)
+			ix.newline = len(chunk) > 0 && chunk[len(chunk)-1] == '\n'
+			h.Reset()
+			line = 0
+		}
+		if err != nil {
+			return ix, nil
+		}
+	}
+}
+
+// ComputeLarge compares a with b like ComputeWithin, but without holding
+// either in memory: only the changed regions, with a few lines of context,
+// are read back and compared, each within timeout. The changes of the diff
+// therefore leave out the equal text between regions. Notes are limited to
+// the final newline and the timeout.
+func ComputeLarge(a, b io.ReaderAt, u Unit, timeout time.Duration) (Diff, error) {
+	ia, err := indexLines(io.NewSectionReader(a, 0, math.MaxInt64))
+	if err != nil {
+		return Diff{}, err
+	}
+	ib, err := indexLines(io.NewSectionReader(b, 0, math.MaxInt64))
+	if err != nil {
+		return Diff{}, err
+	}
+	var matches []block
+	matchLines(ia.hashes, ib.hashes, 0, 0, &matches)
+
+	d := Diff{Unit: u}
+	timedOut := false
+	for _, r := range changedRegions(matches, len(ia.hashes), len(ib.hashes)) {
+		changes, cut, err := compareRegion(a, b, ia, ib, r, u, timeout)
+		if err != nil {
+			return Diff{}, err
+		}
+		d.Changes = append(d.Changes, changes...)
+		timedOut = timedOut || cut
+	}
+	d.Hunks = group(d.Changes)
+	if len(ia.hashes) > 0 && len(ib.hashes) > 0 {
+		switch {
+		case ia.newline && !ib.newline:
+			d.Notes = append(d.Notes, Note{Kind: FinalNewline, Text: "No newline at end of B"})
+		case !ia.newline && ib.newline:
+			d.Notes = append(d.Notes, Note{Kind: FinalNewline, Text: "No newline at end of A"})
+		}
+	}
+	if timedOut {
+		d.Notes = append(d.Notes, timedOutNote(timeout))
+	}
+	return d, nil
+}
+
+// block is a run of n equal lines, starting at line a of the first input
+// and b of the second.
+type block struct {
+	a, b, n int
+}
+
+// matchLines appends to out, in order, the runs of lines of a and b that
+// match, offset by aOff and bOff. Common starts and ends are matched
+// first, then lines that occur once in each, and the lines between those
+// with the greedy algorithm of Myers.
+func matchLines(a, b []uint64, aOff, bOff int, out *[]block) {
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	if pre > 0 {
+		*out = append(*out, block{aOff, bOff, pre})
+	}
+	ma, mb := a[pre:len(a)-suf], b[pre:len(b)-suf]
+	if len(ma) > 0 && len(mb) > 0 {
+		if anchors := uniqueAnchors(ma, mb); len(anchors) > 0 {
+			pa, pb := 0, 0
+			for _, an := range anchors {
+				matchLines(ma[pa:an.a], mb[pb:an.b], aOff+pre+pa, bOff+pre+pb, out)
+				*out = append(*out, block{aOff + pre + an.a, bOff + pre + an.b, 1})
+				pa, pb = an.a+1, an.b+1
+			}
+			matchLines(ma[pa:], mb[pb:], aOff+pre+pa, bOff+pre+pb, out)
+		} else {
+			for _, m := range myers(ma, mb) {
+				*out = append(*out, block{aOff + pre + m.a, bOff + pre + m.b, m.n})
+			}
+		}
+	}
+	if suf > 0 {
+		*out = append(*out, block{aOff + len(a) - suf, bOff + len(b) - suf, suf})
+	}
+}
+
+// uniqueAnchors returns the longest sequence of lines that occur exactly
+// once in both a and b and are in the same order in both, as blocks of
+// one line.
+func uniqueAnchors(a, b []uint64) []block {
+	// Kept small, as there is an entry for every distinct line.
+	type seen struct{ a, b, ai, bi int32 }
+	counts := map[uint64]seen{}
+	for i, h := range a {
+		s := counts[h]
+		s.a++
+		s.ai = int32(i)
+		counts[h] = s
+	}
+	for i, h := range b {
+		if s, ok := counts[h]; ok {
+			s.b++
+			s.bi = int32(i)
+			counts[h] = s
+		}
+	}
+	var pairs []block
+	for _, h := range a {
+		if s := counts[h]; s.a == 1 && s.b == 1 {
+			pairs = append(pairs, block{int(s.ai), int(s.bi), 1})
+		}
+	}
+
+	// Patience sorting: tails[k] is the index in pairs of the smallest b
+	// ending an increasing run of length k+1.
+	var tails []int
+	prev := make([]int, len(pairs))
+	for i, p := range pairs {
+		k := sort.Search(len(tails), func(k int) bool { return pairs[tails[k]].b >= p.b })
+		prev[i] = -1
+		if k > 0 {
+			prev[i] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+	if len(tails) == 0 {
+		return nil
+	}
+	run := make([]block, len(tails))
+	for i, k := len(tails)-1, tails[len(tails)-1]; k >= 0; i, k = i-1, prev[k] {
+		run[i] = pairs[k]
+	}
+	return run
+}
+
+// myers returns the runs of lines of a and b that a shortest edit script
+// keeps, or none if it takes more than maxEdits lines inserted or deleted.
+// The trace it walks back keeps a copy of the whole frontier for every
+// round, which is what bounds maxEdits: at the cap that is some 1000 copies
+// of 2000 offsets, about 16 MB.
+func myers(a, b []uint64) []block {
+	n, m := len(a), len(b)
+	limit := min(n+m, maxEdits)
+	off := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[off+k-1] < v[off+k+1] {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, off, n, m)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack walks the trace of myers back from the end of both inputs and
+// returns the runs of lines kept, in order.
+func backtrack(trace [][]int, off, x, y int) []block {
+	var runs []block
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || k != d && v[off+k-1] < v[off+k+1] {
+			prevK = k + 1
+		}
+		prevX := v[off+prevK]
+		prevY := prevX - prevK
+		if n := min(x-prevX, y-prevY); n > 0 {
+			runs = append(runs, block{x - n, y - n, n})
+		}
+		x, y = prevX, prevY
+	}
+	for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+		runs[i], runs[j] = runs[j], runs[i]
+	}
+	return runs
+}
+
+// region is a range of lines of each input to compare: the lines from a0
+// up to a1 and from b0 up to b1, of which the first pre and the last post
+// are equal context.
+type region struct {
+	a0, a1, b0, b1 int
+	pre, post      int
+}
+
+// changedRegions returns the ranges of lines between the matched runs,
+// merged where they are close and widened by streamContext equal lines.
+func changedRegions(matches []block, na, nb int) []region {
+	var gaps []region
+	pa, pb := 0, 0
+	for _, m := range append(matches, block{na, nb, 0}) {
+		if m.a > pa || m.b > pb {
+			gaps = append(gaps, region{a0: pa, a1: m.a, b0: pb, b1: m.b})
+		}
+		pa, pb = m.a+m.n, m.b+m.n
+	}
+
+	var merged []region
+	for _, g := range gaps {
+		if n := len(merged); n > 0 && g.a0-merged[n-1].a1 <= 2*streamContext {
+			merged[n-1].a1, merged[n-1].b1 = g.a1, g.b1
+			continue
+		}
+		merged = append(merged, g)
+	}
+	for i := range merged {
+		r := &merged[i]
+		before, after := r.a0, na-r.a1
+		if i > 0 {
+			before = r.a0 - merged[i-1].a1
+		}
+		if i < len(merged)-1 {
+			after = merged[i+1].a0 - r.a1
+		}
+		r.pre, r.post = min(streamContext, before), min(streamContext, after)
+		r.a0, r.b0 = r.a0-r.pre, r.b0-r.pre
+		r.a1, r.b1 = r.a1+r.post, r.b1+r.post
+	}
+	return merged
+}
+
+// compareRegion reads region r of a and b back and compares it within
+// timeout, reporting whether the timeout cut the comparison short.
+func compareRegion(a, b io.ReaderAt, ia, ib lineIndex, r region, u Unit, timeout time.Duration) ([]Change, bool, error) {
+	ta, err := readLines(a, ia, r.a0, r.a1)
+	if err != nil {
+		return nil, false, err
+	}
+	tb, err := readLines(b, ib, r.b0, r.b1)
+	if err != nil {
+		return nil, false, err
+	}
+	aOff, bOff := int(ia.offsets[r.a0]), int(ib.offsets[r.b0])
+	if len(ta) <= maxRegion && len(tb) <= maxRegion {
+		d := ComputeWithin(ta, tb, u, timeout)
+		changes := d.Changes
+		for i := range changes {
+			c := &changes[i]
+			c.ALine += r.a0
+			c.BLine += r.b0
+			c.AOffset += aOff
+			c.BOffset += bOff
+		}
+		return changes, d.timedOut(), nil
+	}
+
+	// Too large to compare within the lines: the context is equal and the
+	// rest replaced.
+	preLen := int(ia.offsets[r.a0+r.pre]) - aOff
+	aEnd := int(ia.offsets[r.a1-r.post]) - aOff
+	bEnd := int(ib.offsets[r.b1-r.post]) - bOff
+	line := func(s string) int { return strings.Count(s, "\n") }
+	pre, del, ins, post := ta[:preLen], ta[preLen:aEnd], tb[preLen:bEnd], ta[aEnd:]
+	var changes []Change
+	add := func(op Op, text string, aLine, bLine, ao, bo int) {
+		if text != "" {
+			changes = append(changes, Change{Op: op, Text: text, Hint: hints(text), ALine: aLine, BLine: bLine, AOffset: ao, BOffset: bo})
+		}
+	}
+	aLine, bLine := r.a0+1+line(pre), r.b0+1+line(pre)
+	add(Equal, pre, r.a0+1, r.b0+1, aOff, bOff)
+	add(Delete, del, aLine, bLine, aOff+preLen, bOff+preLen)
+	add(Insert, ins, aLine+line(del), bLine, aOff+aEnd, bOff+preLen)
+	add(Equal, post, aLine+line(del), bLine+line(ins), aOff+aEnd, bOff+bEnd)
+	return changes, false, nil
+}
+
+// readLines reads lines from up to to of the input indexed by ix.
+func readLines(r io.ReaderAt, ix lineIndex, from, to int) (string, error) {
+	buf := make([]byte, ix.offsets[to]-ix.offsets[from])
+	if _, err := r.ReadAt(buf, ix.offsets[from]); err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return string(buf), nil
+}
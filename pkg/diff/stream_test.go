@@ -0,0 +1,141 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// numbered returns n lines "line 1" through "line n", each ending in a
+// line break.
+func numbered(n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d\n", i+1)
+	}
+	return lines
+}
+
+// edited returns lines with the line at each index of edits replaced.
+func edited(lines []string, edits map[int]string) []string {
+	out := append([]string(nil), lines...)
+	for i, l := range edits {
+		out[i] = l
+	}
+	return out
+}
+
+// noteKinds returns the kinds of the notes of d that ComputeLarge makes.
+func noteKinds(d Diff) []string {
+	var kinds []string
+	for _, n := range d.Notes {
+		if n.Kind == FinalNewline {
+			kinds = append(kinds, n.Kind)
+		}
+	}
+	return kinds
+}
+
+func TestComputeLargeMatchesComputeUnit(t *testing.T) {
+	base := numbered(200)
+	same := strings.Repeat("same\n", 5)
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"identical", strings.Join(base, ""), strings.Join(base, "")},
+		{"empty", "", ""},
+		{"one line changed", strings.Join(base, ""), strings.Join(edited(base, map[int]string{100: "line one hundred and one\n"}), "")},
+		{"changes within each other's context", strings.Join(base, ""), strings.Join(edited(base, map[int]string{50: "x\n", 53: "y\n", 57: "z\n"}), "")},
+		{"changes far apart", strings.Join(base, ""), strings.Join(edited(base, map[int]string{10: "x\n", 150: "y\n"}), "")},
+		{"first line changed", strings.Join(base, ""), strings.Join(edited(base, map[int]string{0: "first\n"}), "")},
+		{"last line changed", strings.Join(base, ""), strings.Join(edited(base, map[int]string{199: "last\n"}), "")},
+		{"lines inserted at start", strings.Join(base, ""), "new 1\nnew 2\n" + strings.Join(base, "")},
+		{"lines deleted at end", strings.Join(base, ""), strings.Join(base[:190], "")},
+		{"no newline at end of B", strings.Join(base, ""), strings.TrimSuffix(strings.Join(base, ""), "\n")},
+		{"no newline at end of A", strings.TrimSuffix(strings.Join(base, ""), "\n"), strings.Join(base, "")},
+		{"more than maxEdits", same + strings.Repeat("p\n", maxEdits) + same, same + strings.Repeat("q\n", maxEdits) + same},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			large, err := ComputeLarge(strings.NewReader(tt.a), strings.NewReader(tt.b), Grapheme, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			whole := ComputeWithin(tt.a, tt.b, Grapheme, 0)
+			if got, want := notEqual(large), notEqual(whole); !reflect.DeepEqual(got, want) {
+				t.Errorf("changes:\n got %+v\nwant %+v", got, want)
+			}
+			if got, want := large.Hunks, whole.Hunks; len(got) != len(want) {
+				t.Errorf("got %d hunks, want %d", len(got), len(want))
+			} else {
+				for i := range got {
+					if got[i].Kind != want[i].Kind || got[i].A != want[i].A || got[i].B != want[i].B {
+						t.Errorf("hunk %d: got %s %v %v, want %s %v %v", i, got[i].Kind, got[i].A, got[i].B, want[i].Kind, want[i].A, want[i].B)
+					}
+				}
+			}
+			if got, want := noteKinds(large), noteKinds(whole); !reflect.DeepEqual(got, want) {
+				t.Errorf("notes: got %v, want %v", got, want)
+			}
+			if large.Equal() != (tt.a == tt.b) {
+				t.Errorf("Equal() = %v", large.Equal())
+			}
+		})
+	}
+}
+
+func TestComputeLargeReplacesLargeRegions(t *testing.T) {
+	same := strings.Repeat("same\n", 10)
+	a := same + strings.Repeat("x\n", maxRegion/2+1) + same
+	b := same + strings.Repeat("y\n", maxRegion/2+1) + "tail\n"
+	d, err := ComputeLarge(strings.NewReader(a), strings.NewReader(b), Grapheme, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ops []Op
+	for _, c := range d.Changes {
+		ops = append(ops, c.Op)
+		if c.Op != Insert && a[c.AOffset:c.AOffset+len(c.Text)] != c.Text {
+			t.Errorf("%s at line %d: text is not at offset %d of A", c.Op, c.ALine, c.AOffset)
+		}
+		if c.Op != Delete && b[c.BOffset:c.BOffset+len(c.Text)] != c.Text {
+			t.Errorf("%s at line %d: text is not at offset %d of B", c.Op, c.BLine, c.BOffset)
+		}
+		if c.Op != Insert && strings.Count(a[:c.AOffset], "\n")+1 != c.ALine {
+			t.Errorf("%s: A line %d, want %d", c.Op, c.ALine, strings.Count(a[:c.AOffset], "\n")+1)
+		}
+		if c.Op != Delete && strings.Count(b[:c.BOffset], "\n")+1 != c.BLine {
+			t.Errorf("%s: B line %d, want %d", c.Op, c.BLine, strings.Count(b[:c.BOffset], "\n")+1)
+		}
+	}
+	if want := []Op{Equal, Delete, Insert}; !reflect.DeepEqual(ops, want) {
+		t.Errorf("got changes %v, want %v", ops, want)
+	}
+}
+
+func TestComputeLargeTimeout(t *testing.T) {
+	a := strings.Join(numbered(100), "") + strings.Repeat("the quick brown fox\n", 200)
+	b := strings.Join(numbered(100), "") + strings.Repeat("the quick brown cat\n", 200)
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    bool
+	}{
+		{"without a timeout", 0, false},
+		{"timeout cut short", time.Nanosecond, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ComputeLarge(strings.NewReader(a), strings.NewReader(b), Grapheme, tt.timeout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.timedOut() != tt.want {
+				t.Errorf("timed out %v, want %v: %v", d.timedOut(), tt.want, d.Notes)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package diff
+
+import (
+	"fmt"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// Unit is the smallest piece of text a diff can insert or delete.
+type Unit int
+
+const (
+	// Grapheme diffs characters as a reader sees them, so emoji sequences
+	// and combining marks stay whole.
+	Grapheme Unit = iota
+	// Rune diffs Unicode code points.
+	Rune
+	// Byte diffs raw bytes, which shows encoding damage that the other
+	// units hide.
+	Byte
+)
+
+// Units lists every unit.
+var Units = []Unit{Grapheme, Rune, Byte}
+
+// ParseUnit returns the unit called name.
+func ParseUnit(name string) (Unit, error) {
+	for _, u := range Units {
+		if u.String() == name {
+			return u, nil
+		}
+	}
+	return Grapheme, fmt.Errorf("unknown unit %q", name)
+}
+
+// MarshalText encodes u as its name.
+func (u Unit) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+func (u Unit) String() string {
+	switch u {
+	case Rune:
+		return "rune"
+	case Byte:
+		return "byte"
+	default:
+		return "grapheme"
+	}
+}
+
+// diffBytes diffs a and b one byte at a time. The text of the resulting
+// diffs may split UTF-8 sequences.
+func diffBytes(df *differ, a, b string) []diffmatchpatch.Diff {
+	diffs := df.mainRunes(byteRunes(a), byteRunes(b))
+	for i := range diffs {
+		bs := make([]byte, 0, len(diffs[i].Text))
+		for _, r := range diffs[i].Text {
+			bs = append(bs, byte(r))
+		}
+		diffs[i].Text = string(bs)
+	}
+	return diffs
+}
+
+// byteRunes returns one rune per byte of s.
+func byteRunes(s string) []rune {
+	rs := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		rs[i] = rune(s[i])
+	}
+	return rs
+}
@@ -0,0 +1,44 @@
+// Package hash computes checksums and digests of text.
+package hash
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	gohash "hash"
+	"hash/crc32"
+)
+
+// Algorithm is a named digest function.
+type Algorithm struct {
+	Name string
+	New  func() gohash.Hash
+}
+
+// Algorithms lists the supported algorithms from weakest to strongest.
+var Algorithms = []Algorithm{
+	{Name: "crc32", New: func() gohash.Hash { return crc32.NewIEEE() }},
+	{Name: "md5", New: md5.New},
+	{Name: "sha1", New: sha1.New},
+	{Name: "sha256", New: sha256.New},
+	{Name: "sha512", New: sha512.New},
+}
+
+// Lookup returns the algorithm called name.
+func Lookup(name string) (Algorithm, bool) {
+	for _, a := range Algorithms {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Algorithm{}, false
+}
+
+// Sum returns the hex encoded digest of s.
+func (a Algorithm) Sum(s string) string {
+	h := a.New()
+	h.Write([]byte(s))
+	return hex.EncodeToString(h.Sum(nil))
+}
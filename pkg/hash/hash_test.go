@@ -0,0 +1,29 @@
+package hash
+
+import "testing"
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"crc32", "abc", "352441c2"},
+		{"md5", "abc", "900150983cd24fb0d6963f7d28e17f72"},
+		{"sha1", "abc", "a9993e364706816aba3e25717850c26c9cd0d89d"},
+		{"sha256", "abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{"sha256", "", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.in, func(t *testing.T) {
+			a, ok := Lookup(tt.name)
+			if !ok {
+				t.Fatalf("no algorithm %q", tt.name)
+			}
+			if got := a.Sum(tt.in); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+	if _, ok := Lookup("md4"); ok {
+		t.Error("Lookup found md4")
+	}
+}
@@ -0,0 +1,91 @@
+// Package id generates unique identifiers such as UUIDs and ULIDs.
+package id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// Kind is a named kind of identifier.
+type Kind struct {
+	Name        string
+	Description string
+	New         func() string
+}
+
+// Kinds lists the supported kinds of identifier.
+var Kinds = []Kind{
+	{Name: "uuid4", Description: "random UUID (version 4)", New: UUIDv4},
+	{Name: "uuid7", Description: "time-ordered UUID (version 7)", New: UUIDv7},
+	{Name: "ulid", Description: "time-ordered ULID", New: ULID},
+}
+
+// Lookup returns the kind called name.
+func Lookup(name string) (Kind, bool) {
+	for _, k := range Kinds {
+		if k.Name == name {
+			return k, true
+		}
+	}
+	return Kind{}, false
+}
+
+// UUIDv4 returns a random UUID.
+func UUIDv4() string {
+	var u [16]byte
+	random(u[:])
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return format(u)
+}
+
+// UUIDv7 returns a UUID that starts with the current Unix time in
+// milliseconds, so that later UUIDs sort after earlier ones.
+func UUIDv7() string {
+	var u [16]byte
+	random(u[6:])
+	putMillis(u[:6], time.Now())
+	u[6] = u[6]&0x0f | 0x70
+	u[8] = u[8]&0x3f | 0x80
+	return format(u)
+}
+
+func format(u [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
+
+// crockford is the base 32 alphabet of ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID returns a ULID: the current Unix time in milliseconds followed by 80
+// random bits, written as 26 characters of Crockford's base 32.
+func ULID() string {
+	var u [16]byte
+	putMillis(u[:6], time.Now())
+	random(u[6:])
+	hi, lo := binary.BigEndian.Uint64(u[:8]), binary.BigEndian.Uint64(u[8:])
+	var s [26]byte
+	for i := len(s) - 1; i >= 0; i-- {
+		s[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(s[:])
+}
+
+// putMillis writes the Unix time of t in milliseconds as 48 bits.
+func putMillis(b []byte, t time.Time) {
+	ms := uint64(t.UnixMilli())
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+}
+
+func random(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic("id: reading random bytes: " + err.Error())
+	}
+}
@@ -0,0 +1,58 @@
+package id
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestKinds(t *testing.T) {
+	tests := []struct {
+		kind string
+		want *regexp.Regexp
+	}{
+		{"uuid4", regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{"uuid7", regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{"ulid", regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			k, ok := Lookup(tt.kind)
+			if !ok {
+				t.Fatalf("no kind %q", tt.kind)
+			}
+			seen := map[string]bool{}
+			for i := 0; i < 100; i++ {
+				s := k.New()
+				if !tt.want.MatchString(s) {
+					t.Fatalf("%q does not match %v", s, tt.want)
+				}
+				if seen[s] {
+					t.Fatalf("%q generated twice", s)
+				}
+				seen[s] = true
+			}
+		})
+	}
+	if _, ok := Lookup("uuid1"); ok {
+		t.Error("found kind uuid1")
+	}
+}
+
+func TestTimeOrdered(t *testing.T) {
+	for _, f := range []func() string{UUIDv7, ULID} {
+		first := f()
+		time.Sleep(2 * time.Millisecond)
+		if second := f(); second <= first {
+			t.Errorf("%q does not sort after %q", second, first)
+		}
+	}
+}
+
+func TestPutMillis(t *testing.T) {
+	var b [6]byte
+	putMillis(b[:], time.UnixMilli(0x0123456789ab))
+	if b != [6]byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab} {
+		t.Errorf("got % x", b)
+	}
+}
@@ -0,0 +1,243 @@
+// Package pattern checks whether a text matches a regular expression or
+// glob as a whole and, if not, finds where the text stops matching.
+package pattern
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+)
+
+// Syntax is the syntax of a pattern.
+type Syntax int
+
+const (
+	// Regex patterns use Go's regular expression syntax.
+	Regex Syntax = iota
+	// Glob patterns match literally except for * (any run of characters
+	// within a line), ? (any one character), [...] (one character of a
+	// class) and \ (which escapes the next character).
+	Glob
+	// Template patterns match literally except for placeholders such as
+	// {{number}}; see Placeholders.
+	Template
+)
+
+// Syntaxes lists every syntax.
+var Syntaxes = []Syntax{Regex, Glob, Template}
+
+func (s Syntax) String() string {
+	switch s {
+	case Glob:
+		return "glob"
+	case Template:
+		return "template"
+	default:
+		return "regex"
+	}
+}
+
+// ParseSyntax returns the syntax called name.
+func ParseSyntax(name string) (Syntax, error) {
+	for _, s := range Syntaxes {
+		if s.String() == name {
+			return s, nil
+		}
+	}
+	return Regex, fmt.Errorf("unknown pattern syntax %q", name)
+}
+
+// Pattern is a compiled pattern.
+type Pattern struct {
+	prog *syntax.Prog
+}
+
+// Compile compiles src written in syntax s.
+func Compile(src string, s Syntax) (*Pattern, error) {
+	var err error
+	switch s {
+	case Glob:
+		src, err = globToRegex(src)
+	case Template:
+		src, err = templateToRegex(src)
+	}
+	if err != nil {
+		return nil, err
+	}
+	re, err := syntax.Parse(src, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return nil, err
+	}
+	return &Pattern{prog: prog}, nil
+}
+
+// Result is the outcome of matching a text.
+type Result struct {
+	// Matched reports whether the whole text matches.
+	Matched bool
+	// Offset is the byte offset of the first character that no match can
+	// continue with, or the length of the text if it ends too early or
+	// matches.
+	Offset int
+	// Line and Column are the 1-based position of Offset.
+	Line, Column int
+}
+
+// Match matches the whole of text against p.
+func (p *Pattern) Match(text string) Result {
+	matched, off := p.run(text)
+	before := text[:off]
+	line := strings.Count(before, "\n") + 1
+	col := utf8.RuneCountInString(before[strings.LastIndex(before, "\n")+1:]) + 1
+	return Result{Matched: matched, Offset: off, Line: line, Column: col}
+}
+
+// run simulates p on text one rune at a time, keeping every thread that is
+// still alive. It reports whether a thread matches at the end of text, and
+// otherwise the furthest offset any thread reached.
+func (p *Pattern) run(text string) (bool, int) {
+	cur := p.follow(nil, uint32(p.prog.Start), -1, peek(text, 0))
+	for off := 0; ; {
+		if len(cur) == 0 {
+			return false, lastOffset(text, off)
+		}
+		if off == len(text) {
+			for _, pc := range cur {
+				if p.prog.Inst[pc].Op == syntax.InstMatch {
+					return true, off
+				}
+			}
+			return false, off
+		}
+		r, size := utf8.DecodeRuneInString(text[off:])
+		next := peek(text, off+size)
+		var list []uint32
+		for _, pc := range cur {
+			inst := &p.prog.Inst[pc]
+			ok := false
+			switch inst.Op {
+			case syntax.InstRune, syntax.InstRune1:
+				ok = inst.MatchRune(r)
+			case syntax.InstRuneAny:
+				ok = true
+			case syntax.InstRuneAnyNotNL:
+				ok = r != '\n'
+			}
+			if ok {
+				list = p.follow(list, inst.Out, r, next)
+			}
+		}
+		cur = list
+		off += size
+	}
+}
+
+// lastOffset returns the offset of the rune that ends at off, which is the
+// first rune no thread could consume.
+func lastOffset(text string, off int) int {
+	if off == 0 {
+		return 0
+	}
+	_, size := utf8.DecodeLastRuneInString(text[:off])
+	return off - size
+}
+
+// peek returns the rune at off, or -1 at the end of text.
+func peek(text string, off int) rune {
+	if off >= len(text) {
+		return -1
+	}
+	r, _ := utf8.DecodeRuneInString(text[off:])
+	return r
+}
+
+// follow adds pc to list, following empty transitions that the context
+// between the runes before and after allows. Only instructions that
+// consume a rune or match are added.
+func (p *Pattern) follow(list []uint32, pc uint32, before, after rune) []uint32 {
+	seen := map[uint32]bool{}
+	for _, l := range list {
+		seen[l] = true
+	}
+	ctx := syntax.EmptyOpContext(before, after)
+	var visit func(pc uint32)
+	visit = func(pc uint32) {
+		if seen[pc] {
+			return
+		}
+		seen[pc] = true
+		inst := &p.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			visit(inst.Out)
+			visit(inst.Arg)
+		case syntax.InstCapture, syntax.InstNop:
+			visit(inst.Out)
+		case syntax.InstEmptyWidth:
+			if syntax.EmptyOp(inst.Arg)&^ctx == 0 {
+				visit(inst.Out)
+			}
+		case syntax.InstFail:
+		default:
+			list = append(list, pc)
+		}
+	}
+	visit(pc)
+	return list
+}
+
+// globToRegex translates a glob into an equivalent regular expression.
+func globToRegex(glob string) (string, error) {
+	var b strings.Builder
+	rs := []rune(glob)
+	for i := 0; i < len(rs); i++ {
+		switch r := rs[i]; r {
+		case '*':
+			b.WriteString(`[^\n]*`)
+		case '?':
+			b.WriteString(`[^\n]`)
+		case '\\':
+			if i+1 == len(rs) {
+				return "", fmt.Errorf("glob ends with an unfinished escape")
+			}
+			i++
+			b.WriteString(regexpQuote(rs[i]))
+		case '[':
+			end := i + 1
+			if end < len(rs) && (rs[end] == '!' || rs[end] == '^') {
+				end++
+			}
+			if end < len(rs) && rs[end] == ']' {
+				end++
+			}
+			for end < len(rs) && rs[end] != ']' {
+				end++
+			}
+			if end == len(rs) {
+				return "", fmt.Errorf("unterminated character class in glob at %q", string(rs[i:]))
+			}
+			class := string(rs[i+1 : end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i = end
+		default:
+			b.WriteString(regexpQuote(r))
+		}
+	}
+	return b.String(), nil
+}
+
+func regexpQuote(r rune) string {
+	s := string(r)
+	if strings.ContainsRune(`\.+*?()|[]{}^$`, r) {
+		return `\` + s
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+package pattern
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		syntax  Syntax
+		text    string
+		want    Result
+	}{
+		{"regex matches", `id: \d+\nname: \w+\n`, Regex, "id: 42\nname: amy\n", Result{Matched: true, Offset: 17, Line: 3, Column: 1}},
+		{"regex mismatch", `id: \d+\nname: \w+\n`, Regex, "id: 42\nnom: amy\n", Result{Offset: 8, Line: 2, Column: 2}},
+		{"regex ends early", `abc`, Regex, "ab", Result{Offset: 2, Line: 1, Column: 3}},
+		{"regex extra text", `ab`, Regex, "abc", Result{Offset: 2, Line: 1, Column: 3}},
+		{"regex anchors", `(?m)^a$\n^b$`, Regex, "a\nb", Result{Matched: true, Offset: 3, Line: 2, Column: 2}},
+		{"regex column counts runes", `héllo`, Regex, "héllö", Result{Offset: 5, Line: 1, Column: 5}},
+		{"glob star", `took * ms`, Glob, "took 12 ms", Result{Matched: true, Offset: 10, Line: 1, Column: 11}},
+		{"glob star stops at a line", `a*b`, Glob, "a\nb", Result{Offset: 1, Line: 1, Column: 2}},
+		{"glob question", `v?.?`, Glob, "v1.x", Result{Matched: true, Offset: 4, Line: 1, Column: 5}},
+		{"glob class", `[!0-9]x`, Glob, "7x", Result{Offset: 0, Line: 1, Column: 1}},
+		{"glob escape", `a\*`, Glob, "a*", Result{Matched: true, Offset: 2, Line: 1, Column: 3}},
+		{"glob metacharacters are literal", `(a.b)`, Glob, "(a.b)", Result{Matched: true, Offset: 5, Line: 1, Column: 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Compile(tt.pattern, tt.syntax)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := p.Match(tt.text); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		pattern string
+		syntax  Syntax
+	}{
+		{`a(`, Regex},
+		{`a\`, Glob},
+		{`[abc`, Glob},
+	}
+	for _, tt := range tests {
+		if _, err := Compile(tt.pattern, tt.syntax); err == nil {
+			t.Errorf("Compile(%q, %v) succeeded", tt.pattern, tt.syntax)
+		}
+	}
+}
+
+func TestParseSyntax(t *testing.T) {
+	for _, s := range Syntaxes {
+		if got, err := ParseSyntax(s.String()); err != nil || got != s {
+			t.Errorf("ParseSyntax(%q) = %v, %v", s, got, err)
+		}
+	}
+	if _, err := ParseSyntax("pcre"); err == nil {
+		t.Error("ParseSyntax accepted an unknown syntax")
+	}
+}
@@ -0,0 +1,73 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Placeholders are the placeholders of templates by name, with the regular
+// expression each one matches. None matches across a line break.
+var Placeholders = map[string]string{
+	"any":       `[^\n]*?`,
+	"number":    `-?\d+(?:\.\d+)?(?:[eE][-+]?\d+)?`,
+	"hex":       `[0-9a-fA-F]+`,
+	"uuid":      `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+	"timestamp": `\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?`,
+}
+
+var placeholderRe = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// templateToRegex translates a template into an equivalent regular
+// expression.
+func templateToRegex(tmpl string) (string, error) {
+	var b strings.Builder
+	last := 0
+	for _, loc := range placeholderRe.FindAllStringSubmatchIndex(tmpl, -1) {
+		name := tmpl[loc[2]:loc[3]]
+		re, ok := Placeholders[name]
+		if !ok {
+			return "", fmt.Errorf("unknown placeholder {{%s}}", name)
+		}
+		b.WriteString(regexp.QuoteMeta(tmpl[last:loc[0]]))
+		b.WriteString("(?:" + re + ")")
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(tmpl[last:]))
+	return b.String(), nil
+}
+
+// MaskTemplate returns text with each line that matches a line of tmpl
+// holding placeholders replaced by that line, so that a diff of tmpl and
+// the result only shows differences outside the placeholders. Lines are
+// paired in order; lines of tmpl with unknown placeholders are kept as
+// literal text.
+func MaskTemplate(tmpl, text string) string {
+	type tmplLine struct {
+		text string
+		re   *regexp.Regexp
+	}
+	var lines []tmplLine
+	for _, l := range strings.Split(tmpl, "\n") {
+		if !placeholderRe.MatchString(l) {
+			continue
+		}
+		src, err := templateToRegex(l)
+		if err != nil {
+			continue
+		}
+		lines = append(lines, tmplLine{l, regexp.MustCompile(`^` + src + `$`)})
+	}
+	out := strings.Split(text, "\n")
+	next := 0
+	for i, l := range out {
+		for j := next; j < len(lines); j++ {
+			if lines[j].re.MatchString(l) {
+				out[i] = lines[j].text
+				next = j + 1
+				break
+			}
+		}
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,50 @@
+package pattern
+
+import "testing"
+
+func TestTemplate(t *testing.T) {
+	tests := []struct {
+		tmpl, text string
+		want       bool
+	}{
+		{"took {{number}} ms", "took 12.5 ms", true},
+		{"took {{number}} ms", "took ten ms", false},
+		{"id {{ uuid }}", "id 0b7e5a0c-6f1e-4b8e-9c43-2f6d7a1c9e10", true},
+		{"at {{timestamp}}", "at 2024-01-02T03:04:05.123Z", true},
+		{"hash {{hex}}", "hash 9f86d0", true},
+		{"[{{any}}] (1+1)", "[anything here] (1+1)", true},
+		{"{{any}}", "two\nlines", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tmpl+" "+tt.text, func(t *testing.T) {
+			p, err := Compile(tt.tmpl, Template)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := p.Match(tt.text).Matched; got != tt.want {
+				t.Errorf("Matched = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, err := Compile("{{date}}", Template); err == nil {
+		t.Error("Compile accepted an unknown placeholder")
+	}
+}
+
+func TestMaskTemplate(t *testing.T) {
+	tests := []struct {
+		name, tmpl, text, want string
+	}{
+		{"masked", "id {{number}}\nname amy\n", "id 42\nname amy\n", "id {{number}}\nname amy\n"},
+		{"difference outside placeholders", "id {{number}} ok\n", "id 42 failed\n", "id 42 failed\n"},
+		{"lines paired in order", "a {{number}}\nb {{number}}\n", "b 2\na 1\n", "b {{number}}\na 1\n"},
+		{"unknown placeholder is literal", "{{date}}\n", "2024\n", "2024\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaskTemplate(tt.tmpl, tt.text); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package preset
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+func init() {
+	Register(Preset{
+		Name:        "bench",
+		Description: "go test -bench output: compare benchmarks, report deltas with significance",
+		Normalize:   normalizeBench,
+		Report:      reportBench,
+	})
+}
+
+// alpha is the p-value below which a change counts as significant.
+const alpha = 0.05
+
+// minSamples is the number of runs of a benchmark, on each side, below
+// which no change counts as significant.
+const minSamples = 4
+
+var benchLineRe = regexp.MustCompile(`^(Benchmark\S*)\s+\d+((?:\s+[-\d.e+]+\s+\S+)+)\s*$`)
+
+// benchSamples holds the measurements of one benchmark in one unit, e.g.
+// ns/op, over every run.
+type benchSamples map[string][]float64
+
+// parseBench returns the samples of every benchmark of go test -bench
+// output by benchmark name and unit.
+func parseBench(s string) map[string]benchSamples {
+	benches := map[string]benchSamples{}
+	for _, line := range strings.Split(s, "\n") {
+		m := benchLineRe.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		fields := strings.Fields(m[2])
+		for i := 0; i+1 < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				continue
+			}
+			if benches[m[1]] == nil {
+				benches[m[1]] = benchSamples{}
+			}
+			benches[m[1]][fields[i+1]] = append(benches[m[1]][fields[i+1]], v)
+		}
+	}
+	return benches
+}
+
+// normalizeBench reduces benchmark output to its sorted benchmark names, so
+// the diff shows which benchmarks ran on only one side. The measurements
+// are covered by the report.
+func normalizeBench(s string) string {
+	benches := parseBench(s)
+	if len(benches) == 0 {
+		return s
+	}
+	names := make([]string, 0, len(benches))
+	for name := range benches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, "\n") + "\n"
+}
+
+// reportBench tabulates every benchmark and unit measured on both sides,
+// benchstat style: the median with its spread on each side, the change of
+// the median and whether the change is significant.
+func reportBench(a, b string) string {
+	ba, bb := parseBench(a), parseBench(b)
+	if len(ba) == 0 || len(bb) == 0 {
+		return ""
+	}
+	type row struct{ name, unit string }
+	var rows []row
+	for name, units := range ba {
+		for unit := range units {
+			if len(bb[name][unit]) > 0 {
+				rows = append(rows, row{name, unit})
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].unit != rows[j].unit {
+			return unitOrder(rows[i].unit) < unitOrder(rows[j].unit)
+		}
+		return rows[i].name < rows[j].name
+	})
+
+	var out strings.Builder
+	w := tabwriter.NewWriter(&out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "benchmark\tunit\tA\tB\tdelta")
+	for _, r := range rows {
+		xs, ys := ba[r.name][r.unit], bb[r.name][r.unit]
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.name, r.unit, summary(xs), summary(ys), change(xs, ys))
+	}
+	w.Flush()
+	return out.String() + "\n"
+}
+
+// unitOrder puts the usual units first.
+func unitOrder(unit string) string {
+	switch unit {
+	case "ns/op":
+		return "0"
+	case "B/op":
+		return "1"
+	case "allocs/op":
+		return "2"
+	}
+	return "3" + unit
+}
+
+// summary gives the median of xs and how far the samples stray from it.
+func summary(xs []float64) string {
+	med := median(xs)
+	spread := 0.0
+	for _, x := range xs {
+		spread = math.Max(spread, math.Abs(x-med))
+	}
+	if med == 0 || len(xs) < 2 {
+		return strconv.FormatFloat(med, 'g', 4, 64)
+	}
+	return fmt.Sprintf("%s ±%.0f%%", strconv.FormatFloat(med, 'g', 4, 64), spread/med*100)
+}
+
+// change describes how the median moves from xs to ys, or ~ if the change
+// is not significant.
+func change(xs, ys []float64) string {
+	n := fmt.Sprintf("n=%d+%d", len(xs), len(ys))
+	if len(xs) < minSamples || len(ys) < minSamples {
+		return fmt.Sprintf("? (%s, need %d runs each)", n, minSamples)
+	}
+	p := mannWhitney(xs, ys)
+	mx, my := median(xs), median(ys)
+	if p >= alpha || mx == my {
+		return fmt.Sprintf("~ (p=%.3f %s)", p, n)
+	}
+	if mx == 0 {
+		return fmt.Sprintf("+inf%% (p=%.3f %s)", p, n)
+	}
+	return fmt.Sprintf("%+.2f%% (p=%.3f %s)", (my-mx)/mx*100, p, n)
+}
+
+func median(xs []float64) float64 {
+	s := append([]float64(nil), xs...)
+	sort.Float64s(s)
+	if len(s)%2 == 1 {
+		return s[len(s)/2]
+	}
+	return (s[len(s)/2-1] + s[len(s)/2]) / 2
+}
+
+// mannWhitney returns the two-sided p-value of the Mann-Whitney U test that
+// xs and ys come from the same distribution, using the normal
+// approximation with corrections for ties and continuity.
+func mannWhitney(xs, ys []float64) float64 {
+	type sample struct {
+		v     float64
+		fromX bool
+	}
+	all := make([]sample, 0, len(xs)+len(ys))
+	for _, x := range xs {
+		all = append(all, sample{x, true})
+	}
+	for _, y := range ys {
+		all = append(all, sample{y, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].v < all[j].v })
+
+	n1, n2, n := float64(len(xs)), float64(len(ys)), float64(len(all))
+	var rankX, ties float64
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].v == all[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2 // the average of ranks i+1 through j
+		for k := i; k < j; k++ {
+			if all[k].fromX {
+				rankX += rank
+			}
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+	u := rankX - n1*(n1+1)/2
+	mu := n1 * n2 / 2
+	sigma := math.Sqrt(n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1))))
+	if sigma == 0 {
+		return 1
+	}
+	z := math.Max(math.Abs(u-mu)-0.5, 0) / sigma
+	return math.Erfc(z / math.Sqrt2)
+}
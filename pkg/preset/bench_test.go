@@ -0,0 +1,80 @@
+package preset
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// benchOutput returns go test -bench output with a run of BenchmarkParse
+// taking each of ns nanoseconds.
+func benchOutput(ns ...string) string {
+	var b strings.Builder
+	b.WriteString("goos: linux\ngoarch: amd64\n")
+	for _, n := range ns {
+		b.WriteString("BenchmarkParse-8   \t 1000000\t      " + n + " ns/op\t      64 B/op\t       2 allocs/op\n")
+	}
+	b.WriteString("PASS\nok  \tstrcli\t1.234s\n")
+	return b.String()
+}
+
+func TestNormalizeBench(t *testing.T) {
+	in := benchOutput("100") + "BenchmarkAlpha-8 10 5 ns/op\n"
+	if got, want := normalizeBench(in), "BenchmarkAlpha-8\nBenchmarkParse-8\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := normalizeBench("no benchmarks\n"); got != "no benchmarks\n" {
+		t.Errorf("got %q for output without benchmarks", got)
+	}
+}
+
+func TestReportBench(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		// want are parts of the report expected.
+		want []string
+	}{
+		{"significant", benchOutput("100", "101", "99", "100", "102"), benchOutput("50", "51", "49", "50", "50"), []string{
+			"benchmark         unit       A        B       delta\n" +
+				"BenchmarkParse-8  ns/op      100 ±2%  50 ±2%  -50.00% (p=0.011 n=5+5)\n" +
+				"BenchmarkParse-8  B/op       64 ±0%   64 ±0%  ~ (p=1.000 n=5+5)\n" +
+				"BenchmarkParse-8  allocs/op  2 ±0%    2 ±0%   ~ (p=1.000 n=5+5)\n",
+		}},
+		{"noise", benchOutput("100", "90", "110", "95"), benchOutput("105", "92", "99", "108"), []string{
+			"ns/op      97.5 ±13%  102 ±10%  ~ (p=",
+		}},
+		{"too few runs", benchOutput("100"), benchOutput("50"), []string{
+			"? (n=1+1, need 4 runs each)",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reportBench(tt.a, tt.b)
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("report\n%s\nhas no %q", got, w)
+				}
+			}
+		})
+	}
+	if got := reportBench(benchOutput("1"), "nothing"); got != "" {
+		t.Errorf("report against no benchmarks = %q, want none", got)
+	}
+}
+
+func TestMannWhitney(t *testing.T) {
+	tests := []struct {
+		xs, ys []float64
+		want   float64
+	}{
+		{[]float64{1, 2, 3, 4}, []float64{5, 6, 7, 8}, 0.0304},
+		{[]float64{1, 2, 3, 4}, []float64{1, 2, 3, 4}, 1},
+		{[]float64{5, 5, 5}, []float64{5, 5, 5}, 1},
+	}
+	for _, tt := range tests {
+		if got := mannWhitney(tt.xs, tt.ys); math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("mannWhitney(%v, %v) = %.4f, want %.4f", tt.xs, tt.ys, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package preset
+
+import (
+	"encoding/csv"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+func init() {
+	Register(Preset{
+		Name:        "dbdump",
+		Description: "SQL dumps and CSV exports: mask IDs and timestamps, sort rows",
+		Normalize:   normalizeDump,
+	})
+}
+
+// Placeholders that stand in for masked values.
+const (
+	idMask        = "<id>"
+	timestampMask = "<timestamp>"
+)
+
+var (
+	timestampRe = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?`)
+	autoIncRe   = regexp.MustCompile(`(?i)AUTO_INCREMENT=\d+`)
+	insertRe    = regexp.MustCompile(`(?i)^(INSERT\s+INTO\s+\S+(?:\s*\(([^)]*)\))?\s+VALUES)\s*(.*?);?\s*$`)
+	copyRe      = regexp.MustCompile(`(?i)^COPY\s+\S+(?:\s*\(([^)]*)\))?.*FROM\s+stdin;\s*$`)
+	intRe       = regexp.MustCompile(`^-?\d+$`)
+)
+
+// normalizeDump normalizes a SQL dump (INSERT or COPY statements) or, if the
+// input has none, a CSV export.
+func normalizeDump(s string) string {
+	if !strings.Contains(strings.ToUpper(s), "INSERT INTO") && !copyRe.MatchString(firstCopyLine(s)) {
+		if out, ok := normalizeCSV(s); ok {
+			return out
+		}
+	}
+	return normalizeSQL(s)
+}
+
+func firstCopyLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if copyRe.MatchString(line) {
+			return line
+		}
+	}
+	return ""
+}
+
+// normalizeSQL splits multi-row INSERTs into one row per line, masks the
+// integers in ID columns, masks timestamps and sorts the rows of each run of
+// INSERTs into the same table and of each COPY block. ID columns are found
+// in the column list of the INSERT or COPY statement; rows without one keep
+// their values.
+func normalizeSQL(s string) string {
+	var out, rows []string
+	var idCols []bool
+	prefix := ""
+	inCopy := false
+	flush := func() {
+		sort.Strings(rows)
+		out = append(out, rows...)
+		rows = nil
+		prefix = ""
+	}
+	for _, line := range strings.Split(s, "\n") {
+		line = autoIncRe.ReplaceAllString(line, "AUTO_INCREMENT="+idMask)
+		line = timestampRe.ReplaceAllString(line, timestampMask)
+		switch {
+		case inCopy:
+			if line == `\.` {
+				flush()
+				out = append(out, line)
+				inCopy = false
+				continue
+			}
+			fields := strings.Split(line, "\t")
+			maskIDs(fields, idCols)
+			rows = append(rows, strings.Join(fields, "\t"))
+		case copyRe.MatchString(line):
+			flush()
+			out = append(out, line)
+			idCols = idColumns(strings.Split(copyRe.FindStringSubmatch(line)[1], ","))
+			inCopy = true
+		default:
+			m := insertRe.FindStringSubmatch(line)
+			if m == nil {
+				flush()
+				out = append(out, line)
+				continue
+			}
+			if m[1] != prefix {
+				flush()
+				prefix = m[1]
+				idCols = idColumns(strings.Split(m[2], ","))
+			}
+			for _, tuple := range splitTuples(m[3]) {
+				rows = append(rows, prefix+" "+maskTuple(tuple, idCols)+";")
+			}
+		}
+	}
+	flush()
+	return strings.Join(out, "\n")
+}
+
+// splitTuples splits "(1,'a'),(2,'b')" into its parenthesized tuples.
+func splitTuples(s string) []string {
+	var tuples []string
+	depth, start := 0, -1
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quoted && c == '\\':
+			i++
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			if depth == 0 {
+				start = i
+			}
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 && start >= 0 {
+				tuples = append(tuples, s[start:i+1])
+				start = -1
+			}
+		}
+	}
+	return tuples
+}
+
+// maskTuple replaces the integer values of a tuple in the columns set in
+// idCols with idMask.
+func maskTuple(tuple string, idCols []bool) string {
+	values := splitValues(tuple[1 : len(tuple)-1])
+	maskIDs(values, idCols)
+	return "(" + strings.Join(values, ",") + ")"
+}
+
+// splitValues splits the inside of a tuple at the commas that are neither
+// quoted nor inside parentheses, keeping the spacing around each value.
+func splitValues(s string) []string {
+	var values []string
+	depth, start := 0, 0
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quoted && c == '\\':
+			i++
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			values = append(values, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(values, s[start:])
+}
+
+// idColumns reports which of the named columns are ID columns: those named
+// "id" or ending in "_id", in any case and with or without quotes.
+func idColumns(names []string) []bool {
+	ids := make([]bool, len(names))
+	for i, name := range names {
+		name = strings.ToLower(strings.Trim(strings.TrimSpace(name), "`\"[]"))
+		ids[i] = name == "id" || strings.HasSuffix(name, "_id")
+	}
+	return ids
+}
+
+// maskIDs replaces the integers among values in the columns set in idCols
+// with idMask.
+func maskIDs(values []string, idCols []bool) {
+	for i, v := range values {
+		if i < len(idCols) && idCols[i] && intRe.MatchString(strings.TrimSpace(v)) {
+			values[i] = idMask
+		}
+	}
+}
+
+// normalizeCSV masks ID columns and timestamps and sorts the rows of a CSV
+// export, keeping its header first. A column is an ID column if its header
+// is "id" or ends in "_id". It reports false if s is not CSV.
+func normalizeCSV(s string) (string, bool) {
+	r := csv.NewReader(strings.NewReader(s))
+	records, err := r.ReadAll()
+	if err != nil || len(records) == 0 || len(records[0]) < 2 {
+		return "", false
+	}
+	idCols := idColumns(records[0])
+	rows := records[1:]
+	for _, row := range rows {
+		for i, v := range row {
+			row[i] = timestampRe.ReplaceAllString(v, timestampMask)
+		}
+		maskIDs(row, idCols)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return strings.Join(rows[i], "\x00") < strings.Join(rows[j], "\x00")
+	})
+
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.WriteAll(records)
+	return b.String(), true
+}
@@ -0,0 +1,50 @@
+package preset
+
+import "testing"
+
+func TestNormalizeDump(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"insert rows split and sorted",
+			"INSERT INTO users (id, name) VALUES (2,'bob'),(1,'amy');",
+			"INSERT INTO users (id, name) VALUES (<id>,'amy');\nINSERT INTO users (id, name) VALUES (<id>,'bob');"},
+		{"quoted parentheses and commas",
+			"INSERT INTO t (id, v) VALUES (1,'a), (b');",
+			"INSERT INTO t (id, v) VALUES (<id>,'a), (b');"},
+		{"timestamps and auto increment",
+			"CREATE TABLE t (id int) AUTO_INCREMENT=42;\nINSERT INTO t (id, at) VALUES (1,'2024-01-02 03:04:05');",
+			"CREATE TABLE t (id int) AUTO_INCREMENT=<id>;\nINSERT INTO t (id, at) VALUES (<id>,'<timestamp>');"},
+		{"runs of inserts are sorted separately",
+			"INSERT INTO b (id) VALUES (2);\nINSERT INTO b (id) VALUES (1);\n-- c\nINSERT INTO a (id) VALUES (3);",
+			"INSERT INTO b (id) VALUES (<id>);\nINSERT INTO b (id) VALUES (<id>);\n-- c\nINSERT INTO a (id) VALUES (<id>);"},
+		{"only id columns are masked",
+			"INSERT INTO orders (qty, `user_id`, \"ID\", note) VALUES (3, 7, 9, 'x, 5');",
+			"INSERT INTO orders (qty, `user_id`, \"ID\", note) VALUES (3,<id>,<id>, 'x, 5');"},
+		{"values with parentheses",
+			"INSERT INTO t (at, id) VALUES (coalesce(1, 2), 4);",
+			"INSERT INTO t (at, id) VALUES (coalesce(1, 2),<id>);"},
+		{"no column list",
+			"INSERT INTO t VALUES (1,'a');",
+			"INSERT INTO t VALUES (1,'a');"},
+		{"copy block by column",
+			"COPY t (n, id) FROM stdin;\n2\t5\n\\.",
+			"COPY t (n, id) FROM stdin;\n2\t<id>\n\\."},
+		{"copy block",
+			"COPY public.t (id, name) FROM stdin;\n2\tbob\n1\tamy\n\\.",
+			"COPY public.t (id, name) FROM stdin;\n<id>\tamy\n<id>\tbob\n\\."},
+		{"csv",
+			"id,name,user_id,at\n2,bob,7,2024-01-02T03:04:05Z\n1,amy,8,2024-01-02T03:04:06Z\n",
+			"id,name,user_id,at\n<id>,amy,<id>,<timestamp>\n<id>,bob,<id>,<timestamp>\n"},
+		{"csv keeps other numbers",
+			"id,count\n1,5\n",
+			"id,count\n<id>,5\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeDump(tt.in); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package preset
+
+import "strcli/pkg/transform"
+
+func init() {
+	Register(Preset{
+		Name:        "json",
+		Description: "JSON documents: pretty-print both sides so the diff is line by line",
+		Normalize:   normalizeJSON,
+	})
+}
+
+// normalizeJSON pretty-prints s, or leaves it as it is if it is not JSON.
+func normalizeJSON(s string) string {
+	out, err := transform.JSONIndent(s, "  ")
+	if err != nil {
+		return s
+	}
+	return out
+}
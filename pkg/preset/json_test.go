@@ -0,0 +1,20 @@
+package preset
+
+import "testing"
+
+func TestNormalizeJSON(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"compact", `{"a":[1,2]}`, "{\n  \"a\": [\n    1,\n    2\n  ]\n}\n"},
+		{"already pretty", "{\n  \"a\": 1\n}\n", "{\n  \"a\": 1\n}\n"},
+		{"not JSON", "a: 1\n", "a: 1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeJSON(tt.in); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
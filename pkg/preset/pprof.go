@@ -0,0 +1,142 @@
+package preset
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+func init() {
+	Register(Preset{
+		Name:        "pprof",
+		Description: "go tool pprof -text output: compare functions, report flat and cum deltas",
+		Normalize:   normalizeProfile,
+		Report:      reportProfile,
+	})
+}
+
+// profileRow is one function of a pprof -text listing.
+type profileRow struct {
+	flat, cum string
+	// flatV and cumV are flat and cum in the base unit.
+	flatV, cumV float64
+}
+
+var (
+	valueRe      = `(-?[\d.]+(?:[a-zµ]*|[KMGTPE]?B))`
+	percentRe    = `(?:-?[\d.]+%)`
+	profileRowRe = regexp.MustCompile(`^\s*` + valueRe + `\s+` + percentRe + `\s+` + percentRe + `\s+` + valueRe + `\s+` + percentRe + `\s+(.+?)\s*$`)
+	unitScale    = map[string]float64{
+		"": 1, "ns": 1e-9, "us": 1e-6, "µs": 1e-6, "ms": 1e-3, "s": 1, "m": 60, "min": 60, "h": 3600, "hrs": 3600,
+		"B": 1, "kB": 1 << 10, "KB": 1 << 10, "MB": 1 << 20, "GB": 1 << 30, "TB": 1 << 40, "PB": 1 << 50, "EB": 1 << 60,
+	}
+	numberUnitRe = regexp.MustCompile(`^(-?[\d.]+)(.*)$`)
+)
+
+// parseProfile returns the rows of a pprof -text listing by function.
+func parseProfile(s string) map[string]profileRow {
+	rows := map[string]profileRow{}
+	for _, line := range strings.Split(s, "\n") {
+		m := profileRowRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		rows[m[3]] = profileRow{flat: m[1], cum: m[2], flatV: parseValue(m[1]), cumV: parseValue(m[2])}
+	}
+	return rows
+}
+
+// parseValue parses a value such as "1.20s" or "512kB" in its base unit.
+func parseValue(v string) float64 {
+	m := numberUnitRe.FindStringSubmatch(v)
+	if m == nil {
+		return 0
+	}
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0
+	}
+	return n * unitScale[m[2]]
+}
+
+// normalizeProfile reduces a listing to its sorted function names, so the
+// diff shows which functions appear in only one profile. The values are
+// covered by the report.
+func normalizeProfile(s string) string {
+	rows := parseProfile(s)
+	if len(rows) == 0 {
+		return s
+	}
+	names := make([]string, 0, len(rows))
+	for name := range rows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, "\n") + "\n"
+}
+
+// reportProfile tabulates the flat and cum values of every function in
+// both listings, biggest flat change first.
+func reportProfile(a, b string) string {
+	ra, rb := parseProfile(a), parseProfile(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return ""
+	}
+	names := map[string]bool{}
+	for name := range ra {
+		names[name] = true
+	}
+	for name := range rb {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	change := func(name string) float64 { return math.Abs(rb[name].flatV - ra[name].flatV) }
+	sort.Slice(sorted, func(i, j int) bool {
+		if ci, cj := change(sorted[i]), change(sorted[j]); ci != cj {
+			return ci > cj
+		}
+		return sorted[i] < sorted[j]
+	})
+
+	var out strings.Builder
+	w := tabwriter.NewWriter(&out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "function\tflat A\tflat B\tdelta\tcum A\tcum B\tdelta")
+	for _, name := range sorted {
+		x, inA := ra[name]
+		y, inB := rb[name]
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", name,
+			orDash(x.flat, inA), orDash(y.flat, inB), delta(x.flatV, y.flatV, inA, inB),
+			orDash(x.cum, inA), orDash(y.cum, inB), delta(x.cumV, y.cumV, inA, inB))
+	}
+	w.Flush()
+	return out.String() + "\n"
+}
+
+func orDash(s string, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return s
+}
+
+// delta describes the change from a to b as a percentage.
+func delta(a, b float64, inA, inB bool) string {
+	switch {
+	case !inA:
+		return "new"
+	case !inB:
+		return "gone"
+	case a == b:
+		return "~"
+	case a == 0:
+		return "+inf%"
+	}
+	return fmt.Sprintf("%+.1f%%", (b-a)/a*100)
+}
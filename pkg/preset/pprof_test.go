@@ -0,0 +1,73 @@
+package preset
+
+import (
+	"strings"
+	"testing"
+)
+
+const profileA = `File: strcli
+Type: cpu
+Showing nodes accounting for 1.50s, 100% of 1.50s total
+      flat  flat%   sum%        cum   cum%
+     1.00s 66.67% 66.67%      1.20s 80.00%  main.parse
+     0.50s 33.33%   100%      0.50s 33.33%  runtime.mallocgc
+`
+
+const profileB = `File: strcli
+Type: cpu
+Showing nodes accounting for 1.30s, 100% of 1.30s total
+      flat  flat%   sum%        cum   cum%
+     500ms 38.46% 38.46%      700ms 53.85%  main.parse
+     0.50s 38.46% 76.92%      0.50s 38.46%  runtime.mallocgc
+     0.30s 23.08%   100%      0.30s 23.08%  main.render
+`
+
+func TestNormalizeProfile(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"listing", profileB, "main.parse\nmain.render\nruntime.mallocgc\n"},
+		{"not a profile", "hello\n", "hello\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeProfile(tt.in); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportProfile(t *testing.T) {
+	got := reportProfile(profileA, profileB)
+	want := []string{
+		"function          flat A  flat B  delta   cum A  cum B  delta",
+		"main.parse        1.00s   500ms   -50.0%  1.20s  700ms  -41.7%",
+		"main.render       -       0.30s   new     -      0.30s  new",
+		"runtime.mallocgc  0.50s   0.50s   ~       0.50s  0.50s  ~",
+	}
+	if lines := strings.Split(strings.TrimRight(got, "\n"), "\n"); strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwant\n%s", got, strings.Join(want, "\n"))
+	}
+	if got := reportProfile(profileA, "not a profile"); got != "" {
+		t.Errorf("report of a non-profile = %q, want none", got)
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"1.5s", 1.5},
+		{"250ms", 0.25},
+		{"2kB", 2048},
+		{"1MB", 1 << 20},
+		{"x", 0},
+	}
+	for _, tt := range tests {
+		if got := parseValue(tt.in); got != tt.want {
+			t.Errorf("parseValue(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
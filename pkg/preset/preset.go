@@ -0,0 +1,46 @@
+// Package preset provides ways of preparing both inputs of a comparison for
+// a particular kind of text, so that only meaningful differences remain.
+package preset
+
+import "sort"
+
+// Preset prepares inputs of one kind of text for comparison.
+type Preset struct {
+	// Name identifies the preset on the command line, e.g. "dbdump".
+	Name string
+	// Description is a one-line summary shown in menus.
+	Description string
+	// Normalize rewrites an input so irrelevant differences disappear.
+	Normalize func(string) string
+	// Report, if set, summarizes how two inputs differ where a line diff
+	// would not be readable, e.g. as a table of deltas. It returns "" if
+	// it cannot make sense of the inputs.
+	Report func(a, b string) string
+}
+
+var registry = map[string]Preset{}
+
+// Register makes p available through Lookup and All. It panics if a preset
+// with the same name is already registered.
+func Register(p Preset) {
+	if _, dup := registry[p.Name]; dup {
+		panic("preset: duplicate name " + p.Name)
+	}
+	registry[p.Name] = p
+}
+
+// Lookup returns the preset called name.
+func Lookup(name string) (Preset, bool) {
+	p, ok := registry[name]
+	return p, ok
+}
+
+// All returns every registered preset sorted by name.
+func All() []Preset {
+	all := make([]Preset, 0, len(registry))
+	for _, p := range registry {
+		all = append(all, p)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
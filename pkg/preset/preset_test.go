@@ -0,0 +1,24 @@
+package preset
+
+import "testing"
+
+func TestRegistry(t *testing.T) {
+	if _, ok := Lookup("dbdump"); !ok {
+		t.Error("dbdump is not registered")
+	}
+	if _, ok := Lookup("no-such-preset"); ok {
+		t.Error("Lookup found a preset that is not registered")
+	}
+	all := All()
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Name >= all[i].Name {
+			t.Errorf("All is not sorted: %s before %s", all[i-1].Name, all[i].Name)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a duplicate name did not panic")
+		}
+	}()
+	Register(Preset{Name: "dbdump"})
+}
@@ -0,0 +1,49 @@
+package preset
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+func init() {
+	Register(Preset{
+		Name:        "stacktrace",
+		Description: "Go panics and goroutine dumps: mask IDs, addresses and line numbers",
+		Normalize:   normalizeStack,
+	})
+}
+
+var (
+	goroutineRe = regexp.MustCompile(`\bgoroutine \d+\b`)
+	waitRe      = regexp.MustCompile(`^(goroutine <n> \[[^,\]]+), [^\]]+\]`)
+	argsRe      = regexp.MustCompile(`^(\S+)\((?:0x[0-9a-f]+|\.\.\.|\{[^}]*\})(?:, (?:0x[0-9a-f]+|\.\.\.|\{[^}]*\}))*\)$`)
+	fileLineRe  = regexp.MustCompile(`^(\s+\S+\.go):\d+( \+0x[0-9a-f]+)?$`)
+	addrRe      = regexp.MustCompile(`\b0x[0-9a-f]{6,}\b`)
+)
+
+// normalizeStack masks what differs between runs of the same crash:
+// goroutine IDs, how long goroutines have waited, call arguments, line
+// numbers, code offsets and addresses. The goroutines after the first,
+// which is the one that panicked, are sorted since their order is random.
+func normalizeStack(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		l = goroutineRe.ReplaceAllString(l, "goroutine <n>")
+		l = waitRe.ReplaceAllString(l, "$1]")
+		l = argsRe.ReplaceAllString(l, "$1(...)")
+		l = fileLineRe.ReplaceAllString(l, "$1:<line>")
+		lines[i] = addrRe.ReplaceAllString(l, "<addr>")
+	}
+	body := strings.Join(lines, "\n")
+	trimmed := strings.TrimRight(body, "\n")
+	blocks := strings.Split(trimmed, "\n\n")
+	var first int
+	for first < len(blocks) && !strings.HasPrefix(blocks[first], "goroutine ") {
+		first++
+	}
+	if first+1 < len(blocks) {
+		sort.Strings(blocks[first+1:])
+	}
+	return strings.Join(blocks, "\n\n") + body[len(trimmed):]
+}
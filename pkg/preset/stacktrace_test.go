@@ -0,0 +1,62 @@
+package preset
+
+import "testing"
+
+func TestNormalizeStack(t *testing.T) {
+	const a = `panic: boom
+
+goroutine 7 [running]:
+main.run(0xc000012345, 0x3)
+	/src/main.go:42 +0x1d
+main.main()
+	/src/main.go:10 +0x25
+
+goroutine 9 [select, 2 minutes]:
+net/http.serve({0x1, 0x2})
+	/go/net/http/server.go:300 +0x99
+
+goroutine 8 [chan receive]:
+main.worker(...)
+	/src/worker.go:5
+`
+	const b = `panic: boom
+
+goroutine 1 [running]:
+main.run(0xc0000aaaaa, 0x4)
+	/src/main.go:44 +0x2f
+main.main()
+	/src/main.go:11 +0x30
+
+goroutine 12 [chan receive]:
+main.worker(...)
+	/src/worker.go:6
+
+goroutine 3 [select]:
+net/http.serve({0x5, 0x6})
+	/go/net/http/server.go:301 +0x10
+`
+	const want = `panic: boom
+
+goroutine <n> [running]:
+main.run(...)
+	/src/main.go:<line>
+main.main()
+	/src/main.go:<line>
+
+goroutine <n> [chan receive]:
+main.worker(...)
+	/src/worker.go:<line>
+
+goroutine <n> [select]:
+net/http.serve(...)
+	/go/net/http/server.go:<line>
+`
+	for name, in := range map[string]string{"a": a, "b": b} {
+		if got := normalizeStack(in); got != want {
+			t.Errorf("%s: got\n%s\nwant\n%s", name, got, want)
+		}
+	}
+	if got := normalizeStack("created at 0xc000123456\n"); got != "created at <addr>\n" {
+		t.Errorf("address not masked: %q", got)
+	}
+}
@@ -0,0 +1,195 @@
+package preset
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(Preset{
+		Name:        "tests",
+		Description: "go test -json, go test -v or JUnit XML: report tests that newly fail or pass",
+		Normalize:   normalizeTests,
+		Report:      reportTests,
+	})
+}
+
+// testResult is the outcome of one test.
+type testResult struct {
+	status  string // pass, fail or skip
+	seconds float64
+}
+
+// parseTests returns the results of the tests in s by name, from whichever
+// format s is in.
+func parseTests(s string) map[string]testResult {
+	trimmed := strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(trimmed, "<"):
+		return parseJUnit(trimmed)
+	case strings.HasPrefix(trimmed, "{"):
+		return parseTestJSON(trimmed)
+	}
+	return parseTestVerbose(s)
+}
+
+// parseTestJSON reads the events of go test -json.
+func parseTestJSON(s string) map[string]testResult {
+	results := map[string]testResult{}
+	sc := bufio.NewScanner(strings.NewReader(s))
+	sc.Buffer(nil, 1<<24)
+	for sc.Scan() {
+		var ev struct {
+			Action, Package, Test string
+			Elapsed               float64
+		}
+		if json.Unmarshal(sc.Bytes(), &ev) != nil || ev.Test == "" {
+			continue
+		}
+		switch ev.Action {
+		case "pass", "fail", "skip":
+			results[ev.Package+"."+ev.Test] = testResult{status: ev.Action, seconds: ev.Elapsed}
+		}
+	}
+	return results
+}
+
+var testVerboseRe = regexp.MustCompile(`^\s*--- (PASS|FAIL|SKIP): (\S+) \(([\d.]+)s\)`)
+
+// parseTestVerbose reads the result lines of go test -v.
+func parseTestVerbose(s string) map[string]testResult {
+	results := map[string]testResult{}
+	for _, line := range strings.Split(s, "\n") {
+		if m := testVerboseRe.FindStringSubmatch(line); m != nil {
+			secs, _ := strconv.ParseFloat(m[3], 64)
+			results[m[2]] = testResult{status: strings.ToLower(m[1]), seconds: secs}
+		}
+	}
+	return results
+}
+
+// parseJUnit reads the test cases of a JUnit XML report, however its test
+// suites are nested.
+func parseJUnit(s string) map[string]testResult {
+	results := map[string]testResult{}
+	dec := xml.NewDecoder(strings.NewReader(s))
+	for {
+		tok, err := dec.Token()
+		if err != nil { // io.EOF or a syntax error
+			return results
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "testcase" {
+			continue
+		}
+		var tc struct {
+			Name      string    `xml:"name,attr"`
+			ClassName string    `xml:"classname,attr"`
+			Time      string    `xml:"time,attr"`
+			Failure   *struct{} `xml:"failure"`
+			Error     *struct{} `xml:"error"`
+			Skipped   *struct{} `xml:"skipped"`
+		}
+		if dec.DecodeElement(&tc, &start) != nil {
+			continue
+		}
+		r := testResult{status: "pass"}
+		r.seconds, _ = strconv.ParseFloat(tc.Time, 64)
+		switch {
+		case tc.Failure != nil || tc.Error != nil:
+			r.status = "fail"
+		case tc.Skipped != nil:
+			r.status = "skip"
+		}
+		name := tc.Name
+		if tc.ClassName != "" {
+			name = tc.ClassName + "." + name
+		}
+		results[name] = r
+	}
+}
+
+// normalizeTests reduces test output to a sorted line per test with its
+// status, so the diff shows the tests whose status changed.
+func normalizeTests(s string) string {
+	results := parseTests(s)
+	if len(results) == 0 {
+		return s
+	}
+	var b strings.Builder
+	for _, name := range sortedKeys(results) {
+		fmt.Fprintf(&b, "%s %s\n", results[name].status, name)
+	}
+	return b.String()
+}
+
+// Durations change significantly when they grow or shrink by this factor
+// and by at least minDurationChange seconds.
+const (
+	durationFactor    = 1.5
+	minDurationChange = 0.1
+)
+
+// reportTests lists the tests that newly fail or pass, that were added or
+// removed and whose duration changed significantly.
+func reportTests(a, b string) string {
+	ra, rb := parseTests(a), parseTests(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return ""
+	}
+	sections := []struct {
+		title string
+		names []string
+	}{{title: "Newly failing"}, {title: "Newly passing"}, {title: "Added"}, {title: "Removed"}, {title: "Duration changed"}}
+	for _, name := range sortedKeys(ra) {
+		if _, ok := rb[name]; !ok {
+			sections[3].names = append(sections[3].names, name+" ("+ra[name].status+")")
+		}
+	}
+	for _, name := range sortedKeys(rb) {
+		x, inA := ra[name]
+		y := rb[name]
+		switch {
+		case !inA:
+			sections[2].names = append(sections[2].names, name+" ("+y.status+")")
+		case y.status == "fail" && x.status != "fail":
+			sections[0].names = append(sections[0].names, name)
+		case x.status == "fail" && y.status == "pass":
+			sections[1].names = append(sections[1].names, name)
+		}
+		if inA && math.Abs(y.seconds-x.seconds) >= minDurationChange &&
+			(y.seconds >= x.seconds*durationFactor || x.seconds >= y.seconds*durationFactor) {
+			sections[4].names = append(sections[4].names, fmt.Sprintf("%s %.2fs → %.2fs", name, x.seconds, y.seconds))
+		}
+	}
+	var out strings.Builder
+	for _, s := range sections {
+		if len(s.names) == 0 {
+			continue
+		}
+		fmt.Fprintf(&out, "%s:\n", s.title)
+		for _, n := range s.names {
+			fmt.Fprintf(&out, "  %s\n", n)
+		}
+	}
+	if out.Len() == 0 {
+		return "No test changed status.\n\n"
+	}
+	return out.String() + "\n"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
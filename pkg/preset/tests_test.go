@@ -0,0 +1,77 @@
+package preset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTests(t *testing.T) {
+	tests := []struct {
+		name, in string
+		want     map[string]testResult
+	}{
+		{"verbose", "=== RUN   TestA\n--- PASS: TestA (0.01s)\n=== RUN   TestB\n    --- FAIL: TestB/sub (1.50s)\n--- SKIP: TestC (0.00s)\n", map[string]testResult{
+			"TestA":     {"pass", 0.01},
+			"TestB/sub": {"fail", 1.5},
+			"TestC":     {"skip", 0},
+		}},
+		{"json", `{"Action":"run","Package":"p","Test":"TestA"}
+{"Action":"pass","Package":"p","Test":"TestA","Elapsed":0.2}
+{"Action":"fail","Package":"p","Test":"TestB","Elapsed":1}
+{"Action":"pass","Package":"p","Elapsed":1.2}
+`, map[string]testResult{
+			"p.TestA": {"pass", 0.2},
+			"p.TestB": {"fail", 1},
+		}},
+		{"junit", `<?xml version="1.0"?>
+<testsuites><testsuite name="s">
+  <testcase classname="pkg.Suite" name="ok" time="0.5"/>
+  <testcase classname="pkg.Suite" name="broken" time="1"><failure message="x"/></testcase>
+  <testcase name="crashed"><error/></testcase>
+  <testcase name="later"><skipped/></testcase>
+</testsuite></testsuites>`, map[string]testResult{
+			"pkg.Suite.ok":     {"pass", 0.5},
+			"pkg.Suite.broken": {"fail", 1},
+			"crashed":          {"fail", 0},
+			"later":            {"skip", 0},
+		}},
+		{"nothing", "hello\n", map[string]testResult{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseTests(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeTests(t *testing.T) {
+	in := "--- PASS: TestB (0.01s)\n--- FAIL: TestA (0.01s)\nFAIL\n"
+	if got, want := normalizeTests(in), "fail TestA\npass TestB\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := normalizeTests("no tests\n"); got != "no tests\n" {
+		t.Errorf("got %q for output without tests", got)
+	}
+}
+
+func TestReportTests(t *testing.T) {
+	tests := []struct {
+		name, a, b, want string
+	}{
+		{"changes",
+			"--- PASS: TestA (0.01s)\n--- FAIL: TestB (0.01s)\n--- PASS: TestGone (0.01s)\n--- PASS: TestSlow (0.10s)\n",
+			"--- FAIL: TestA (0.01s)\n--- PASS: TestB (0.01s)\n--- SKIP: TestNew (0.00s)\n--- PASS: TestSlow (1.00s)\n",
+			"Newly failing:\n  TestA\nNewly passing:\n  TestB\nAdded:\n  TestNew (skip)\nRemoved:\n  TestGone (pass)\nDuration changed:\n  TestSlow 0.10s → 1.00s\n\n"},
+		{"small duration change", "--- PASS: TestA (0.01s)\n", "--- PASS: TestA (0.05s)\n", "No test changed status.\n\n"},
+		{"not test output", "--- PASS: TestA (0.01s)\n", "hello\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reportTests(tt.a, tt.b); got != tt.want {
+				t.Errorf("got\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
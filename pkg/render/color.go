@@ -0,0 +1,69 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"strcli/pkg/diff"
+)
+
+// Styles are the styles diffs are colored in: Insert and Delete for
+// changed text, Note for the notes of a diff, and for Visible, Glyph for
+// whitespace, Invisible for invisible characters and Trailing for
+// whitespace at the end of a line. Markers prefixes each line of a change
+// with +, - or a space, so the diff can be read without colors.
+type Styles struct {
+	Insert, Delete, Note       lipgloss.Style
+	Glyph, Invisible, Trailing lipgloss.Style
+	Markers                    bool
+}
+
+// DefaultStyles returns the styles of a dark terminal: insertions in green
+// and deletions in red.
+func DefaultStyles() Styles {
+	return Styles{
+		Insert:    lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")),
+		Delete:    lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")),
+		Note:      lipgloss.NewStyle().Foreground(lipgloss.Color("#FFAF00")).Bold(true),
+		Glyph:     lipgloss.NewStyle().Foreground(lipgloss.Color("244")),
+		Invisible: lipgloss.NewStyle().Foreground(lipgloss.Color("#FFAF00")).Bold(true),
+		Trailing:  lipgloss.NewStyle().Background(lipgloss.Color("52")),
+	}
+}
+
+// Color renders every change of d on its own line, with insertions in the
+// Insert style and deletions in the Delete style, followed by the notes of
+// d.
+func (s Styles) Color(d diff.Diff) string {
+	return s.color(d, func(t string) string { return t })
+}
+
+// color renders d like Color, passing the text of each change through
+// show.
+func (s Styles) color(d diff.Diff, show func(string) string) string {
+	var coloredDiff string
+	for _, c := range d.Changes {
+		switch c.Op {
+		case diff.Insert:
+			coloredDiff += s.Insert.Render(s.mark("+", show(Text(d, c))))
+		case diff.Delete:
+			coloredDiff += s.Delete.Render(s.mark("-", show(Text(d, c))))
+		case diff.Equal:
+			coloredDiff += s.mark(" ", show(Text(d, c)))
+		}
+		coloredDiff += "\n"
+	}
+	for _, n := range d.Notes {
+		coloredDiff += s.Note.Render("⚠ "+n.Text) + "\n"
+	}
+	return coloredDiff
+}
+
+// mark prefixes every line of t with prefix if s has Markers on.
+func (s Styles) mark(prefix, t string) string {
+	if !s.Markers {
+		return t
+	}
+	body := strings.TrimSuffix(t, "\n")
+	return prefix + strings.ReplaceAll(body, "\n", "\n"+prefix) + t[len(body):]
+}
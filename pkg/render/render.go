@@ -0,0 +1,179 @@
+// Package render turns a diff.Diff into text in one of several formats.
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"strcli/pkg/diff"
+)
+
+// Func renders a diff.
+type Func func(diff.Diff) (string, error)
+
+// formats build the renderer of each output format from the styles it is
+// colored in.
+var formats = map[string]func(Styles) Func{
+	"plain":     func(Styles) Func { return infallible(Plain) },
+	"color":     func(s Styles) Func { return infallible(s.Color) },
+	"json":      func(Styles) Func { return JSON },
+	"porcelain": func(Styles) Func { return infallible(Porcelain) },
+}
+
+// infallible turns a renderer that cannot fail into a Func.
+func infallible(r func(diff.Diff) string) Func {
+	return func(d diff.Diff) (string, error) { return r(d), nil }
+}
+
+// Lookup returns the renderer of the format called name, colored in s.
+func Lookup(name string, s Styles) (Func, bool) {
+	f, ok := formats[name]
+	if !ok {
+		return nil, false
+	}
+	return f(s), true
+}
+
+// Names returns the names of all formats, sorted.
+func Names() []string {
+	var names []string
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// markedLine is one line of the merged view of a diff, with inserted and
+// deleted runs wrapped in markers.
+type markedLine struct {
+	text    string
+	changed bool
+	// joined is set when the line break ending this line was itself part of
+	// a change, so the next line belongs to the same hunk.
+	joined bool
+}
+
+// markLines walks the changes of d and splits them into merged lines.
+// Inserted runs are rendered by ins and deleted runs by del.
+func markLines(d diff.Diff, ins, del func(string) string) []markedLine {
+	var lines []markedLine
+	var cur strings.Builder
+	changed := false
+	for _, c := range d.Changes {
+		mark := func(s string) string { return s }
+		switch c.Op {
+		case diff.Insert:
+			mark = ins
+		case diff.Delete:
+			mark = del
+		}
+		segs := strings.Split(Text(d, c), "\n")
+		for i, seg := range segs {
+			if i > 0 {
+				if c.Op != diff.Equal && segs[i-1] == "" {
+					// A changed line break with no changed text before it
+					// would be invisible.
+					cur.WriteString(mark("↵"))
+					changed = true
+				}
+				lines = append(lines, markedLine{text: cur.String(), changed: changed, joined: c.Op != diff.Equal})
+				cur.Reset()
+				changed = false
+			}
+			if seg == "" {
+				continue
+			}
+			cur.WriteString(mark(seg))
+			if c.Op != diff.Equal {
+				changed = true
+			}
+		}
+	}
+	if cur.Len() > 0 || changed {
+		lines = append(lines, markedLine{text: cur.String(), changed: changed})
+	}
+	return lines
+}
+
+// Text returns the text of c as it should be shown. A byte-level diff can
+// split UTF-8 sequences, so their bytes are shown as \xNN escapes.
+func Text(d diff.Diff, c diff.Change) string {
+	if d.Unit != diff.Byte || utf8.ValidString(c.Text) {
+		return c.Text
+	}
+	var b strings.Builder
+	for s := c.Text; s != ""; {
+		r, n := utf8.DecodeRuneInString(s)
+		if r == utf8.RuneError && n == 1 {
+			fmt.Fprintf(&b, "\\x%02x", s[0])
+		} else {
+			b.WriteString(s[:n])
+		}
+		s = s[n:]
+	}
+	return b.String()
+}
+
+// Plain renders d without color: every hunk gets a unified-style
+// header followed by its lines, with deletions wrapped in [-…-] and
+// insertions in {+…+}.
+func Plain(d diff.Diff) string {
+	lines := markLines(d,
+		func(s string) string { return "{+" + s + "+}" },
+		func(s string) string { return "[-" + s + "-]" },
+	)
+	var b strings.Builder
+	hunk := 0
+	inHunk := false
+	for _, l := range lines {
+		if !l.changed && !inHunk {
+			continue
+		}
+		if !inHunk && hunk < len(d.Hunks) {
+			h := d.Hunks[hunk]
+			fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@ %s", h.A.Start, h.A.Len(), h.B.Start, h.B.Len(), h.Kind)
+			if d.Unit == diff.Byte {
+				fmt.Fprintf(&b, " at byte -%d +%d", h.Changes[0].AOffset, h.Changes[0].BOffset)
+			}
+			b.WriteString("\n")
+			hunk++
+		}
+		b.WriteString(l.text)
+		b.WriteString("\n")
+		inHunk = l.joined
+	}
+	for _, n := range d.Notes {
+		fmt.Fprintf(&b, "\\ %s\n", n.Text)
+	}
+	return b.String()
+}
+
+// JSON renders the hunk model of d as indented JSON.
+func JSON(d diff.Diff) (string, error) {
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+// Porcelain renders a stable summary of d for scripts: one
+// tab-separated line per hunk with its kind and the line ranges in each
+// input, and one per note.
+//
+//	hunk	modified	2,1	2,1
+//	note	final-newline	No newline at end of B
+func Porcelain(d diff.Diff) string {
+	var b strings.Builder
+	for _, h := range d.Hunks {
+		fmt.Fprintf(&b, "hunk\t%s\t%d,%d\t%d,%d\n", h.Kind, h.A.Start, h.A.Len(), h.B.Start, h.B.Len())
+	}
+	for _, n := range d.Notes {
+		fmt.Fprintf(&b, "note\t%s\t%s\n", n.Kind, n.Text)
+	}
+	return b.String()
+}
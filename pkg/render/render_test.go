@@ -0,0 +1,160 @@
+package render
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"strcli/pkg/diff"
+)
+
+func TestPlain(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		unit diff.Unit
+		want string
+	}{
+		{"identical", "a\nb\n", "a\nb\n", diff.Grapheme, ""},
+		{"within a line", "one\ntwo\nthree\n", "one\n2\nthree\n", diff.Grapheme, "@@ -2,1 +2,1 @@ modified\n[-two-]{+2+}\n"},
+		{"two hunks", "x\ny\n", "X\ny\nz\n", diff.Grapheme, "@@ -1,1 +1,1 @@ modified\n[-x-]{+X+}\n@@ -3,0 +3,1 @@ added\n{+z+}\n"},
+		{"only a line break", "a\n", "a", diff.Grapheme, "@@ -1,1 +1,1 @@ removed\na[-↵-]\n\\ only whitespace differs\n\\ No newline at end of B\n"},
+		{"blank lines", "a\n", "a\n\n\n", diff.Grapheme, "@@ -2,0 +2,2 @@ added\n{+↵+}\n{+↵+}\n\\ only whitespace differs\n\\ A ends with 0 blank lines, B with 2 blank lines\n"},
+		{"bytes", "x\u00e9", "x\u00e8", diff.Byte, "@@ -1,1 +1,1 @@ modified at byte -2 +2\nx\\xc3[-\\xa9-]{+\\xa8+}\n"},
+		{"whole bytes", "ab", "aB", diff.Byte, "@@ -1,1 +1,1 @@ modified at byte -1 +1\na[-b-]{+B+}\n\\ only letter case differs\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Plain(diff.ComputeUnit(tt.a, tt.b, tt.unit)); got != tt.want {
+				t.Errorf("got\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var got struct {
+		Hunks []struct {
+			Kind string
+			A, B struct{ Start, End int }
+		}
+		Changes []struct {
+			Op    string
+			Hint  []string
+			ALine int `json:"a_line"`
+		}
+	}
+	out, err := JSON(diff.Compute("a\nb\n", "a\nb \n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Hunks) != 1 || got.Hunks[0].Kind != "added" || got.Hunks[0].A.Start != 2 {
+		t.Errorf("hunks = %+v", got.Hunks)
+	}
+	var ops []string
+	for _, c := range got.Changes {
+		ops = append(ops, c.Op)
+		if c.Op == "insert" && (len(c.Hint) != 1 || c.Hint[0] != "whitespace" || c.ALine != 2) {
+			t.Errorf("insert = %+v, want a whitespace hint on line 2", c)
+		}
+	}
+	if want := []string{"equal", "insert", "equal"}; !reflect.DeepEqual(ops, want) {
+		t.Errorf("ops = %q, want %q", ops, want)
+	}
+}
+
+func TestPorcelain(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"identical", "a\n", "a\n", ""},
+		{"two hunks", "x\ny\n", "X\ny\nz\n", "hunk\tmodified\t1,1\t1,1\nhunk\tadded\t3,0\t3,1\n"},
+		{"note", "a\n", "a", "hunk\tremoved\t1,1\t1,1\nnote\tprobable-cause\tonly whitespace differs\nnote\tfinal-newline\tNo newline at end of B\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Porcelain(diff.Compute(tt.a, tt.b)); got != tt.want {
+				t.Errorf("got\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColor(t *testing.T) {
+	d := diff.Compute("ab\n", "ac\n")
+	got := DefaultStyles().Color(d)
+	for _, want := range []string{"a\n", "b\n", "c\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("%q has no %q", got, want)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, name := range Names() {
+		if _, ok := Lookup(name, DefaultStyles()); !ok {
+			t.Errorf("Lookup(%q) failed", name)
+		}
+	}
+	if want := []string{"color", "json", "plain", "porcelain"}; !reflect.DeepEqual(Names(), want) {
+		t.Errorf("Names() = %q, want %q", Names(), want)
+	}
+	if _, ok := Lookup("xml", DefaultStyles()); ok {
+		t.Error("Lookup found xml")
+	}
+}
+
+func TestLookupStyles(t *testing.T) {
+	d := diff.Compute("ab\n", "ac\n")
+	marked, _ := Lookup("color", Styles{Markers: true})
+	unmarked, _ := Lookup("color", Styles{})
+	if got, _ := marked(d); !strings.Contains(got, "+c") {
+		t.Errorf("with markers: %q", got)
+	}
+	if got, _ := unmarked(d); strings.Contains(got, "+c") {
+		t.Errorf("markers of another renderer leak into %q", got)
+	}
+}
+
+func TestMarkers(t *testing.T) {
+	s := DefaultStyles()
+	s.Markers = true
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"within a line", "ab\n", "ac\n", " a\n-b\n+c\n \n\n"},
+		{"lines", "x\ny\nz\n", "x\nz\n", " x\n\n-y\n  \n z\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Color(diff.Compute(tt.a, tt.b)); got != tt.want {
+				t.Errorf("got\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMark(t *testing.T) {
+	tests := []struct{ s, want string }{
+		{"a", "+a"},
+		{"a\nb", "+a\n+b"},
+		{"a\n", "+a\n"},
+		{"a\n\n", "+a\n+\n"},
+	}
+	for _, tt := range tests {
+		if got := (Styles{}).mark("+", tt.s); got != tt.s {
+			t.Errorf("mark(%q) without markers = %q", tt.s, got)
+		}
+		if got := (Styles{Markers: true}).mark("+", tt.s); got != tt.want {
+			t.Errorf("mark(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"strcli/pkg/diff"
+)
+
+// invisibleNames are the short names shown for characters that take no
+// space or look like a plain space.
+var invisibleNames = map[rune]string{
+	'\u00A0': "NBSP",
+	'\u00AD': "SHY",
+	'\u200B': "ZWSP",
+	'\u200C': "ZWNJ",
+	'\u200D': "ZWJ",
+	'\u200E': "LRM",
+	'\u200F': "RLM",
+	'\u2007': "FIGSP",
+	'\u202F': "NNBSP",
+	'\u2060': "WJ",
+	'\uFEFF': "BOM",
+}
+
+// Visible shows the whitespace and invisible characters of t: spaces as ·,
+// tabs as →, carriage returns as ␍ and characters such as zero-width
+// spaces by name, e.g. ⟨ZWSP⟩. Whitespace at the end of a line is
+// highlighted. Line feeds are kept.
+func (s Styles) Visible(t string) string {
+	lines := strings.Split(t, "\n")
+	for i, l := range lines {
+		body := strings.TrimRightFunc(l, unicode.IsSpace)
+		lines[i] = s.visibleRun(body, s.Glyph) + s.trailingVisible(l[len(body):])
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s Styles) trailingVisible(t string) string {
+	if t == "" {
+		return ""
+	}
+	return s.Trailing.Render(s.visibleRun(t, lipgloss.NewStyle()))
+}
+
+// visibleRun replaces the whitespace of t with glyphs drawn in style, and
+// invisible characters with their names.
+func (s Styles) visibleRun(t string, style lipgloss.Style) string {
+	var b strings.Builder
+	for _, r := range t {
+		switch name, ok := invisibleNames[r]; {
+		case r == ' ':
+			b.WriteString(style.Render("·"))
+		case r == '\t':
+			b.WriteString(style.Render("→"))
+		case r == '\r':
+			b.WriteString(style.Render("␍"))
+		case ok:
+			b.WriteString(s.Invisible.Render("⟨" + name + "⟩"))
+		case unicode.Is(unicode.Cf, r) || unicode.IsControl(r) && r != '\n' || unicode.IsSpace(r) && r != '\n':
+			b.WriteString(s.Invisible.Render(fmt.Sprintf("⟨U+%04X⟩", r)))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ColorVisible renders d like Color, but with whitespace and invisible
+// characters made visible.
+func (s Styles) ColorVisible(d diff.Diff) string {
+	return s.color(d, func(t string) string { return s.visibleRun(t, s.Glyph) })
+}
@@ -0,0 +1,35 @@
+package render
+
+import (
+	"testing"
+
+	"strcli/pkg/diff"
+)
+
+func TestVisible(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"spaces and tabs", "a b\tc", "a·b→c"},
+		{"trailing whitespace", "a \t\nb", "a·→\nb"},
+		{"carriage return", "a\r\nb", "a␍\nb"},
+		{"named", "a\u00a0b\u200bc\ufeff", "a⟨NBSP⟩b⟨ZWSP⟩c⟨BOM⟩"},
+		{"unnamed", "a\u2066b\x07", "a⟨U+2066⟩b⟨U+0007⟩"},
+		{"other spaces", "a\u3000b", "a⟨U+3000⟩b"},
+		{"plain", "h\u00e9llo\n", "h\u00e9llo\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultStyles().Visible(tt.in); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorVisible(t *testing.T) {
+	got := DefaultStyles().ColorVisible(diff.Compute("a b\n", "a b\n"))
+	if want := "a\n·\n⟨NBSP⟩\nb\n\n⚠ only whitespace differs\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,185 @@
+// Package theme defines the named color schemes of the TUI.
+package theme
+
+import "github.com/charmbracelet/lipgloss"
+
+// A Theme is a set of colors for every part of the TUI.
+type Theme struct {
+	Name string
+	// Insert and Delete color inserted and deleted text, Note warnings
+	// about a diff and invisible characters, and Error error messages.
+	Insert, Delete, Note, Error lipgloss.Color
+	// Accent colors the cursor, titles and selected items, Subtle borders
+	// of overlays, tips and labels, Border the border of the focused pane
+	// and placeholders, Faint the end of a pane's buffer and Glyph the
+	// glyphs standing in for whitespace.
+	Accent, Subtle, Border, Faint, Glyph lipgloss.Color
+	// CursorLine is the background of the line under the cursor and
+	// CursorLineText its text.
+	CursorLine, CursorLineText lipgloss.Color
+	// Trailing is the background of trailing whitespace.
+	Trailing lipgloss.Color
+	// Match is the background of regular expression matches, MatchText
+	// their text and Groups that of their capture groups.
+	Match, MatchText lipgloss.Color
+	Groups           [4]lipgloss.Color
+	// Foreground and Background are the terminal's text and background
+	// colors, used where text is drawn on one of the colors above.
+	Foreground, Background lipgloss.Color
+	// Plain themes use no colors at all: inserted text is underlined and
+	// deleted text struck through instead.
+	Plain bool
+	// Light, if set, is the theme to use instead on a light background.
+	Light *Theme
+}
+
+// ForBackground returns the variant of t for a dark or a light background.
+func (t Theme) ForBackground(dark bool) Theme {
+	if dark || t.Light == nil {
+		return t
+	}
+	return *t.Light
+}
+
+// Default is the theme used unless another is chosen.
+var Default = Theme{
+	Name:           "default",
+	Insert:         "#00FF00",
+	Delete:         "#FF0000",
+	Note:           "#FFAF00",
+	Error:          "#FF0000",
+	Accent:         "212",
+	Subtle:         "99",
+	Border:         "238",
+	Faint:          "235",
+	Glyph:          "244",
+	CursorLine:     "57",
+	CursorLineText: "230",
+	Trailing:       "52",
+	Match:          "24",
+	MatchText:      "15",
+	Groups:         [4]lipgloss.Color{"214", "120", "212", "81"},
+	Foreground:     "15",
+	Background:     "0",
+	Light: &Theme{
+		Name:           "default",
+		Insert:         "#008700",
+		Delete:         "#D70000",
+		Note:           "#AF5F00",
+		Error:          "#D70000",
+		Accent:         "162",
+		Subtle:         "61",
+		Border:         "248",
+		Faint:          "252",
+		Glyph:          "246",
+		CursorLine:     "189",
+		CursorLineText: "16",
+		Trailing:       "224",
+		Match:          "153",
+		MatchText:      "16",
+		Groups:         [4]lipgloss.Color{"130", "28", "162", "25"},
+		Foreground:     "16",
+		Background:     "231",
+	},
+}
+
+// Themes are all the themes, in the order they are cycled through.
+var Themes = []Theme{
+	Default,
+	{
+		Name:           "dracula",
+		Insert:         "#50FA7B",
+		Delete:         "#FF5555",
+		Note:           "#FFB86C",
+		Error:          "#FF5555",
+		Accent:         "#FF79C6",
+		Subtle:         "#BD93F9",
+		Border:         "#6272A4",
+		Faint:          "#44475A",
+		Glyph:          "#6272A4",
+		CursorLine:     "#44475A",
+		CursorLineText: "#F8F8F2",
+		Trailing:       "#5C2E3A",
+		Match:          "#44475A",
+		MatchText:      "#F8F8F2",
+		Groups:         [4]lipgloss.Color{"#FFB86C", "#50FA7B", "#FF79C6", "#8BE9FD"},
+		Foreground:     "#F8F8F2",
+		Background:     "#282A36",
+	},
+	{
+		Name:           "solarized-dark",
+		Insert:         "#859900",
+		Delete:         "#DC322F",
+		Note:           "#B58900",
+		Error:          "#DC322F",
+		Accent:         "#D33682",
+		Subtle:         "#6C71C4",
+		Border:         "#586E75",
+		Faint:          "#073642",
+		Glyph:          "#586E75",
+		CursorLine:     "#073642",
+		CursorLineText: "#EEE8D5",
+		Trailing:       "#4A1E1D",
+		Match:          "#268BD2",
+		MatchText:      "#FDF6E3",
+		Groups:         [4]lipgloss.Color{"#CB4B16", "#859900", "#D33682", "#2AA198"},
+		Foreground:     "#FDF6E3",
+		Background:     "#002B36",
+	},
+	{
+		Name:           "solarized-light",
+		Insert:         "#859900",
+		Delete:         "#DC322F",
+		Note:           "#CB4B16",
+		Error:          "#DC322F",
+		Accent:         "#D33682",
+		Subtle:         "#6C71C4",
+		Border:         "#93A1A1",
+		Faint:          "#EEE8D5",
+		Glyph:          "#93A1A1",
+		CursorLine:     "#EEE8D5",
+		CursorLineText: "#073642",
+		Trailing:       "#F5D5CF",
+		Match:          "#268BD2",
+		MatchText:      "#FDF6E3",
+		Groups:         [4]lipgloss.Color{"#CB4B16", "#859900", "#D33682", "#2AA198"},
+		Foreground:     "#073642",
+		Background:     "#FDF6E3",
+	},
+	Monochrome,
+}
+
+// Monochrome is the theme without colors, used when they are turned off.
+var Monochrome = Theme{
+	Name:  "monochrome",
+	Plain: true,
+}
+
+// Lookup returns the theme called name.
+func Lookup(name string) (Theme, bool) {
+	for _, t := range Themes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
+
+// Names returns the names of all themes.
+func Names() []string {
+	names := make([]string, len(Themes))
+	for i, t := range Themes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// Next returns the theme after t, wrapping around to the first.
+func Next(t Theme) Theme {
+	for i, u := range Themes {
+		if u.Name == t.Name {
+			return Themes[(i+1)%len(Themes)]
+		}
+	}
+	return Default
+}
@@ -0,0 +1,66 @@
+package theme
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLookup(t *testing.T) {
+	for _, name := range Names() {
+		th, ok := Lookup(name)
+		if !ok || th.Name != name {
+			t.Errorf("Lookup(%q) = %q, %v", name, th.Name, ok)
+		}
+		if !th.Plain && (th.Insert == "" || th.Delete == "" || th.Foreground == "" || th.Background == "") {
+			t.Errorf("theme %q lacks colors", name)
+		}
+	}
+	if _, ok := Lookup("neon"); ok {
+		t.Error("found theme neon")
+	}
+}
+
+func TestNames(t *testing.T) {
+	want := []string{"default", "dracula", "solarized-dark", "solarized-light", "monochrome"}
+	if got := Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct{ from, want string }{
+		{"default", "dracula"},
+		{"solarized-light", "monochrome"},
+		{"monochrome", "default"},
+		{"unknown", "default"},
+	}
+	for _, tt := range tests {
+		if got := Next(Theme{Name: tt.from}).Name; got != tt.want {
+			t.Errorf("Next(%q) = %q, want %q", tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestForBackground(t *testing.T) {
+	dracula, _ := Lookup("dracula")
+	tests := []struct {
+		name string
+		t    Theme
+		dark bool
+		want lipgloss.Color
+	}{
+		{"default on dark", Default, true, Default.Background},
+		{"default on light", Default, false, Default.Light.Background},
+		{"without a light variant", dracula, false, dracula.Background},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.t.ForBackground(tt.dark)
+			if got.Background != tt.want || got.Name != tt.t.Name {
+				t.Errorf("got %q with background %q, want %q", got.Name, got.Background, tt.want)
+			}
+		})
+	}
+}
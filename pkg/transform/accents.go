@@ -0,0 +1,39 @@
+package transform
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	xtransform "golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "strip-accents",
+		Description: "Remove accents and diacritics (é to e, ü to u)",
+		Apply:       simple(StripAccents),
+	})
+}
+
+// letters maps letters that carry a diacritic but have no decomposition to
+// their plain Latin form.
+var letters = strings.NewReplacer(
+	"Ø", "O", "ø", "o",
+	"Ł", "L", "ł", "l",
+	"Đ", "D", "đ", "d",
+	"Ħ", "H", "ħ", "h",
+	"ı", "i",
+)
+
+// StripAccents removes the combining marks from s, so that "Müller" and
+// "Muller" compare equal.
+func StripAccents(s string) string {
+	t := xtransform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	out, _, err := xtransform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return letters.Replace(out)
+}
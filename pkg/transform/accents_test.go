@@ -0,0 +1,14 @@
+package transform
+
+import "testing"
+
+func TestStripAccents(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"strip-accents", "", "Müller café", "Muller cafe"},
+		{"strip-accents", "", "Crème Brûlée", "Creme Brulee"},
+		{"strip-accents", "", "Łódź, Øresund, Đakovo", "Lodz, Oresund, Dakovo"},
+		{"strip-accents", "", "ı", "i"},
+		{"strip-accents", "", "plain ascii\n", "plain ascii\n"},
+		{"strip-accents", "", "日本語", "日本語"},
+	})
+}
@@ -0,0 +1,96 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	for _, b := range []struct {
+		name string
+		base int
+	}{
+		{"binary", 2},
+		{"octal", 8},
+		{"decimal", 10},
+		{"hex", 16},
+	} {
+		b := b
+		Register(Transform{
+			Name:        "to-" + b.name,
+			Description: fmt.Sprintf("Write every number in the whole text as %s; 0x, 0o and 0b prefixes mark numbers in other bases", b.name),
+			Apply:       func(in, _ string) (string, error) { return convertNumbers(in, b.base) },
+		})
+	}
+	Register(Transform{
+		Name:        "base-convert",
+		Description: "Convert every number without prefix in the whole text from one base to another, e.g. 16:10",
+		Arg:         "from:to",
+		Apply:       baseConvert,
+	})
+}
+
+// tokenPattern matches runs of letters, digits, underscores and dots. Only
+// runs that are a number as a whole are converted, so that versions such as
+// v1.2.3 and names such as x2 are left alone.
+var tokenPattern = regexp.MustCompile(`[0-9A-Za-z_.]+`)
+
+// numberPattern matches integer literals as written in Go and most other
+// languages.
+var numberPattern = regexp.MustCompile(`^(?:0[xX][0-9a-fA-F_]+|0[oO][0-7_]+|0[bB][01_]+|\d+)$`)
+
+// basePrefixes are written before numbers in each base but decimal.
+var basePrefixes = map[int]string{2: "0b", 8: "0o", 16: "0x"}
+
+// convertNumbers writes every integer in s in base, with its prefix. It
+// fails if s has none.
+func convertNumbers(s string, base int) (string, error) {
+	found := false
+	out := tokenPattern.ReplaceAllStringFunc(s, func(m string) string {
+		if !numberPattern.MatchString(m) {
+			return m
+		}
+		// Base 0 reads the prefixes, but would also read a leading zero
+		// as octal.
+		base0 := 10
+		if len(m) > 2 && (m[1] < '0' || m[1] > '9') {
+			base0 = 0
+		}
+		n, ok := new(big.Int).SetString(m, base0)
+		if !ok {
+			return m
+		}
+		found = true
+		return basePrefixes[base] + n.Text(base)
+	})
+	if !found {
+		return "", errors.New("no numbers to convert")
+	}
+	return out, nil
+}
+
+func baseConvert(in, arg string) (string, error) {
+	fromStr, toStr, _ := strings.Cut(arg, ":")
+	from, err1 := strconv.Atoi(strings.TrimSpace(fromStr))
+	to, err2 := strconv.Atoi(strings.TrimSpace(toStr))
+	if err1 != nil || err2 != nil || from < 2 || from > 36 || to < 2 || to > 36 {
+		return "", fmt.Errorf("invalid bases %q; use from:to with bases from 2 to 36, e.g. 16:10", arg)
+	}
+	found := false
+	out := tokenPattern.ReplaceAllStringFunc(in, func(m string) string {
+		n, ok := new(big.Int).SetString(m, from)
+		if !ok {
+			return m
+		}
+		found = true
+		return n.Text(to)
+	})
+	if !found {
+		return "", fmt.Errorf("no base %d numbers to convert", from)
+	}
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package transform
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "base64-encode",
+		Description: "Encode as standard base64",
+		Apply:       simple(base64Encoder(base64.StdEncoding)),
+	})
+	Register(Transform{
+		Name:        "base64-decode",
+		Description: "Decode standard base64",
+		Apply:       base64Decoder(base64.StdEncoding),
+	})
+	Register(Transform{
+		Name:        "base64url-encode",
+		Description: "Encode as URL-safe base64",
+		Apply:       simple(base64Encoder(base64.URLEncoding)),
+	})
+	Register(Transform{
+		Name:        "base64url-decode",
+		Description: "Decode URL-safe base64",
+		Apply:       base64Decoder(base64.URLEncoding),
+	})
+}
+
+func base64Encoder(enc *base64.Encoding) func(string) string {
+	return func(in string) string {
+		return enc.EncodeToString([]byte(in))
+	}
+}
+
+// base64Decoder decodes base64 that may be wrapped over several lines and
+// may lack its padding. The decoded bytes must be UTF-8 text.
+func base64Decoder(enc *base64.Encoding) func(string, string) (string, error) {
+	return func(in, _ string) (string, error) {
+		in = strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, in)
+		if in == "" {
+			return "", errors.New("no base64 to decode")
+		}
+		dec := enc
+		if !strings.HasSuffix(in, "=") {
+			if len(in)%4 == 1 {
+				return "", errors.New("invalid base64: input is truncated")
+			}
+			dec = enc.WithPadding(base64.NoPadding)
+		}
+		b, err := dec.DecodeString(in)
+		var corrupt base64.CorruptInputError
+		if errors.As(err, &corrupt) && int(corrupt) >= len(in) {
+			return "", errors.New("invalid base64: input is truncated")
+		}
+		if errors.As(err, &corrupt) {
+			r, _ := utf8.DecodeRuneInString(in[corrupt:])
+			return "", fmt.Errorf("invalid base64: unexpected %q at offset %d", r, corrupt)
+		}
+		if err != nil {
+			return "", err
+		}
+		return asText(b)
+	}
+}
@@ -0,0 +1,47 @@
+package transform
+
+import "testing"
+
+func TestBase64(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"base64-encode", "", "héllo, wörld\n", "aMOpbGxvLCB3w7ZybGQK"},
+		{"base64-decode", "", "aMOpbGxvLCB3w7ZybGQK", "héllo, wörld\n"},
+		{"base64-decode", "", "aGVs\nbG8=\n", "hello"},
+		{"base64-decode", "", "aGVsbG8", "hello"},
+		{"base64url-encode", "", "a?b>c~", "YT9iPmN-"},
+		{"base64url-decode", "", "YT9iPmN-", "a?b>c~"},
+		{"base64url-decode", "", "Pz8-Pw", "??>?"},
+	})
+}
+
+func TestBase64Errors(t *testing.T) {
+	testErrors(t, []errorTest{
+		{"base64-decode", "", "", "no base64"},
+		{"base64-decode", "", " \n", "no base64"},
+		{"base64-decode", "", "aGVsb", "truncated"},
+		{"base64-decode", "", "aGV$bG8=", "unexpected '$'"},
+		{"base64-decode", "", "YT9iPmN-", "unexpected '-'"},
+		{"base64-decode", "", "/w==", "binary"},
+		{"base64-decode", "", "aGVsbG8==", "invalid base64"},
+		{"base64-decode", "", "aGVsbA=", "truncated"},
+	})
+}
+
+func TestBase64PaddingEachTime(t *testing.T) {
+	// Each input is decoded on its own terms: leaving out the padding of
+	// one must not make the padding of the next invalid.
+	inputs := []struct{ in, want string }{
+		{"aGVsbG8=", "hello"},
+		{"aGVsbG8", "hello"},
+		{"aGVsbG8=", "hello"},
+		{"aGk", "hi"},
+		{"aGk=", "hi"},
+	}
+	for _, name := range []string{"base64-decode", "base64url-decode"} {
+		for _, tt := range inputs {
+			if got, err := apply(t, name, tt.in, ""); got != tt.want || err != nil {
+				t.Errorf("%s %q = %q, %v, want %q", name, tt.in, got, err, tt.want)
+			}
+		}
+	}
+}
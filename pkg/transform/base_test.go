@@ -0,0 +1,32 @@
+package transform
+
+import "testing"
+
+func TestBaseConversions(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"to-hex", "", "255 and 16", "0xff and 0x10"},
+		{"to-binary", "", "5, 0x0F", "0b101, 0b1111"},
+		{"to-octal", "", "[8, 64]", "[0o10, 0o100]"},
+		{"to-decimal", "", "0xff 0b101 0o17 0X1_0", "255 5 15 16"},
+		{"to-decimal", "", "010", "10"},
+		{"to-hex", "", "pi is 3.14, not 3", "pi is 3.14, not 0x3"},
+		{"to-hex", "", "v1.2.3 of x2 in 1.21 has 10", "v1.2.3 of x2 in 1.21 has 0xa"},
+		{"to-hex", "", "18446744073709551616", "0x10000000000000000"},
+		{"base-convert", "16:10", "ff FF 10", "255 255 16"},
+		{"base-convert", " 10 : 2 ", "6 is 3+3", "110 is 11+11"},
+		{"base-convert", "36:10", "zz", "1295"},
+		{"base-convert", "2:10", "101 and 102", "5 and 102"},
+	})
+}
+
+func TestBaseConvertErrors(t *testing.T) {
+	testErrors(t, []errorTest{
+		{"base-convert", "16", "ff", "invalid bases"},
+		{"base-convert", "1:10", "ff", "invalid bases"},
+		{"base-convert", "16:37", "ff", "invalid bases"},
+		{"base-convert", "hex:dec", "ff", "use from:to with bases from 2 to 36"},
+		{"base-convert", "2:10", "version 1.0.1", "no base 2 numbers"},
+		{"to-hex", "", "pi is 3.14", "no numbers"},
+		{"to-decimal", "", "", "no numbers"},
+	})
+}
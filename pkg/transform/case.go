@@ -0,0 +1,194 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "upper",
+		Description: "Convert to UPPER CASE",
+		Apply:       simple(cases.Upper(language.Und).String),
+	})
+	Register(Transform{
+		Name:        "lower",
+		Description: "Convert to lower case",
+		Apply:       simple(cases.Lower(language.Und).String),
+	})
+	Register(Transform{
+		Name:        "upper-lang",
+		Description: "Convert to UPPER CASE using the rules of a language, e.g. tr",
+		Arg:         "language tag",
+		Apply:       withLanguage(cases.Upper),
+	})
+	Register(Transform{
+		Name:        "lower-lang",
+		Description: "Convert to lower case using the rules of a language, e.g. tr",
+		Arg:         "language tag",
+		Apply:       withLanguage(cases.Lower),
+	})
+	Register(Transform{
+		Name:        "title-lang",
+		Description: "Convert to Title Case using the rules of a language, e.g. nl",
+		Arg:         "language tag",
+		Apply:       withLanguage(cases.Title),
+	})
+	Register(Transform{
+		Name:        "fold",
+		Description: "Case fold for caseless comparison (ß and ss, K and k match)",
+		Apply:       simple(Fold),
+	})
+	Register(Transform{
+		Name:        "camel",
+		Description: "Convert each line to camelCase",
+		Apply:       simple(perLine(words("", camelWord))),
+	})
+	Register(Transform{
+		Name:        "pascal",
+		Description: "Convert each line to PascalCase",
+		Apply:       simple(perLine(words("", pascalWord))),
+	})
+	Register(Transform{
+		Name:        "snake",
+		Description: "Convert each line to snake_case",
+		Apply:       simple(perLine(words("_", lowerWord))),
+	})
+	Register(Transform{
+		Name:        "screaming-snake",
+		Description: "Convert each line to SCREAMING_SNAKE_CASE",
+		Apply:       simple(perLine(words("_", upperWord))),
+	})
+	Register(Transform{
+		Name:        "kebab",
+		Description: "Convert each line to kebab-case",
+		Apply:       simple(perLine(words("-", lowerWord))),
+	})
+	Register(Transform{
+		Name:        "title",
+		Description: "Convert to Title Case",
+		Apply:       simple(cases.Title(language.Und).String),
+	})
+	Register(Transform{
+		Name:        "sentence",
+		Description: "Convert to Sentence case",
+		Apply:       simple(sentenceCase),
+	})
+}
+
+// Fold case folds s, so that texts that differ only in case become equal.
+// Unlike lower-casing, folding maps ß to ss and treats the Kelvin sign like k.
+func Fold(s string) string {
+	return cases.Fold().String(s)
+}
+
+// withLanguage adapts a caser constructor to take a BCP 47 language tag as
+// its argument.
+func withLanguage(newCaser func(language.Tag, ...cases.Option) cases.Caser) func(string, string) (string, error) {
+	return func(in, arg string) (string, error) {
+		tag, err := language.Parse(arg)
+		if err != nil {
+			return "", fmt.Errorf("invalid language tag %q", arg)
+		}
+		return newCaser(tag).String(in), nil
+	}
+}
+
+// perLine applies fn to every line of a text.
+func perLine(fn func(string) string) func(string) string {
+	return func(s string) string {
+		lines := strings.Split(s, "\n")
+		for i, line := range lines {
+			lines[i] = fn(line)
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+// splitWords splits an identifier or phrase into words. Words are separated
+// by anything but letters and digits, and by changes of case, so
+// "parseHTTPRequest" and "parse http request" both give parse, HTTP/http,
+// Request/request.
+func splitWords(s string) []string {
+	var words []string
+	rs := []rune(s)
+	start := -1
+	for i, r := range rs {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(rs[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		prev := rs[i-1]
+		upperAfterLower := unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev))
+		acronymEnd := unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(rs) && unicode.IsLower(rs[i+1])
+		if upperAfterLower || acronymEnd {
+			words = append(words, string(rs[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(rs[start:]))
+	}
+	return words
+}
+
+// words returns a function that splits its input into words, converts
+// each with conv and joins them with sep.
+func words(sep string, conv func(i int, w string) string) func(string) string {
+	return func(s string) string {
+		ws := splitWords(s)
+		for i, w := range ws {
+			ws[i] = conv(i, w)
+		}
+		return strings.Join(ws, sep)
+	}
+}
+
+func camelWord(i int, w string) string {
+	if i == 0 {
+		return lowerWord(i, w)
+	}
+	return capitalize(w)
+}
+
+func pascalWord(_ int, w string) string { return capitalize(w) }
+func lowerWord(_ int, w string) string  { return cases.Lower(language.Und).String(w) }
+func upperWord(_ int, w string) string  { return cases.Upper(language.Und).String(w) }
+
+// capitalize upper-cases the first letter of w and lower-cases the rest.
+func capitalize(w string) string {
+	return cases.Title(language.Und).String(w)
+}
+
+// sentenceCase lower-cases everything except the first letter of each
+// sentence. A sentence starts a line or follows ., ! or ? and whitespace.
+func sentenceCase(s string) string {
+	rs := []rune(s)
+	start := true
+	for i, r := range rs {
+		switch {
+		case unicode.IsLetter(r) && start:
+			rs[i] = unicode.ToTitle(r)
+			start = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			rs[i] = unicode.ToLower(r)
+			start = false
+		case r == '\n':
+			start = true
+		case r == '.' || r == '!' || r == '?':
+			start = i+1 == len(rs) || unicode.IsSpace(rs[i+1])
+		}
+	}
+	return string(rs)
+}
@@ -0,0 +1,56 @@
+package transform
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCase(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"upper", "", "Hello, wörld", "HELLO, WÖRLD"},
+		{"lower", "", "Hello, WÖRLD", "hello, wörld"},
+		{"upper", "", "", ""},
+		{"camel", "", "parse HTTP request\nuser_id", "parseHttpRequest\nuserId"},
+		{"pascal", "", "parseHTTPRequest", "ParseHttpRequest"},
+		{"snake", "", "parseHTTPRequest\nsome-kebab-case", "parse_http_request\nsome_kebab_case"},
+		{"screaming-snake", "", "maxRetryCount", "MAX_RETRY_COUNT"},
+		{"kebab", "", "Some Title v2Beta", "some-title-v2-beta"},
+		{"title", "", "the QUICK brown fox's tail, once", "The Quick Brown Fox's Tail, Once"},
+		{"sentence", "", "HELLO THERE. how are you?i am fine\nnew LINE", "Hello there. How are you?i am fine\nNew line"},
+		{"sentence", "", "v1.2 is OUT", "V1.2 is out"},
+		{"upper", "", "straße", "STRASSE"},
+		{"upper-lang", "tr", "istanbul", "İSTANBUL"},
+		{"lower-lang", "tr", "ISTANBUL", "ıstanbul"},
+		{"lower-lang", "en", "ISTANBUL", "istanbul"},
+		{"title-lang", "nl", "ijsland", "IJsland"},
+		{"fold", "", "Straße \u212A", "strasse k"},
+	})
+}
+
+func TestCaseLanguageErrors(t *testing.T) {
+	for _, name := range []string{"upper-lang", "lower-lang", "title-lang"} {
+		if _, err := apply(t, name, "x", "not a tag!"); err == nil {
+			t.Errorf("%s accepted an invalid language tag", name)
+		}
+	}
+}
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"parseHTTPRequest", []string{"parse", "HTTP", "Request"}},
+		{"parse http request", []string{"parse", "http", "request"}},
+		{"user_id2Name", []string{"user", "id2", "Name"}},
+		{"--", nil},
+		{"ÉtéChaud", []string{"Été", "Chaud"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := splitWords(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "rot13",
+		Description: "Rotate letters by 13 places; applying it twice gives the text back",
+		Apply:       simple(func(s string) string { return caesar(s, 13) }),
+	})
+	Register(Transform{
+		Name:        "caesar-encode",
+		Description: "Shift letters forward through the alphabet, e.g. 3 turns abc into def",
+		Arg:         "shift",
+		Apply:       caesarShift(1),
+	})
+	Register(Transform{
+		Name:        "caesar-decode",
+		Description: "Shift letters back through the alphabet, undoing caesar-encode",
+		Arg:         "shift",
+		Apply:       caesarShift(-1),
+	})
+}
+
+func caesarShift(sign int) func(string, string) (string, error) {
+	return func(in, arg string) (string, error) {
+		n, err := strconv.Atoi(strings.TrimSpace(arg))
+		if err != nil {
+			return "", fmt.Errorf("invalid shift %q", arg)
+		}
+		return caesar(in, sign*n), nil
+	}
+}
+
+// caesar shifts the ASCII letters of s by n places, wrapping around the
+// alphabet and keeping their case. Other characters are kept as they are.
+func caesar(s string, n int) string {
+	n = (n%26 + 26) % 26
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return 'a' + (r-'a'+rune(n))%26
+		case r >= 'A' && r <= 'Z':
+			return 'A' + (r-'A'+rune(n))%26
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,23 @@
+package transform
+
+import "testing"
+
+func TestCipher(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"rot13", "", "Hello, World!", "Uryyb, Jbeyq!"},
+		{"rot13", "", "Uryyb, Jbeyq!", "Hello, World!"},
+		{"rot13", "", "ÄÖÜ 123", "ÄÖÜ 123"},
+		{"caesar-encode", "3", "abc XYZ", "def ABC"},
+		{"caesar-encode", " 29 ", "abc", "def"},
+		{"caesar-encode", "-1", "abc", "zab"},
+		{"caesar-decode", "3", "def ABC", "abc XYZ"},
+		{"caesar-decode", "26", "Same", "Same"},
+	})
+}
+
+func TestCipherErrors(t *testing.T) {
+	testErrors(t, []errorTest{
+		{"caesar-encode", "", "abc", "invalid shift"},
+		{"caesar-decode", "three", "abc", "invalid shift"},
+	})
+}
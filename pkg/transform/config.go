@@ -0,0 +1,236 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// configFormat is a data format that config files are written in.
+type configFormat struct {
+	name   string
+	decode func(string) (any, error)
+	encode func(any) (string, error)
+}
+
+var configFormats = []configFormat{
+	{name: "json", decode: decodeJSON, encode: encodeJSON},
+	{name: "yaml", decode: decodeYAML, encode: encodeYAML},
+	{name: "toml", decode: decodeTOML, encode: encodeTOML},
+}
+
+func init() {
+	for _, from := range configFormats {
+		for _, to := range configFormats {
+			if from.name == to.name {
+				continue
+			}
+			from, to := from, to
+			Register(Transform{
+				Name:        from.name + "-to-" + to.name,
+				Description: fmt.Sprintf("Convert %s to %s, sorting keys", strings.ToUpper(from.name), strings.ToUpper(to.name)),
+				Apply: func(in, _ string) (string, error) {
+					v, err := from.decode(in)
+					if err != nil {
+						return "", err
+					}
+					return to.encode(normalizeValue(v))
+				},
+			})
+		}
+	}
+}
+
+func decodeJSON(in string) (any, error) {
+	// Decoded trimmed, as jsonError expects offsets from the first
+	// non-space byte.
+	trimmed := strings.TrimSpace(in)
+	dec := json.NewDecoder(strings.NewReader(trimmed))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, jsonError(in, err)
+	}
+	end := int(dec.InputOffset())
+	if err := dec.Decode(new(any)); err == nil {
+		rest := trimmed[end:]
+		off := len(in) - len(strings.TrimLeft(in, " \t\r\n")) + end + len(rest) - len(strings.TrimLeft(rest, " \t\r\n"))
+		return nil, errorAt(in, off, errors.New("more than one JSON value"))
+	} else if !errors.Is(err, io.EOF) {
+		return nil, jsonError(in, err)
+	}
+	return v, nil
+}
+
+func encodeJSON(v any) (string, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+// decodeYAML decodes the documents of in. A stream of several documents
+// becomes a list of them.
+func decodeYAML(in string) (any, error) {
+	dec := yaml.NewDecoder(strings.NewReader(in))
+	var docs []any
+	for {
+		var v any
+		if err := dec.Decode(&v); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		docs = append(docs, v)
+	}
+	switch len(docs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return docs[0], nil
+	}
+	return docs, nil
+}
+
+func encodeYAML(v any) (string, error) {
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func decodeTOML(in string) (any, error) {
+	var v map[string]any
+	if _, err := toml.Decode(in, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func encodeTOML(v any) (string, error) {
+	if _, ok := v.(map[string]any); !ok {
+		return "", fmt.Errorf("TOML documents must be a table, not %s", kindOf(v))
+	}
+	if path, ok := find(v, "", func(v any) bool { return v == nil }); ok {
+		return "", fmt.Errorf("TOML cannot hold null, but %s is null", path)
+	}
+	if path, ok := find(v, "", func(v any) bool { _, ok := v.(bigInt); return ok }); ok {
+		return "", fmt.Errorf("TOML integers are 64-bit, but %s is larger", path)
+	}
+	var b bytes.Buffer
+	if err := toml.NewEncoder(&b).Encode(v); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// find returns the path below path of the first value in v that match
+// holds for, such as "a.b[2]".
+func find(v any, path string, match func(any) bool) (string, bool) {
+	if match(v) {
+		return path, true
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			if p, ok := find(v[k], p, match); ok {
+				return p, true
+			}
+		}
+	case []any:
+		for i, e := range v {
+			if p, ok := find(e, fmt.Sprintf("%s[%d]", path, i), match); ok {
+				return p, true
+			}
+		}
+	}
+	return "", false
+}
+
+// normalizeValue converts what the decoders produce into values every
+// encoder accepts: maps get string keys and JSON numbers become integers
+// where possible. Integers too large for int64 are kept exact as bigInt.
+func normalizeValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = normalizeValue(e)
+		}
+		return v
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = normalizeValue(e)
+		}
+		return m
+	case []any:
+		for i, e := range v {
+			v[i] = normalizeValue(e)
+		}
+		return v
+	case []map[string]any:
+		l := make([]any, len(v))
+		for i, e := range v {
+			l[i] = normalizeValue(e)
+		}
+		return l
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if !strings.ContainsAny(string(v), ".eE") {
+			return bigInt(v)
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return v
+}
+
+// bigInt is an integer too large for int64, kept as its decimal digits.
+type bigInt string
+
+func (n bigInt) MarshalJSON() ([]byte, error) {
+	return []byte(n), nil
+}
+
+func (n bigInt) MarshalYAML() (any, error) {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: string(n)}, nil
+}
+
+// kindOf names the kind of a decoded value for error messages.
+func kindOf(v any) string {
+	switch v.(type) {
+	case []any:
+		return "a list"
+	case string:
+		return "a string"
+	case nil:
+		return "null"
+	default:
+		return "a single value"
+	}
+}
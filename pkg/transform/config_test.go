@@ -0,0 +1,58 @@
+package transform
+
+import "testing"
+
+func TestConfigConversions(t *testing.T) {
+	const in = `{"name": "strcli", "nested": {"ok": true}, "size": 3, "ratio": 0.5, "tags": ["a", "b"]}`
+	want, err := apply(t, "json-pretty", `{"name": "strcli", "nested": {"ok": true}, "ratio": 0.5, "size": 3, "tags": ["a", "b"]}`, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, via := range []string{"yaml", "toml"} {
+		t.Run(via, func(t *testing.T) {
+			converted, err := apply(t, "json-to-"+via, in, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			back, err := apply(t, via+"-to-json", converted, "")
+			if err != nil {
+				t.Fatalf("%s-to-json of %q: %v", via, converted, err)
+			}
+			if back != want {
+				t.Errorf("json to %s and back:\n got %s\nwant %s", via, back, want)
+			}
+		})
+	}
+}
+
+func TestConfig(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"json-to-yaml", "", `{"b": [1, 2], "a": {"c": "x"}}`, "a:\n  c: x\nb:\n  - 1\n  - 2\n"},
+		{"json-to-toml", "", `{"b": 1, "a": {"c": "x"}}`, "b = 1\n\n[a]\n  c = \"x\"\n"},
+		{"yaml-to-json", "", "1: one\nlist: [a]\n", "{\n  \"1\": \"one\",\n  \"list\": [\n    \"a\"\n  ]\n}\n"},
+		{"toml-to-yaml", "", "[[item]]\nid = 1\n[[item]]\nid = 2\n", "item:\n  - id: 1\n  - id: 2\n"},
+		{"yaml-to-toml", "", "a: 1\n", "a = 1\n"},
+		{"toml-to-json", "", "f = 1.5\n", "{\n  \"f\": 1.5\n}\n"},
+		{"json-to-yaml", "", `{"big": 123456789012345678901234, "neg": -99999999999999999999}`, "big: 123456789012345678901234\nneg: -99999999999999999999\n"},
+		{"yaml-to-json", "", "a: 1\n---\nb: 2\n", "[\n  {\n    \"a\": 1\n  },\n  {\n    \"b\": 2\n  }\n]\n"},
+		{"yaml-to-json", "", "---\na: 1\n", "{\n  \"a\": 1\n}\n"},
+	})
+}
+
+func TestConfigErrors(t *testing.T) {
+	testErrors(t, []errorTest{
+		{"json-to-yaml", "", "{\n  \"a\":}", "line 2, column 7"},
+		{"json-to-toml", "", `[1, 2]`, "must be a table, not a list"},
+		{"yaml-to-toml", "", "just text", "must be a table, not a string"},
+		{"yaml-to-json", "", "a: [", "yaml"},
+		{"toml-to-json", "", "a = ", "toml"},
+		{"json-to-yaml", "", "{\"a\": 1} {\"b\": 2}", "line 1, column 10: more than one JSON value"},
+		{"json-to-yaml", "", "  {}\n\n  []", "line 3, column 3: more than one JSON value"},
+		{"json-to-yaml", "", "{} ]", "line 1, column 4"},
+		{"json-to-yaml", "", "   {\"a\":}", "line 1, column 9"},
+		{"json-to-toml", "", `{"a": {"b": [1, null]}}`, "a.b[1] is null"},
+		{"yaml-to-toml", "", "a: ~\n", "a is null"},
+		{"json-to-toml", "", `{"a": {"b": [123456789012345678901234]}}`, "a.b[0] is larger"},
+		{"yaml-to-json", "", "a: 1\n---\nb: [\n", "yaml"},
+	})
+}
@@ -0,0 +1,23 @@
+package transform
+
+import "strings"
+
+func init() {
+	Register(Transform{
+		Name:        "to-lf",
+		Description: "Convert CRLF and lone CR line endings to LF",
+		Apply:       simple(ToLF),
+	})
+	Register(Transform{
+		Name:        "to-crlf",
+		Description: "Convert all line endings to CRLF",
+		Apply: simple(func(s string) string {
+			return strings.ReplaceAll(ToLF(s), "\n", "\r\n")
+		}),
+	})
+}
+
+// ToLF converts CRLF and lone CR line endings in s to LF.
+func ToLF(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\r", "\n")
+}
@@ -0,0 +1,12 @@
+package transform
+
+import "testing"
+
+func TestLineEndings(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"to-lf", "", "a\r\nb\rc\nd", "a\nb\nc\nd"},
+		{"to-lf", "", "\r\r\n", "\n\n"},
+		{"to-crlf", "", "a\nb\r\nc\rd", "a\r\nb\r\nc\r\nd"},
+		{"to-crlf", "", "no line break", "no line break"},
+	})
+}
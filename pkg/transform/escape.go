@@ -0,0 +1,136 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"html"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "go-escape",
+		Description: "Escape as a double-quoted Go string literal",
+		Apply:       simple(strconv.Quote),
+	})
+	Register(Transform{
+		Name:        "go-unescape",
+		Description: "Unescape a Go string literal; the quotes are optional",
+		Apply: func(in, _ string) (string, error) {
+			s := strings.TrimSpace(in)
+			if !strings.HasPrefix(s, `"`) && !strings.HasPrefix(s, "`") {
+				s = `"` + s + `"`
+			}
+			out, err := strconv.Unquote(s)
+			if err != nil {
+				return "", errors.New("invalid Go string literal")
+			}
+			return out, nil
+		},
+	})
+	Register(Transform{
+		Name:        "json-escape",
+		Description: "Escape as a JSON string",
+		Apply: func(in, _ string) (string, error) {
+			var b bytes.Buffer
+			enc := json.NewEncoder(&b)
+			enc.SetEscapeHTML(false)
+			if err := enc.Encode(in); err != nil {
+				return "", err
+			}
+			return strings.TrimSuffix(b.String(), "\n"), nil
+		},
+	})
+	Register(Transform{
+		Name:        "json-unescape",
+		Description: "Unescape a JSON string; the quotes are optional",
+		Apply: func(in, _ string) (string, error) {
+			s := strings.TrimSpace(in)
+			if !strings.HasPrefix(s, `"`) {
+				s = `"` + s + `"`
+			}
+			var out string
+			if err := json.Unmarshal([]byte(s), &out); err != nil {
+				return "", jsonError(s, err)
+			}
+			return out, nil
+		},
+	})
+	Register(Transform{
+		Name:        "html-escape",
+		Description: "Escape <, >, &, ' and \" for HTML",
+		Apply:       simple(html.EscapeString),
+	})
+	Register(Transform{
+		Name:        "html-unescape",
+		Description: "Replace HTML entities, named or numeric, with the characters they stand for",
+		Apply:       simple(html.UnescapeString),
+	})
+	Register(Transform{
+		Name:        "shell-escape",
+		Description: "Quote as a single POSIX shell word",
+		Apply:       simple(shellQuote),
+	})
+	Register(Transform{
+		Name:        "shell-unescape",
+		Description: "Remove POSIX shell quoting and escapes",
+		Apply: func(in, _ string) (string, error) {
+			return shellUnquote(strings.TrimSpace(in))
+		},
+	})
+}
+
+// shellQuote quotes s in single quotes, which keep everything literal
+// except single quotes themselves.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789@%_-+=:,./") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// shellUnquote removes single quotes, double quotes and backslash escapes
+// the way a POSIX shell does for one word, without expanding anything.
+func shellUnquote(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return "", errors.New("unterminated single quote")
+			}
+			b.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+		case '"':
+			i++
+			for ; i < len(s) && s[i] != '"'; i++ {
+				// In double quotes a backslash only escapes these, and
+				// with a line break continues the line.
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("$`\"\\\n", s[i+1]) >= 0 {
+					i++
+					if s[i] == '\n' {
+						continue
+					}
+				}
+				b.WriteByte(s[i])
+			}
+			if i == len(s) {
+				return "", errors.New("unterminated double quote")
+			}
+		case '\\':
+			// A backslash before a line break continues the line.
+			if i+1 < len(s) {
+				i++
+				if s[i] != '\n' {
+					b.WriteByte(s[i])
+				}
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
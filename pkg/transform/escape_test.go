@@ -0,0 +1,58 @@
+package transform
+
+import "testing"
+
+func TestEscapeRoundTrip(t *testing.T) {
+	tests := []struct {
+		escape, unescape string
+		in               string
+	}{
+		{"go-escape", "go-unescape", "tab\there \"quoted\" \\ é\n"},
+		{"json-escape", "json-unescape", "line\nbreak \"quoted\" </tag>"},
+		{"html-escape", "html-unescape", `<a href="x">Tom & Jerry's</a>`},
+		{"shell-escape", "shell-unescape", "it's a $HOME \"test\""},
+		{"shell-escape", "shell-unescape", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.escape+" "+tt.in, func(t *testing.T) {
+			escaped, err := apply(t, tt.escape, tt.in, "")
+			if err != nil {
+				t.Fatalf("%s: %v", tt.escape, err)
+			}
+			got, err := apply(t, tt.unescape, escaped, "")
+			if err != nil {
+				t.Fatalf("%s of %q: %v", tt.unescape, escaped, err)
+			}
+			if got != tt.in {
+				t.Errorf("%s then %s: got %q, want %q", tt.escape, tt.unescape, got, tt.in)
+			}
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"go-escape", "", "a\tb\"", `"a\tb\""`},
+		{"go-unescape", "", `a\nb`, "a\nb"},
+		{"go-unescape", "", "`raw\\n`", `raw\n`},
+		{"json-escape", "", "<é>\n", `"<é>\n"`},
+		{"json-unescape", "", ` "é\t" `, "é\t"},
+		{"html-escape", "", `<b>"x" & 'y'</b>`, "&lt;b&gt;&#34;x&#34; &amp; &#39;y&#39;&lt;/b&gt;"},
+		{"html-unescape", "", "&lt;&eacute;&#233;&#x41;", "<ééA"},
+		{"shell-escape", "", "plain-word_1.txt", "plain-word_1.txt"},
+		{"shell-escape", "", "it's", `'it'\''s'`},
+		{"shell-unescape", "", `a\ b"c \$d"'e\f'`, `a bc $de\f`},
+		{"shell-unescape", "", "one \\\ntwo", "one two"},
+		{"shell-unescape", "", "\"one \\\ntwo\"", "one two"},
+		{"shell-unescape", "", "'one \\\ntwo'", "one \\\ntwo"},
+	})
+}
+
+func TestEscapeErrors(t *testing.T) {
+	testErrors(t, []errorTest{
+		{"go-unescape", "", `bad \q`, "invalid Go string literal"},
+		{"json-unescape", "", `bad \q`, "line 1, column"},
+		{"shell-unescape", "", "'open", "unterminated single quote"},
+		{"shell-unescape", "", `"open`, "unterminated double quote"},
+	})
+}
@@ -0,0 +1,83 @@
+package transform
+
+import (
+	"net/netip"
+	"regexp"
+	"strings"
+)
+
+func init() {
+	for _, e := range []struct {
+		name, what string
+		find       func(string) []string
+	}{
+		{"extract-urls", "URLs", findAll(urlPattern, trimURL)},
+		{"extract-emails", "email addresses", findAll(emailPattern, nil)},
+		{"extract-ips", "IPv4 and IPv6 addresses", findIPs},
+		{"extract-uuids", "UUIDs", findAll(uuidPattern, nil)},
+	} {
+		e := e
+		Register(Transform{
+			Name:        e.name,
+			Description: "Keep only the " + e.what + " found in the text, one per line",
+			Apply: simple(func(s string) string {
+				found := e.find(s)
+				if len(found) == 0 {
+					return ""
+				}
+				return strings.Join(found, "\n") + "\n"
+			}),
+		})
+	}
+}
+
+var (
+	urlPattern   = regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.-]*://[^\s<>"'` + "`" + `]+`)
+	emailPattern = regexp.MustCompile(`\b[a-zA-Z0-9._%+-]+@[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)*\.[a-zA-Z]{2,}\b`)
+	uuidPattern  = regexp.MustCompile(`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`)
+	// ipCandidate matches anything that might be an address; netip decides.
+	ipCandidate = regexp.MustCompile(`[0-9a-fA-F:.]*[:.][0-9a-fA-F:.]*[0-9a-fA-F]`)
+)
+
+// findAll returns a function that finds the matches of re in order, after
+// cleaning each with clean if it is set.
+func findAll(re *regexp.Regexp, clean func(string) string) func(string) []string {
+	return func(s string) []string {
+		found := re.FindAllString(s, -1)
+		if clean != nil {
+			for i, f := range found {
+				found[i] = clean(f)
+			}
+		}
+		return found
+	}
+}
+
+// trimURL drops punctuation that ends the sentence around a URL rather
+// than the URL itself, keeping closing brackets that have a partner inside.
+func trimURL(u string) string {
+	for {
+		trimmed := strings.TrimRight(u, ".,;:!?")
+		if strings.HasSuffix(trimmed, ")") && strings.Count(trimmed, "(") < strings.Count(trimmed, ")") {
+			trimmed = trimmed[:len(trimmed)-1]
+		}
+		if trimmed == u {
+			return u
+		}
+		u = trimmed
+	}
+}
+
+func findIPs(s string) []string {
+	var ips []string
+	for _, c := range ipCandidate.FindAllString(s, -1) {
+		if addr, err := netip.ParseAddr(c); err == nil {
+			ips = append(ips, addr.String())
+		} else if addr, err := netip.ParseAddr(strings.Trim(c, ":.")); err == nil {
+			ips = append(ips, addr.String())
+		} else if ap, err := netip.ParseAddrPort(c); err == nil {
+			ips = append(ips, ap.Addr().String())
+		}
+	}
+	return ips
+}
@@ -0,0 +1,30 @@
+package transform
+
+import "testing"
+
+func TestExtract(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"extract-urls", "", "See https://example.com/a?b=1, or (http://x.org/wiki/Go_(language)).\nftp://files.example.net/f.txt!", "https://example.com/a?b=1\nhttp://x.org/wiki/Go_(language)\nftp://files.example.net/f.txt\n"},
+		{"extract-urls", "", `<a href="https://example.com/">link</a>`, "https://example.com/\n"},
+		{"extract-urls", "", "no links here", ""},
+		{"extract-emails", "", "Mail ann.lee+tag@mail.example.co.uk or bob@example.com.", "ann.lee+tag@mail.example.co.uk\nbob@example.com\n"},
+		{"extract-emails", "", "not@an-address", ""},
+		{"extract-ips", "", "from 192.168.0.1:8080 to 10.0.0.255, and ::1 or [2001:db8::1]:443.", "192.168.0.1\n10.0.0.255\n::1\n2001:db8::1\n"},
+		{"extract-ips", "", "version 1.2.3 at 12:30, 999.1.1.1", ""},
+		{"extract-uuids", "", "id=123E4567-E89B-12D3-A456-426614174000 and 00000000-0000-0000-0000-000000000000x", "123E4567-E89B-12D3-A456-426614174000\n"},
+	})
+}
+
+func TestTrimURL(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"https://a.b/c.", "https://a.b/c"},
+		{"https://a.b/c).", "https://a.b/c"},
+		{"https://a.b/(c)", "https://a.b/(c)"},
+		{"https://a.b/c?!", "https://a.b/c"},
+	}
+	for _, tt := range tests {
+		if got := trimURL(tt.in); got != tt.want {
+			t.Errorf("trimURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
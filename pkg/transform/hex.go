@@ -0,0 +1,91 @@
+package transform
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "hex-encode",
+		Description: "Encode as hexadecimal",
+		Apply:       simple(func(in string) string { return hex.EncodeToString([]byte(in)) }),
+	})
+	Register(Transform{
+		Name:        "hex-encode-as",
+		Description: "Encode as hexadecimal bytes in a style: spaced, c or go",
+		Arg:         "style",
+		Apply: func(in, style string) (string, error) {
+			switch style {
+			case "spaced":
+				return hexSpaced(in), nil
+			case "c":
+				return hexLiteral(in, "{", "}"), nil
+			case "go":
+				return hexLiteral(in, "[]byte{", "}"), nil
+			}
+			return "", fmt.Errorf("unknown style %q; use spaced, c or go", style)
+		},
+	})
+	Register(Transform{
+		Name:        "hex-dump",
+		Description: "Show offsets, hex bytes and printable characters like hexdump -C",
+		Apply:       simple(func(in string) string { return hex.Dump([]byte(in)) }),
+	})
+	Register(Transform{
+		Name:        "hex-decode",
+		Description: "Decode hexadecimal, ignoring spaces, 0x prefixes and literal syntax",
+		Apply: func(in, _ string) (string, error) {
+			b, err := hex.DecodeString(hexDigits.Replace(in))
+			var invalid hex.InvalidByteError
+			switch {
+			case errors.As(err, &invalid):
+				return "", fmt.Errorf("invalid hex digit %q", rune(invalid))
+			case errors.Is(err, hex.ErrLength):
+				return "", errors.New("odd number of hex digits")
+			case err != nil:
+				return "", err
+			}
+			return asText(b)
+		},
+	})
+}
+
+// hexDigits removes what surrounds the digits of the styles of hex-encode-as.
+var hexDigits = strings.NewReplacer(
+	"[]byte", "", "{", "", "}", "", ",", "", "0x", "", "0X", "",
+	" ", "", "\t", "", "\n", "", "\r", "",
+)
+
+// hexSpaced encodes in as hex with a space between bytes.
+func hexSpaced(in string) string {
+	parts := make([]string, len(in))
+	for i := 0; i < len(in); i++ {
+		parts[i] = fmt.Sprintf("%02x", in[i])
+	}
+	return strings.Join(parts, " ")
+}
+
+// bytesPerLine is how many bytes hexLiteral writes on one line.
+const bytesPerLine = 12
+
+// hexLiteral encodes in as an array literal between open and close. Long
+// literals are split over several lines.
+func hexLiteral(in, open, close string) string {
+	parts := make([]string, len(in))
+	for i := 0; i < len(in); i++ {
+		parts[i] = fmt.Sprintf("0x%02x", in[i])
+	}
+	if len(parts) <= bytesPerLine {
+		return open + strings.Join(parts, ", ") + close
+	}
+	var b strings.Builder
+	b.WriteString(open + "\n")
+	for i := 0; i < len(parts); i += bytesPerLine {
+		b.WriteString("\t" + strings.Join(parts[i:min(i+bytesPerLine, len(parts))], ", ") + ",\n")
+	}
+	b.WriteString(close)
+	return b.String()
+}
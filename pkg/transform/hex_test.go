@@ -0,0 +1,52 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHex(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"hex-encode", "", "hex ✓", "68657820e29c93"},
+		{"hex-encode-as", "spaced", "ab\n", "61 62 0a"},
+		{"hex-encode-as", "c", "ab", "{0x61, 0x62}"},
+		{"hex-encode-as", "go", "ab", "[]byte{0x61, 0x62}"},
+		{"hex-encode-as", "go", "abcdefghijklm", "[]byte{\n\t0x61, 0x62, 0x63, 0x64, 0x65, 0x66, 0x67, 0x68, 0x69, 0x6a, 0x6b, 0x6c,\n\t0x6d,\n}"},
+		{"hex-dump", "", "ab", "00000000  61 62                                             |ab|\n"},
+		{"hex-decode", "", "68657820e29c93", "hex ✓"},
+		{"hex-decode", "", "61 62 0A", "ab\n"},
+		{"hex-decode", "", "[]byte{\n\t0x61, 0x62,\n}", "ab"},
+		{"hex-decode", "", "{0X61, 0x62}", "ab"},
+	})
+}
+
+func TestHexRoundTrip(t *testing.T) {
+	const in = "Hello, wörld! A line long enough to be split.\n"
+	for _, style := range []string{"spaced", "c", "go"} {
+		t.Run(style, func(t *testing.T) {
+			enc, err := apply(t, "hex-encode-as", in, style)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := apply(t, "hex-decode", enc, "")
+			if err != nil {
+				t.Fatalf("hex-decode of %q: %v", enc, err)
+			}
+			if got != in {
+				t.Errorf("got %q, want %q", got, in)
+			}
+			if style != "spaced" && !strings.Contains(enc, "\n") {
+				t.Errorf("%q is not split over lines", enc)
+			}
+		})
+	}
+}
+
+func TestHexErrors(t *testing.T) {
+	testErrors(t, []errorTest{
+		{"hex-encode-as", "rust", "ab", `unknown style "rust"`},
+		{"hex-decode", "", "6g", `invalid hex digit 'g'`},
+		{"hex-decode", "", "616", "odd number"},
+		{"hex-decode", "", "ff fe", "binary"},
+	})
+}
@@ -0,0 +1,46 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "html-entities",
+		Description: "Write <, >, &, ' and \" and every non-ASCII character as HTML entities",
+		Apply:       simple(func(s string) string { return htmlEntities(s, true) }),
+	})
+	Register(Transform{
+		Name:        "html-entities-non-ascii",
+		Description: "Write only non-ASCII characters as HTML entities, e.g. é as &#xE9;",
+		Apply:       simple(func(s string) string { return htmlEntities(s, false) }),
+	})
+}
+
+// htmlSpecial are the named entities of the characters HTML gives meaning.
+var htmlSpecial = map[rune]string{
+	'&':  "&amp;",
+	'<':  "&lt;",
+	'>':  "&gt;",
+	'"':  "&quot;",
+	'\'': "&#39;",
+}
+
+// htmlEntities replaces non-ASCII characters with numeric character
+// references, and the special characters of HTML with entities if special
+// is set. html-unescape turns either back.
+func htmlEntities(s string, special bool) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch e, ok := htmlSpecial[r]; {
+		case ok && special:
+			b.WriteString(e)
+		case r > 0x7F:
+			fmt.Fprintf(&b, "&#x%X;", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
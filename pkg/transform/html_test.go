@@ -0,0 +1,36 @@
+package transform
+
+import "testing"
+
+func TestHTMLEntities(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"html-entities", "", `<a href="x">Tom & Jerry's</a>`, "&lt;a href=&quot;x&quot;&gt;Tom &amp; Jerry&#39;s&lt;/a&gt;"},
+		{"html-entities", "", "café → \U0001F44D", "caf&#xE9; &#x2192; &#x1F44D;"},
+		{"html-entities-non-ascii", "", "<b>café</b>", "<b>caf&#xE9;</b>"},
+		{"html-entities-non-ascii", "", "plain ASCII & more", "plain ASCII & more"},
+		{"html-unescape", "", "&lt;b&gt; caf&#xE9; &eacute; &#233;", "<b> café é é"},
+	})
+}
+
+func TestHTMLEntitiesRoundTrip(t *testing.T) {
+	in := "<p class='x'>naïve &amp; \U0001F44D</p>\n"
+	tests := []struct{ name, want string }{
+		{"html-entities", in},
+		// Only the non-ASCII characters were encoded, so the entity that was
+		// there is decoded too.
+		{"html-entities-non-ascii", "<p class='x'>naïve & \U0001F44D</p>\n"},
+	}
+	for _, tt := range tests {
+		enc, err := apply(t, tt.name, in, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		dec, err := apply(t, "html-unescape", enc, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dec != tt.want {
+			t.Errorf("%s: got %q back, want %q", tt.name, dec, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "json-pretty",
+		Description: "Format JSON with two-space indentation",
+		Apply: func(in, _ string) (string, error) {
+			return JSONIndent(in, "  ")
+		},
+	})
+	Register(Transform{
+		Name:        "json-minify",
+		Description: "Remove all insignificant whitespace from JSON",
+		Apply: func(in, _ string) (string, error) {
+			return JSONCompact(in)
+		},
+	})
+}
+
+// JSONIndent formats the JSON document in with one indent per level.
+func JSONIndent(in, indent string) (string, error) {
+	var b bytes.Buffer
+	if err := json.Indent(&b, []byte(strings.TrimSpace(in)), "", indent); err != nil {
+		return "", jsonError(in, err)
+	}
+	b.WriteByte('\n')
+	return b.String(), nil
+}
+
+// JSONCompact removes insignificant whitespace from the JSON document in.
+func JSONCompact(in string) (string, error) {
+	var b bytes.Buffer
+	if err := json.Compact(&b, []byte(strings.TrimSpace(in))); err != nil {
+		return "", jsonError(in, err)
+	}
+	return b.String(), nil
+}
+
+// jsonError adds the line and column where parsing in failed to err.
+func jsonError(in string, err error) error {
+	var syntax *json.SyntaxError
+	if !errors.As(err, &syntax) {
+		return err
+	}
+	// The offset counts from the first non-space byte and points past the
+	// byte that failed.
+	off := len(in) - len(strings.TrimLeft(in, " \t\r\n")) + int(syntax.Offset)
+	return errorAt(in, min(max(off-1, 0), len(in)), err)
+}
+
+// errorAt adds the line and column of byte off of in to err.
+func errorAt(in string, off int, err error) error {
+	before := in[:off]
+	line := strings.Count(before, "\n") + 1
+	col := utf8.RuneCountInString(before[strings.LastIndex(before, "\n")+1:]) + 1
+	return fmt.Errorf("line %d, column %d: %w", line, col, err)
+}
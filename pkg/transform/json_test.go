@@ -0,0 +1,17 @@
+package transform
+
+import "testing"
+
+func TestJSON(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"json-pretty", "", ` {"a":[1,2],"b":{}} `, "{\n  \"a\": [\n    1,\n    2\n  ],\n  \"b\": {}\n}\n"},
+		{"json-minify", "", "{\n  \"a\": [ 1, 2 ],\n  \"b\": \"x y\"\n}\n", `{"a":[1,2],"b":"x y"}`},
+	})
+	testErrors(t, []errorTest{
+		{"json-pretty", "", `{"a":}`, "line 1, column 6"},
+		{"json-minify", "", `{"a":}`, "line 1, column 6"},
+		{"json-pretty", "", "  {\"a\":}", "line 1, column 8"},
+		{"json-pretty", "", "{\n  \"é\": 1,\n  \"b\" 2\n}", "line 3, column 7"},
+		{"json-pretty", "", "{\"a\": 1", "unexpected end of JSON input"},
+	})
+}
@@ -0,0 +1,102 @@
+package transform
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "jwt-decode",
+		Description: "Show the header and claims of a JSON Web Token, with times made readable; the signature is not checked",
+		Apply:       func(in, _ string) (string, error) { return decodeJWT(in) },
+	})
+}
+
+// jwtTimeClaims are the registered claims that hold a NumericDate.
+var jwtTimeClaims = []string{"exp", "nbf", "iat"}
+
+func decodeJWT(in string) (string, error) {
+	token := strings.TrimSpace(in)
+	token = strings.TrimSpace(strings.TrimPrefix(token, "Bearer "))
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("a JWT has 3 parts separated by dots, found %d", len(parts))
+	}
+	var b strings.Builder
+	var claims map[string]any
+	for i, name := range []string{"Header", "Payload"} {
+		raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[i], "="))
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", strings.ToLower(name), err)
+		}
+		pretty, err := JSONIndent(string(raw), "  ")
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", strings.ToLower(name), err)
+		}
+		fmt.Fprintf(&b, "%s:\n%s\n", name, pretty)
+		if i == 1 {
+			if err := json.Unmarshal(raw, &claims); err != nil {
+				return "", errors.New("payload: claims are not a JSON object")
+			}
+		}
+	}
+
+	var times []string
+	for _, c := range jwtTimeClaims {
+		if v, ok := claims[c].(float64); ok {
+			times = append(times, fmt.Sprintf("%-4s %s", c, describeTime(time.Unix(int64(v), 0), c == "exp")))
+		}
+	}
+	if len(times) > 0 {
+		b.WriteString("Times:\n" + strings.Join(times, "\n") + "\n\n")
+	}
+	fmt.Fprintf(&b, "Signature (not verified):\n%s\n", parts[2])
+	return b.String(), nil
+}
+
+// describeTime shows t in UTC and relative to now. If expiry is set, a
+// time in the past is reported as expired.
+func describeTime(t time.Time, expiry bool) string {
+	d := time.Until(t)
+	var rel string
+	switch {
+	case d > 0:
+		rel = "in " + roughDuration(d)
+	case expiry:
+		rel = "expired " + roughDuration(-d) + " ago"
+	default:
+		rel = roughDuration(-d) + " ago"
+	}
+	return fmt.Sprintf("%s (%s)", t.UTC().Format(time.RFC3339), rel)
+}
+
+// roughDuration gives d in its largest whole unit, e.g. "3 days".
+func roughDuration(d time.Duration) string {
+	units := []struct {
+		name string
+		size time.Duration
+	}{
+		{"year", 365 * 24 * time.Hour},
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+	}
+	for _, u := range units {
+		if n := int(d / u.size); n > 0 {
+			return plural(n, u.name)
+		}
+	}
+	return plural(int(d/time.Second), "second")
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,77 @@
+package transform
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+// jwt returns a token with the given header and payload and a dummy
+// signature.
+func jwt(header, payload string) string {
+	enc := base64.RawURLEncoding.EncodeToString
+	return enc([]byte(header)) + "." + enc([]byte(payload)) + ".c2ln"
+}
+
+func TestJWTDecode(t *testing.T) {
+	token := jwt(`{"alg":"HS256","typ":"JWT"}`, `{"sub":"42","iat":1000000000,"exp":1000003600}`)
+	tests := []struct {
+		name, in string
+		want     []string
+	}{
+		{"token", token, []string{
+			"Header:\n{\n  \"alg\": \"HS256\",\n  \"typ\": \"JWT\"\n}\n",
+			"Payload:\n{\n  \"sub\": \"42\",\n",
+			"Times:\nexp  2001-09-09T02:46:40Z (expired ",
+			"\niat  2001-09-09T01:46:40Z (",
+			"Signature (not verified):\nc2ln\n",
+		}},
+		{"bearer", "Bearer " + token + "\n", []string{"\"sub\": \"42\""}},
+		{"no times", jwt(`{"alg":"none"}`, `{"sub":"x"}`), []string{"Payload:\n{\n  \"sub\": \"x\"\n}\n\nSignature"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := apply(t, "jwt-decode", tt.in, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("got\n%s\nwant %q in it", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestJWTDecodeErrors(t *testing.T) {
+	testErrors(t, []errorTest{
+		{"jwt-decode", "", "abc.def", "3 parts separated by dots, found 2"},
+		{"jwt-decode", "", "!!!.e30.x", "header: illegal base64 data"},
+		{"jwt-decode", "", jwt(`{}`, `not json`), "payload: "},
+		{"jwt-decode", "", jwt(`{}`, `[1]`), "payload: claims are not a JSON object"},
+	})
+}
+
+func TestDescribeTime(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		t      time.Time
+		expiry bool
+		want   string
+	}{
+		{"future", now.Add(50 * time.Hour), true, "(in 2 days)"},
+		{"expired", now.Add(-90 * time.Minute), true, "(expired 1 hour ago)"},
+		{"past", now.Add(-3 * 366 * 24 * time.Hour), false, "(3 years ago)"},
+		{"seconds", now.Add(-30*time.Second - time.Millisecond), false, "(30 seconds ago)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeTime(tt.t, tt.expiry); !strings.HasSuffix(got, tt.want) {
+				t.Errorf("got %q, want it to end with %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package transform
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "reverse",
+		Description: "Reverse the characters of each line, keeping emoji and accents whole",
+		Apply:       simple(perLine(reverseGraphemes)),
+	})
+	Register(Transform{
+		Name:        "reverse-lines",
+		Description: "Reverse the order of lines",
+		Apply:       simple(lines(reverseLines)),
+	})
+	Register(Transform{
+		Name:        "sort",
+		Description: "Sort lines in ascending order",
+		Apply:       simple(lines(sortLines(func(a, b string) bool { return a < b }))),
+	})
+	Register(Transform{
+		Name:        "sort-desc",
+		Description: "Sort lines in descending order",
+		Apply:       simple(lines(sortLines(func(a, b string) bool { return a > b }))),
+	})
+	Register(Transform{
+		Name:        "sort-nocase",
+		Description: "Sort lines ignoring case",
+		Apply:       simple(lines(sortLines(func(a, b string) bool { return Fold(a) < Fold(b) }))),
+	})
+	Register(Transform{
+		Name:        "sort-numeric",
+		Description: "Sort lines by the number they start with, like sort -n",
+		Apply:       simple(lines(sortLines(func(a, b string) bool { return leadingNumber(a) < leadingNumber(b) }))),
+	})
+	Register(Transform{
+		Name:        "sort-version",
+		Description: "Sort lines treating runs of digits as numbers, so v1.10 follows v1.9",
+		Apply:       simple(lines(sortLines(versionLess))),
+	})
+	Register(Transform{
+		Name:        "unique",
+		Description: "Remove repeated lines, keeping the first occurrence",
+		Apply:       simple(lines(uniqueFirst)),
+	})
+	Register(Transform{
+		Name:        "unique-last",
+		Description: "Remove repeated lines, keeping the last occurrence",
+		Apply:       simple(lines(uniqueLast)),
+	})
+	Register(Transform{
+		Name:        "unique-count",
+		Description: "Count how often each line occurs, most frequent first, like sort | uniq -c",
+		Apply:       simple(lines(uniqueCount)),
+	})
+}
+
+func uniqueFirst(ls []string) []string {
+	seen := map[string]bool{}
+	out := ls[:0]
+	for _, l := range ls {
+		if !seen[l] {
+			seen[l] = true
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+func uniqueLast(ls []string) []string {
+	return reverseLines(uniqueFirst(reverseLines(ls)))
+}
+
+// uniqueCount prefixes each distinct line with its number of occurrences,
+// sorting by count and then by the line.
+func uniqueCount(ls []string) []string {
+	counts := map[string]int{}
+	for _, l := range ls {
+		counts[l]++
+	}
+	distinct := uniqueFirst(ls)
+	sort.SliceStable(distinct, func(i, j int) bool {
+		if ci, cj := counts[distinct[i]], counts[distinct[j]]; ci != cj {
+			return ci > cj
+		}
+		return distinct[i] < distinct[j]
+	})
+	width := len(strconv.Itoa(counts[distinct[0]]))
+	out := make([]string, len(distinct))
+	for i, l := range distinct {
+		out[i] = fmt.Sprintf("%*d %s", width, counts[l], l)
+	}
+	return out
+}
+
+// sortLines returns a stable sort of lines by less.
+func sortLines(less func(a, b string) bool) func([]string) []string {
+	return func(ls []string) []string {
+		sort.SliceStable(ls, func(i, j int) bool { return less(ls[i], ls[j]) })
+		return ls
+	}
+}
+
+var leadingNumberRe = regexp.MustCompile(`^\s*[-+]?(\d+\.?\d*|\.\d+)`)
+
+// leadingNumber returns the number s starts with, or 0 if it does not start
+// with one.
+func leadingNumber(s string) float64 {
+	n, _ := strconv.ParseFloat(strings.TrimSpace(leadingNumberRe.FindString(s)), 64)
+	return n
+}
+
+// versionLess compares a and b piece by piece, where a piece is a run of
+// digits or of other characters, comparing runs of digits by value.
+func versionLess(a, b string) bool {
+	for a != "" && b != "" {
+		pa, pb := versionPiece(a), versionPiece(b)
+		a, b = a[len(pa):], b[len(pb):]
+		if pa == pb {
+			continue
+		}
+		da, db := isDigit(pa), isDigit(pb)
+		if da && db {
+			na, nb := strings.TrimLeft(pa, "0"), strings.TrimLeft(pb, "0")
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			return len(pa) < len(pb)
+		}
+		return pa < pb
+	}
+	return len(a) < len(b)
+}
+
+// versionPiece returns the run of digits or of other characters s starts
+// with.
+func versionPiece(s string) string {
+	digit := isDigit(s[:1])
+	end := strings.IndexFunc(s, func(r rune) bool { return (r >= '0' && r <= '9') != digit })
+	if end < 0 {
+		return s
+	}
+	return s[:end]
+}
+
+func isDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+// reverseGraphemes reverses s one grapheme cluster at a time.
+func reverseGraphemes(s string) string {
+	var clusters []string
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		clusters = append(clusters, g.Str())
+	}
+	var b strings.Builder
+	for i := len(clusters) - 1; i >= 0; i-- {
+		b.WriteString(clusters[i])
+	}
+	return b.String()
+}
+
+func reverseLines(ls []string) []string {
+	for i, j := 0, len(ls)-1; i < j; i, j = i+1, j-1 {
+		ls[i], ls[j] = ls[j], ls[i]
+	}
+	return ls
+}
+
+// lines adapts a function that rearranges lines to work on text. A final
+// line break stays at the end instead of becoming an empty first line.
+func lines(fn func([]string) []string) func(string) string {
+	return func(s string) string {
+		if s == "" {
+			return s
+		}
+		body, nl := strings.CutSuffix(s, "\n")
+		out := strings.Join(fn(strings.Split(body, "\n")), "\n")
+		if nl {
+			out += "\n"
+		}
+		return out
+	}
+}
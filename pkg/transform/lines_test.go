@@ -0,0 +1,24 @@
+package transform
+
+import "testing"
+
+func TestLines(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"reverse", "", "abc\nxyz", "cba\nzyx"},
+		{"reverse", "", "née 👍🏽!", "!👍🏽 eén"},
+		{"reverse", "", "👨‍👩‍👧 a", "a 👨‍👩‍👧"},
+		{"reverse-lines", "", "one\ntwo\nthree\n", "three\ntwo\none\n"},
+		{"reverse-lines", "", "one\ntwo", "two\none"},
+		{"reverse-lines", "", "", ""},
+		{"sort", "", "b\nB\na\n", "B\na\nb\n"},
+		{"sort-desc", "", "b\nc\na", "c\nb\na"},
+		{"sort-nocase", "", "b\nB\na\nA\n", "a\nA\nb\nB\n"},
+		{"sort-numeric", "", "10 ten\n9 nine\n-1 minus\nnone\n1.5 x\n", "-1 minus\nnone\n1.5 x\n9 nine\n10 ten\n"},
+		{"sort-version", "", "v1.10\nv1.9\nv1.9.1\nv1.02\nv1.2\n", "v1.2\nv1.02\nv1.9\nv1.9.1\nv1.10\n"},
+		{"sort-version", "", "img10\nimg2\nimg", "img\nimg2\nimg10"},
+		{"unique", "", "b\na\nb\nc\na\n", "b\na\nc\n"},
+		{"unique-last", "", "b\na\nb\nc\na\n", "b\nc\na\n"},
+		{"unique-count", "", "b\na\nb\nc\nb\na\nd\nd\nd\nd\nd\nd\nd\nd\nd\nd\n", "10 d\n 3 b\n 2 a\n 1 c\n"},
+		{"unique-count", "", "", ""},
+	})
+}
@@ -0,0 +1,131 @@
+package transform
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "lorem",
+		Description: "Replace the text with lorem ipsum, e.g. 50w for words or 3p for paragraphs",
+		Arg:         "amount",
+		Apply: func(_, arg string) (string, error) {
+			n, unit, err := parseAmount(arg)
+			if err != nil {
+				return "", err
+			}
+			if unit == 'p' {
+				return LoremParagraphs(n), nil
+			}
+			return LoremWords(n) + "\n", nil
+		},
+	})
+	Register(Transform{
+		Name:        "random-sentences",
+		Description: "Replace the text with simple random English sentences",
+		Arg:         "number of sentences",
+		Apply: func(_, arg string) (string, error) {
+			n, err := strconv.Atoi(arg)
+			if err != nil || n < 1 {
+				return "", fmt.Errorf("invalid number of sentences %q", arg)
+			}
+			return RandomSentences(n) + "\n", nil
+		},
+	})
+}
+
+// parseAmount parses an amount such as "50w" or "3p"; a bare number counts
+// words.
+func parseAmount(s string) (int, byte, error) {
+	s = strings.TrimSpace(s)
+	unit := byte('w')
+	if s != "" && (s[len(s)-1] == 'w' || s[len(s)-1] == 'p') {
+		unit = s[len(s)-1]
+		s = s[:len(s)-1]
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, 0, fmt.Errorf("invalid amount %q; use e.g. 50w or 3p", s)
+	}
+	return n, unit, nil
+}
+
+var loremWords = strings.Fields(`lorem ipsum dolor sit amet consectetur adipiscing elit sed do
+eiusmod tempor incididunt ut labore et dolore magna aliqua enim ad minim veniam quis nostrud
+exercitation ullamco laboris nisi aliquip ex ea commodo consequat duis aute irure in
+reprehenderit voluptate velit esse cillum fugiat nulla pariatur excepteur sint occaecat
+cupidatat non proident sunt culpa qui officia deserunt mollit anim id est laborum`)
+
+// LoremWords returns n words of lorem ipsum made into sentences, starting
+// with the traditional "Lorem ipsum dolor sit amet".
+func LoremWords(n int) string {
+	return lorem(n, true)
+}
+
+// lorem returns n words of lorem ipsum made into sentences, starting with
+// the traditional words if classic is set.
+func lorem(n int, classic bool) string {
+	words := make([]string, n)
+	for i := range words {
+		if classic && i < 5 {
+			words[i] = loremWords[i]
+		} else {
+			words[i] = loremWords[rand.Intn(len(loremWords))]
+		}
+	}
+	var b strings.Builder
+	for start := 0; start < n; {
+		end := start + 6 + rand.Intn(8)
+		if end > n-4 {
+			end = n // rather than leave a sentence of a word or two
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(capitalizeFirst(strings.Join(words[start:end], " ")) + ".")
+		start = end
+	}
+	return b.String()
+}
+
+// LoremParagraphs returns n paragraphs of lorem ipsum separated by blank
+// lines.
+func LoremParagraphs(n int) string {
+	paras := make([]string, n)
+	for i := range paras {
+		paras[i] = lorem(40+rand.Intn(40), i == 0)
+	}
+	return strings.Join(paras, "\n\n") + "\n"
+}
+
+var (
+	subjects   = []string{"the cat", "a developer", "my neighbour", "the old clock", "every robot", "a small bird", "the committee", "our server"}
+	verbs      = []string{"reads", "builds", "ignores", "paints", "compares", "finds", "loses", "repairs"}
+	objects    = []string{"a green book", "the red door", "two strings", "a long letter", "the last train", "an odd number", "the quiet garden", "a broken link"}
+	adverbials = []string{"", "", " today", " again", " at noon", " without a word", " in the rain", " twice"}
+)
+
+// RandomSentences returns n simple random sentences.
+func RandomSentences(n int) string {
+	sentences := make([]string, n)
+	for i := range sentences {
+		sentences[i] = capitalizeFirst(fmt.Sprintf("%s %s %s%s.",
+			pick(subjects), pick(verbs), pick(objects), pick(adverbials)))
+	}
+	return strings.Join(sentences, " ")
+}
+
+func pick(words []string) string {
+	return words[rand.Intn(len(words))]
+}
+
+// capitalizeFirst upper-cases the first letter of s.
+func capitalizeFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
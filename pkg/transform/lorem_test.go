@@ -0,0 +1,68 @@
+package transform
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLorem(t *testing.T) {
+	tests := []struct {
+		arg        string
+		prefix     string
+		words      int
+		paragraphs int
+	}{
+		{"1", "Lorem.", 1, 1},
+		{"5", "Lorem ipsum dolor sit amet.", 5, 1},
+		{"50w", "Lorem ipsum dolor sit amet ", 50, 1},
+		{" 12w ", "Lorem ipsum dolor sit amet ", 12, 1},
+		{"1p", "Lorem ipsum dolor sit amet ", 0, 1},
+		{"3p", "Lorem ipsum dolor sit amet ", 0, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := apply(t, "lorem", "ignored", tt.arg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasSuffix(got, ".\n") {
+				t.Errorf("got %q, want sentences ending in a newline", got)
+			}
+			if !strings.HasPrefix(got, tt.prefix) {
+				t.Errorf("got %q, want it to start with %q", got, tt.prefix)
+			}
+			if paras := strings.Split(strings.TrimSuffix(got, "\n"), "\n\n"); len(paras) != tt.paragraphs {
+				t.Errorf("got %d paragraphs, want %d", len(paras), tt.paragraphs)
+			}
+			if n := len(strings.Fields(got)); tt.words > 0 && n != tt.words {
+				t.Errorf("got %d words, want %d", n, tt.words)
+			}
+		})
+	}
+}
+
+func TestRandomSentences(t *testing.T) {
+	for _, n := range []int{1, 2, 7} {
+		got, err := apply(t, "random-sentences", "", strconv.Itoa(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c := strings.Count(got, "."); c != n {
+			t.Errorf("%d sentences: got %q with %d", n, got, c)
+		}
+		if got[0] < 'A' || got[0] > 'Z' {
+			t.Errorf("got %q, want a capital first letter", got)
+		}
+	}
+}
+
+func TestLoremErrors(t *testing.T) {
+	testErrors(t, []errorTest{
+		{"lorem", "", "x", "invalid amount"},
+		{"lorem", "0w", "x", "invalid amount"},
+		{"lorem", "3x", "x", "invalid amount"},
+		{"random-sentences", "two", "x", "invalid number of sentences"},
+		{"random-sentences", "-1", "x", "invalid number of sentences"},
+	})
+}
@@ -0,0 +1,96 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// RoundTrip decodes text and encodes it back, to check whether the encoding
+// keeps the text intact.
+type RoundTrip struct {
+	Name        string
+	Description string
+	// Prepare canonicalizes what the round trip is not expected to keep,
+	// such as insignificant whitespace. It may be nil.
+	Prepare func(string) (string, error)
+	// Run decodes its input and encodes the result again.
+	Run func(string) (string, error)
+}
+
+// Check runs rt on in. It returns in as prepared and the round-tripped
+// text; the round trip is lossless if they are equal.
+func (rt RoundTrip) Check(in string) (before, after string, err error) {
+	before = in
+	if rt.Prepare != nil {
+		if before, err = rt.Prepare(in); err != nil {
+			return "", "", err
+		}
+	}
+	after, err = rt.Run(before)
+	return before, after, err
+}
+
+var roundTrips = map[string]RoundTrip{}
+
+// RegisterRoundTrip makes rt available through LookupRoundTrip and
+// RoundTrips.
+func RegisterRoundTrip(rt RoundTrip) {
+	if _, dup := roundTrips[rt.Name]; dup {
+		panic("transform: duplicate round trip " + rt.Name)
+	}
+	roundTrips[rt.Name] = rt
+}
+
+// LookupRoundTrip returns the round trip called name.
+func LookupRoundTrip(name string) (RoundTrip, bool) {
+	rt, ok := roundTrips[name]
+	return rt, ok
+}
+
+// RoundTrips returns every registered round trip sorted by name.
+func RoundTrips() []RoundTrip {
+	all := make([]RoundTrip, 0, len(roundTrips))
+	for _, rt := range roundTrips {
+		all = append(all, rt)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+func init() {
+	RegisterRoundTrip(RoundTrip{
+		Name:        "json",
+		Description: "Parse JSON and serialize it again",
+		Prepare:     JSONCompact,
+		Run: func(in string) (string, error) {
+			var v any
+			if err := json.Unmarshal([]byte(in), &v); err != nil {
+				return "", err
+			}
+			var b bytes.Buffer
+			enc := json.NewEncoder(&b)
+			enc.SetEscapeHTML(false)
+			if err := enc.Encode(v); err != nil {
+				return "", err
+			}
+			return strings.TrimSuffix(b.String(), "\n"), nil
+		},
+	})
+	RegisterRoundTrip(RoundTrip{
+		Name:        "base64",
+		Description: "Decode standard base64 and encode it again",
+		Prepare: func(in string) (string, error) {
+			return strings.TrimSpace(in), nil
+		},
+		Run: func(in string) (string, error) {
+			b, err := base64.StdEncoding.DecodeString(in)
+			if err != nil {
+				return "", err
+			}
+			return base64.StdEncoding.EncodeToString(b), nil
+		},
+	})
+}
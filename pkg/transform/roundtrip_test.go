@@ -0,0 +1,34 @@
+package transform
+
+import "testing"
+
+func TestRoundTripCheck(t *testing.T) {
+	tests := []struct {
+		name, in string
+		lossless bool
+		wantErr  bool
+	}{
+		{"json", `{ "a": [1, 2], "b": "x" }`, true, false},
+		{"json", `{"b": 1, "a": 2}`, false, false},
+		{"json", `{"a": 1.0}`, false, false},
+		{"json", `{"a": "<tag>"}`, true, false},
+		{"json", `{"a":`, false, true},
+		{"base64", " aGVsbG8= \n", true, false},
+		{"base64", "aGVsbG8", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.in, func(t *testing.T) {
+			rt, ok := LookupRoundTrip(tt.name)
+			if !ok {
+				t.Fatalf("no round trip %q", tt.name)
+			}
+			before, after, err := rt.Check(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && (before == after) != tt.lossless {
+				t.Errorf("before %q, after %q; want lossless %v", before, after, tt.lossless)
+			}
+		})
+	}
+}
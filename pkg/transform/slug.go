@@ -0,0 +1,31 @@
+package transform
+
+import (
+	"strings"
+	"unicode"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "slugify",
+		Description: "Turn each line into a URL slug, e.g. \"Crème Brûlée!\" to creme-brulee",
+		Apply:       simple(perLine(func(s string) string { return slugify(s, "-") })),
+	})
+	Register(Transform{
+		Name:        "slugify-with",
+		Description: "Turn each line into a URL slug with a custom separator",
+		Arg:         "separator",
+		Apply: func(in, sep string) (string, error) {
+			return perLine(func(s string) string { return slugify(s, sep) })(in), nil
+		},
+	})
+}
+
+// slugify lower-cases s, strips its accents and joins its runs of letters
+// and digits with sep.
+func slugify(s, sep string) string {
+	words := strings.FieldsFunc(Fold(StripAccents(s)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, sep)
+}
@@ -0,0 +1,14 @@
+package transform
+
+import "testing"
+
+func TestSlug(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"slugify", "", "Crème Brûlée!", "creme-brulee"},
+		{"slugify", "", "  Hello, World  \nGo 1.21 Release", "hello-world\ngo-1-21-release"},
+		{"slugify", "", "Straße", "strasse"},
+		{"slugify", "", "!!!", ""},
+		{"slugify-with", "_", "Crème Brûlée!", "creme_brulee"},
+		{"slugify-with", "", "a b c", "abc"},
+	})
+}
@@ -0,0 +1,127 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "csv-table",
+		Description: "Lay out CSV as an aligned table with borders, taking the first row as the header",
+		Apply:       func(in, _ string) (string, error) { return csvTable(in, ',') },
+	})
+	Register(Transform{
+		Name:        "tsv-table",
+		Description: "Lay out tab-separated values as an aligned table with borders",
+		Apply:       func(in, _ string) (string, error) { return csvTable(in, '\t') },
+	})
+	Register(Transform{
+		Name:        "table-csv",
+		Description: "Turn a table made by csv-table, or a Markdown table, back into CSV",
+		Apply:       func(in, _ string) (string, error) { return tableCSV(in) },
+	})
+}
+
+func csvTable(in string, sep rune) (string, error) {
+	r := csv.NewReader(strings.NewReader(in))
+	r.Comma = sep
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return "", err
+	}
+	if len(rows) == 0 {
+		return "", errors.New("no rows")
+	}
+
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+	widths := make([]int, cols)
+	numeric := make([]bool, cols)
+	for i := range numeric {
+		numeric[i] = len(rows) > 1
+	}
+	for i, row := range rows {
+		for j, cell := range row {
+			// A line break would break the table, so it is shown as ↵.
+			row[j] = strings.ReplaceAll(strings.ReplaceAll(cell, "\r\n", "↵"), "\n", "↵")
+			widths[j] = max(widths[j], runewidth.StringWidth(row[j]))
+			if _, err := strconv.ParseFloat(strings.TrimSpace(cell), 64); i > 0 && err != nil && cell != "" {
+				numeric[j] = false
+			}
+		}
+	}
+
+	var b strings.Builder
+	border := func(left, mid, right string) {
+		b.WriteString(left)
+		for j, w := range widths {
+			if j > 0 {
+				b.WriteString(mid)
+			}
+			b.WriteString(strings.Repeat("─", w+2))
+		}
+		b.WriteString(right + "\n")
+	}
+	border("┌", "┬", "┐")
+	for i, row := range rows {
+		b.WriteString("│")
+		for j, w := range widths {
+			cell := ""
+			if j < len(row) {
+				cell = row[j]
+			}
+			if numeric[j] && i > 0 {
+				cell = runewidth.FillLeft(cell, w)
+			} else {
+				cell = runewidth.FillRight(cell, w)
+			}
+			b.WriteString(" " + cell + " │")
+		}
+		b.WriteString("\n")
+		if i == 0 && len(rows) > 1 {
+			border("├", "┼", "┤")
+		}
+	}
+	border("└", "┴", "┘")
+	return b.String(), nil
+}
+
+// tableCSV reads the rows of a table drawn with │ or | between cells,
+// skipping border lines, and writes them as CSV.
+func tableCSV(in string) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	for _, line := range strings.Split(in, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.Trim(line, "─│┌┐└┘├┤┬┴┼|+-=: ") == "" {
+			continue
+		}
+		sep := "│"
+		if !strings.Contains(line, sep) {
+			sep = "|"
+		}
+		line = strings.TrimSuffix(strings.TrimPrefix(line, sep), sep)
+		cells := strings.Split(line, sep)
+		for i, c := range cells {
+			cells[i] = strings.ReplaceAll(strings.TrimSpace(c), "↵", "\n")
+		}
+		if err := w.Write(cells); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	if buf.Len() == 0 {
+		return "", errors.New("no table rows found")
+	}
+	return buf.String(), w.Error()
+}
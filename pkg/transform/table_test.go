@@ -0,0 +1,61 @@
+package transform
+
+import "testing"
+
+func TestTables(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"csv-table", "", "name,qty\napple,3\nkiwi,12\n", `┌───────┬─────┐
+│ name  │ qty │
+├───────┼─────┤
+│ apple │   3 │
+│ kiwi  │  12 │
+└───────┴─────┘
+`},
+		{"csv-table", "", "a,b\n\"x\ny\",日本\nlong", `┌──────┬──────┐
+│ a    │ b    │
+├──────┼──────┤
+│ x↵y  │ 日本 │
+│ long │      │
+└──────┴──────┘
+`},
+		{"csv-table", "", "only,header", `┌──────┬────────┐
+│ only │ header │
+└──────┴────────┘
+`},
+		{"tsv-table", "", "k\tv\nx\t1.5", `┌───┬─────┐
+│ k │ v   │
+├───┼─────┤
+│ x │ 1.5 │
+└───┴─────┘
+`},
+		{"table-csv", "", `┌──────┬──────┐
+│ a    │ b    │
+├──────┼──────┤
+│ x↵y  │ 日本 │
+└──────┴──────┘
+`, "a,b\n\"x\ny\",日本\n"},
+		{"table-csv", "", "| name | qty |\n|------|----:|\n| apple, red | 3 |\n", "name,qty\n\"apple, red\",3\n"},
+	})
+}
+
+func TestTableRoundTrip(t *testing.T) {
+	in := "name,qty\napple,3\n\"a, b\",\n"
+	table, err := apply(t, "csv-table", in, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := apply(t, "table-csv", table, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("got %q back, want %q", out, in)
+	}
+}
+
+func TestTableErrors(t *testing.T) {
+	testErrors(t, []errorTest{
+		{"csv-table", "", "", "no rows"},
+		{"table-csv", "", "┌──┐\n└──┘\n", "no table rows found"},
+	})
+}
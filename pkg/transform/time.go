@@ -0,0 +1,160 @@
+package transform
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // so time zones work where the system has no database
+)
+
+func init() {
+	Register(Transform{
+		Name:        "epoch-to-rfc3339",
+		Description: "Turn lines holding Unix times in s, ms, µs or ns into RFC 3339 times in UTC",
+		Apply:       simple(perLine(epochLine(time.RFC3339Nano))),
+	})
+	Register(Transform{
+		Name:        "epoch-format",
+		Description: "Turn lines holding Unix times into UTC times in a Go layout, e.g. 2006-01-02 15:04",
+		Arg:         "layout",
+		Apply: func(in, layout string) (string, error) {
+			return perLine(epochLine(layout))(in), nil
+		},
+	})
+	Register(Transform{
+		Name:        "time-to-epoch",
+		Description: "Turn lines holding dates and times into Unix times in seconds",
+		Apply:       timeToEpoch(time.Second),
+	})
+	Register(Transform{
+		Name:        "time-to-epoch-ms",
+		Description: "Turn lines holding dates and times into Unix times in milliseconds",
+		Apply:       timeToEpoch(time.Millisecond),
+	})
+	Register(Transform{
+		Name:        "annotate-timestamps",
+		Description: "Follow each Unix time with its RFC 3339 time and each RFC 3339 time with its Unix time",
+		Apply:       simple(annotateTimestamps),
+	})
+	Register(Transform{
+		Name:        "timezone",
+		Description: "Convert RFC 3339 times to a time zone, e.g. Europe/Berlin, UTC or Local",
+		Arg:         "time zone",
+		Apply: func(in, zone string) (string, error) {
+			loc, err := time.LoadLocation(strings.TrimSpace(zone))
+			if err != nil {
+				return "", err
+			}
+			return rfc3339Pattern.ReplaceAllStringFunc(in, func(s string) string {
+				t, err := parseTime(s)
+				if err != nil {
+					return s
+				}
+				return t.In(loc).Format(time.RFC3339Nano)
+			}), nil
+		},
+	})
+}
+
+var (
+	// epochPattern matches Unix times from 2001 to 2286 in seconds,
+	// milliseconds, microseconds or nanoseconds.
+	epochPattern   = regexp.MustCompile(`\b\d{10}(?:\d{3}|\d{6}|\d{9})?\b`)
+	rfc3339Pattern = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}[Tt ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:[Zz]|[+-]\d{2}:\d{2})`)
+	// timestampPattern matches either, so one pass annotates both.
+	timestampPattern = regexp.MustCompile(rfc3339Pattern.String() + "|" + epochPattern.String())
+)
+
+// parseEpoch reads a Unix time, telling its unit from its number of digits.
+func parseEpoch(s string) (time.Time, bool) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	switch len(s) {
+	case 10:
+		return time.Unix(n, 0), true
+	case 13:
+		return time.UnixMilli(n), true
+	case 16:
+		return time.UnixMicro(n), true
+	case 19:
+		return time.Unix(0, n), true
+	}
+	return time.Time{}, false
+}
+
+// epochLine formats a line holding only a Unix time with layout, in UTC.
+// Other lines are kept as they are.
+func epochLine(layout string) func(string) string {
+	return func(line string) string {
+		t, ok := parseEpoch(strings.TrimSpace(line))
+		if !ok {
+			return line
+		}
+		return t.UTC().Format(layout)
+	}
+}
+
+// timeLayouts are the layouts parseTime tries, in order.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+	time.UnixDate,
+}
+
+// parseTime reads s in any of timeLayouts. Times without a zone are in UTC.
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date or time %q", s)
+}
+
+func timeToEpoch(unit time.Duration) func(string, string) (string, error) {
+	return func(in, _ string) (string, error) {
+		var err error
+		out := lines(func(ls []string) []string {
+			for i, l := range ls {
+				s := strings.TrimSpace(l)
+				if s == "" || err != nil {
+					continue
+				}
+				var t time.Time
+				if t, err = parseTime(s); err != nil {
+					err = fmt.Errorf("line %d: %w", i+1, err)
+					continue
+				}
+				ls[i] = strconv.FormatInt(t.UnixNano()/int64(unit), 10)
+			}
+			return ls
+		})(in)
+		return out, err
+	}
+}
+
+// annotateTimestamps follows each timestamp in s with the same time in the
+// other notation, in brackets.
+func annotateTimestamps(s string) string {
+	return timestampPattern.ReplaceAllStringFunc(s, func(m string) string {
+		if t, ok := parseEpoch(m); ok {
+			return fmt.Sprintf("%s [%s]", m, t.UTC().Format(time.RFC3339Nano))
+		}
+		if t, err := parseTime(m); err == nil {
+			return fmt.Sprintf("%s [%d]", m, t.Unix())
+		}
+		return m
+	})
+}
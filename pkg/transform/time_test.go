@@ -0,0 +1,26 @@
+package transform
+
+import "testing"
+
+func TestTime(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"epoch-to-rfc3339", "", "1700000000\n1700000000123\nnot a time\n", "2023-11-14T22:13:20Z\n2023-11-14T22:13:20.123Z\nnot a time\n"},
+		{"epoch-to-rfc3339", "", " 1700000000123456 \n1700000000123456789", "2023-11-14T22:13:20.123456Z\n2023-11-14T22:13:20.123456789Z"},
+		{"epoch-to-rfc3339", "", "17000000001", "17000000001"},
+		{"epoch-format", "2006-01-02 15:04", "1700000000", "2023-11-14 22:13"},
+		{"time-to-epoch", "", "2023-11-14T22:13:20Z\n\n2023-11-14 23:13:20+01:00\n2023-11-14\n", "1700000000\n\n1700000000\n1699920000\n"},
+		{"time-to-epoch", "", "Tue, 14 Nov 2023 22:13:20 UTC", "1700000000"},
+		{"time-to-epoch-ms", "", "2023-11-14T22:13:20.123Z", "1700000000123"},
+		{"annotate-timestamps", "", "at 1700000000 and 2023-11-14T22:13:20Z.", "at 1700000000 [2023-11-14T22:13:20Z] and 2023-11-14T22:13:20Z [1700000000]."},
+		{"timezone", "Europe/Berlin", "start 2023-11-14T22:13:20Z, 2023-07-01T12:00:00Z", "start 2023-11-14T23:13:20+01:00, 2023-07-01T14:00:00+02:00"},
+		{"timezone", " UTC ", "2023-11-14T23:13:20.5+01:00", "2023-11-14T22:13:20.5Z"},
+	})
+}
+
+func TestTimeErrors(t *testing.T) {
+	testErrors(t, []errorTest{
+		{"time-to-epoch", "", "2023-11-14\nyesterday\nnonsense", `line 2: unrecognized date or time "yesterday"`},
+		{"time-to-epoch-ms", "", "14/11/2023", "unrecognized date or time"},
+		{"timezone", "Mars/Olympus", "2023-11-14T22:13:20Z", "unknown time zone"},
+	})
+}
@@ -0,0 +1,65 @@
+// Package transform provides named text transformations that can be applied
+// to an input before it is compared.
+package transform
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// Transform is a named text transformation.
+type Transform struct {
+	// Name identifies the transform on the command line, e.g. "upper".
+	Name string
+	// Description is a one-line summary shown in menus.
+	Description string
+	// Arg names the argument the transform takes, e.g. "shift", or is empty
+	// if it takes none.
+	Arg string
+	// Apply transforms in. arg is empty unless Arg is set.
+	Apply func(in, arg string) (string, error)
+}
+
+var registry = map[string]Transform{}
+
+// Register makes t available through Lookup and All. It panics if a
+// transform with the same name is already registered.
+func Register(t Transform) {
+	if _, dup := registry[t.Name]; dup {
+		panic("transform: duplicate name " + t.Name)
+	}
+	registry[t.Name] = t
+}
+
+// Lookup returns the transform called name.
+func Lookup(name string) (Transform, bool) {
+	t, ok := registry[name]
+	return t, ok
+}
+
+// All returns every registered transform sorted by name.
+func All() []Transform {
+	all := make([]Transform, 0, len(registry))
+	for _, t := range registry {
+		all = append(all, t)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+// simple adapts a transform that can neither fail nor take an argument.
+func simple(fn func(string) string) func(string, string) (string, error) {
+	return func(in, _ string) (string, error) {
+		return fn(in), nil
+	}
+}
+
+// asText returns decoded bytes as a string, or an error if they are not
+// UTF-8 text and so cannot be shown in a pane.
+func asText(b []byte) (string, error) {
+	if !utf8.Valid(b) {
+		return "", fmt.Errorf("decoded data is binary, not text (%d bytes)", len(b))
+	}
+	return string(b), nil
+}
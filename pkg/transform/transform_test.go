@@ -0,0 +1,80 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+)
+
+// apply runs the registered transform called name.
+func apply(t *testing.T, name, in, arg string) (string, error) {
+	t.Helper()
+	tr, ok := Lookup(name)
+	if !ok {
+		t.Fatalf("no transform %q", name)
+	}
+	return tr.Apply(in, arg)
+}
+
+// outputTest is a case of testOutputs.
+type outputTest struct {
+	name, arg, in, want string
+}
+
+// testOutputs checks that each transform turns its input into what the test
+// wants.
+func testOutputs(t *testing.T, tests []outputTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.in, func(t *testing.T) {
+			got, err := apply(t, tt.name, tt.in, tt.arg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// errorTest is a case of testErrors.
+type errorTest struct {
+	name, arg, in string
+	// want is part of the error expected.
+	want string
+}
+
+// testErrors checks that each transform rejects its input with the error the
+// test wants.
+func testErrors(t *testing.T, tests []errorTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.in, func(t *testing.T) {
+			out, err := apply(t, tt.name, tt.in, tt.arg)
+			if err == nil {
+				t.Fatalf("got %q, want an error", out)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %q, want one with %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	if _, ok := Lookup("no-such-transform"); ok {
+		t.Error("Lookup found a transform that is not registered")
+	}
+	all := All()
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Name >= all[i].Name {
+			t.Errorf("All is not sorted: %s before %s", all[i-1].Name, all[i].Name)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a duplicate name did not panic")
+		}
+	}()
+	Register(Transform{Name: "upper"})
+}
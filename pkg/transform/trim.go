@@ -0,0 +1,152 @@
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "trim",
+		Description: "Remove leading and trailing whitespace from each line",
+		Apply:       simple(perLine(strings.TrimSpace)),
+	})
+	Register(Transform{
+		Name:        "trim-left",
+		Description: "Remove leading whitespace from each line",
+		Apply:       simple(perLine(func(s string) string { return strings.TrimLeftFunc(s, unicode.IsSpace) })),
+	})
+	Register(Transform{
+		Name:        "trim-right",
+		Description: "Remove trailing whitespace from each line",
+		Apply:       simple(perLine(func(s string) string { return strings.TrimRightFunc(s, unicode.IsSpace) })),
+	})
+	Register(Transform{
+		Name:        "pad-right",
+		Description: "Pad each line on the right to a width, e.g. 20 or 20:. to pad with dots",
+		Arg:         "width[:character]",
+		Apply:       padLines(false),
+	})
+	Register(Transform{
+		Name:        "pad-left",
+		Description: "Pad each line on the left to a width, e.g. 8:0 for leading zeros",
+		Arg:         "width[:character]",
+		Apply:       padLines(true),
+	})
+	Register(Transform{
+		Name:        "expand-tabs",
+		Description: "Replace tabs with spaces up to the next tab stop, e.g. every 4 columns",
+		Arg:         "width",
+		Apply: func(in, arg string) (string, error) {
+			width, err := parseWidth(arg)
+			if err != nil {
+				return "", err
+			}
+			return ExpandTabs(in, width), nil
+		},
+	})
+	Register(Transform{
+		Name:        "truncate",
+		Description: "Cut each line down to a width",
+		Arg:         "width",
+		Apply:       truncateLines(""),
+	})
+	Register(Transform{
+		Name:        "truncate-ellipsis",
+		Description: "Cut each line down to a width, ending cut lines with …",
+		Arg:         "width",
+		Apply:       truncateLines("…"),
+	})
+}
+
+// padLines pads lines to the width given by the argument, on the left if
+// left is set. Widths are measured in terminal cells, so wide characters
+// such as CJK count twice.
+func padLines(left bool) func(string, string) (string, error) {
+	return func(in, arg string) (string, error) {
+		ws, fill, hasFill := strings.Cut(arg, ":")
+		width, err := parseWidth(ws)
+		if err != nil {
+			return "", err
+		}
+		pad := " "
+		if hasFill {
+			if utf8.RuneCountInString(fill) != 1 || runewidth.StringWidth(fill) != 1 {
+				return "", fmt.Errorf("padding must be one narrow character, not %q", fill)
+			}
+			pad = fill
+		}
+		return lines(func(ls []string) []string {
+			for i, l := range ls {
+				n := width - runewidth.StringWidth(l)
+				if n <= 0 {
+					continue
+				}
+				if left {
+					ls[i] = strings.Repeat(pad, n) + l
+				} else {
+					ls[i] = l + strings.Repeat(pad, n)
+				}
+			}
+			return ls
+		})(in), nil
+	}
+}
+
+// truncateLines cuts lines down to the width given by the argument, ending
+// cut lines with tail.
+func truncateLines(tail string) func(string, string) (string, error) {
+	return func(in, arg string) (string, error) {
+		width, err := parseWidth(arg)
+		if err != nil {
+			return "", err
+		}
+		return lines(func(ls []string) []string {
+			for i, l := range ls {
+				ls[i] = runewidth.Truncate(l, width, tail)
+			}
+			return ls
+		})(in), nil
+	}
+}
+
+// ExpandTabs replaces the tabs in s with spaces up to the next tab stop,
+// every width cells. With a width of 0 tabs are removed.
+func ExpandTabs(s string, width int) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	var b strings.Builder
+	col := 0
+	for _, r := range s {
+		switch r {
+		case '\t':
+			n := width
+			if width > 0 {
+				n = width - col%width
+			}
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+		case '\n':
+			b.WriteRune(r)
+			col = 0
+		default:
+			b.WriteRune(r)
+			col += runewidth.RuneWidth(r)
+		}
+	}
+	return b.String()
+}
+
+func parseWidth(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid width %q", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,55 @@
+package transform
+
+import "testing"
+
+func TestTrim(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"trim", "", "  a \n\tb\t\n", "a\nb\n"},
+		{"trim-left", "", "  a \n\tb\t", "a \nb\t"},
+		{"trim-right", "", "  a \n\tb\t", "  a\n\tb"},
+		{"trim", "", " a　", "a"},
+	})
+}
+
+func TestPad(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"pad-right", "4", "a\nabcdef\n", "a   \nabcdef\n"},
+		{"pad-right", "6:.", "ab\nabc", "ab....\nabc..."},
+		{"pad-left", "3:0", "7\n42\n100", "007\n042\n100"},
+		{"pad-left", " 4 ", "日本", "日本"},
+		{"pad-left", "5", "日本", " 日本"},
+		{"pad-right", "2:·", "é", "é·"},
+	})
+}
+
+func TestExpandTabs(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"expand-tabs", "4", "a\tb\n\tc", "a   b\n    c"},
+		{"expand-tabs", "8", "abc\td", "abc     d"},
+		{"expand-tabs", "4", "日本\tx", "日本    x"},
+		{"expand-tabs", "4", "日本語\tx", "日本語  x"},
+		{"expand-tabs", "0", "a\tb", "ab"},
+		{"expand-tabs", "2", "no tabs", "no tabs"},
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"truncate", "3", "abcdef\nab\n", "abc\nab\n"},
+		{"truncate", "0", "abc", ""},
+		{"truncate", "3", "日本語", "日"},
+		{"truncate-ellipsis", "4", "abcdef\nabcd", "abc…\nabcd"},
+	})
+}
+
+func TestTrimErrors(t *testing.T) {
+	testErrors(t, []errorTest{
+		{"pad-right", "", "a", "invalid width"},
+		{"pad-left", "-1", "a", "invalid width"},
+		{"pad-left", "4:ab", "a", "one narrow character"},
+		{"pad-left", "4:日", "a", "one narrow character"},
+		{"pad-left", "4:", "a", "one narrow character"},
+		{"truncate", "x", "a", "invalid width"},
+		{"expand-tabs", "", "a", "invalid width"},
+	})
+}
@@ -0,0 +1,89 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/unicode/runenames"
+)
+
+func init() {
+	for _, f := range []struct {
+		form norm.Form
+		name string
+		desc string
+	}{
+		{norm.NFC, "nfc", "Normalize to NFC: composed characters, e.g. e + ◌́ to é"},
+		{norm.NFD, "nfd", "Normalize to NFD: decomposed characters, e.g. é to e + ◌́"},
+		{norm.NFKC, "nfkc", "Normalize to NFKC: composed, with compatibility characters such as ﬁ and ² replaced"},
+		{norm.NFKD, "nfkd", "Normalize to NFKD: decomposed, with compatibility characters replaced"},
+	} {
+		Register(Transform{Name: f.name, Description: f.desc, Apply: simple(f.form.String)})
+	}
+	Register(Transform{
+		Name:        "codepoints",
+		Description: "List each character's code point, name and UTF-8 bytes, one per line",
+		Apply: simple(func(s string) string {
+			var b strings.Builder
+			for _, r := range s {
+				b.WriteString(DescribeRune(r) + "\n")
+			}
+			return b.String()
+		}),
+	})
+}
+
+// DescribeRune gives the code point, a printable form, the Unicode name and
+// the UTF-8 bytes of r, e.g. "U+00E9  é  LATIN SMALL LETTER E WITH ACUTE
+// (c3 a9)", aligned for a list of characters.
+func DescribeRune(r rune) string {
+	return fmt.Sprintf("%-8s %s %s (% x)", fmt.Sprintf("U+%04X", r), runewidth.FillRight(PrintableRune(r), 3), RuneName(r), string(r))
+}
+
+// PrintableRune shows r so that it can be seen on its own: combining marks
+// on a dotted circle, and nothing for control and format characters.
+func PrintableRune(r rune) string {
+	switch {
+	case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r):
+		return "◌" + string(r)
+	case unicode.IsControl(r), unicode.Is(unicode.Cf, r), r == unicode.ReplacementChar:
+		return ""
+	}
+	return string(r)
+}
+
+// RuneName returns the Unicode name of r, or a description if it has none.
+func RuneName(r rune) string {
+	if name := runenames.Name(r); name != "" && !strings.HasPrefix(name, "<") {
+		return name
+	}
+	switch {
+	case unicode.IsControl(r):
+		return "<control>"
+	case r >= 0xD800 && r <= 0xDFFF:
+		return "<surrogate>"
+	case unicode.Is(unicode.Co, r):
+		return "<private use>"
+	}
+	return "<unassigned>"
+}
+
+// NormalizationForms lists the forms s is already in, e.g. "NFC, NFKC".
+func NormalizationForms(s string) string {
+	var forms []string
+	for _, f := range []struct {
+		form norm.Form
+		name string
+	}{{norm.NFC, "NFC"}, {norm.NFD, "NFD"}, {norm.NFKC, "NFKC"}, {norm.NFKD, "NFKD"}} {
+		if f.form.IsNormalString(s) {
+			forms = append(forms, f.name)
+		}
+	}
+	if len(forms) == 0 {
+		return "none"
+	}
+	return strings.Join(forms, ", ")
+}
@@ -0,0 +1,48 @@
+package transform
+
+import "testing"
+
+func TestNormalization(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"nfc", "", "e\u0301", "\u00e9"},
+		{"nfd", "", "\u00e9", "e\u0301"},
+		{"nfkc", "", "\ufb01x\u00b2", "fix2"},
+		{"nfkd", "", "\u00e9\u00b2", "e\u03012"},
+		{"nfc", "", "plain", "plain"},
+		{"codepoints", "", "a\u00e9", "U+0061   a   LATIN SMALL LETTER A (61)\nU+00E9   \u00e9   LATIN SMALL LETTER E WITH ACUTE (c3 a9)\n"},
+		{"codepoints", "", "\u0301\t", "U+0301   \u25cc\u0301   COMBINING ACUTE ACCENT (cc 81)\nU+0009       <control> (09)\n"},
+	})
+}
+
+func TestRuneName(t *testing.T) {
+	tests := []struct {
+		r    rune
+		want string
+	}{
+		{'A', "LATIN CAPITAL LETTER A"},
+		{'\U0001F44D', "THUMBS UP SIGN"},
+		{'\n', "<control>"},
+		{'\ue000', "<private use>"},
+		{0x0378, "<unassigned>"},
+	}
+	for _, tt := range tests {
+		if got := RuneName(tt.r); got != tt.want {
+			t.Errorf("RuneName(%U) = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizationForms(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"abc", "NFC, NFD, NFKC, NFKD"},
+		{"\u00e9", "NFC, NFKC"},
+		{"e\u0301", "NFD, NFKD"},
+		{"\ufb01", "NFC, NFD"},
+		{"\ufb01\u00e9e\u0301", "none"},
+	}
+	for _, tt := range tests {
+		if got := NormalizationForms(tt.in); got != tt.want {
+			t.Errorf("NormalizationForms(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
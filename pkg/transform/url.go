@@ -0,0 +1,63 @@
+package transform
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+func init() {
+	Register(Transform{
+		Name:        "url-encode",
+		Description: "Percent-encode for use in a query string",
+		Apply:       simple(url.QueryEscape),
+	})
+	Register(Transform{
+		Name:        "url-path-encode",
+		Description: "Percent-encode for use in a URL path",
+		Apply:       simple(url.PathEscape),
+	})
+	Register(Transform{
+		Name:        "url-decode",
+		Description: "Decode percent-encoding",
+		Apply: func(in, _ string) (string, error) {
+			return urlDecode(in)
+		},
+	})
+	Register(Transform{
+		Name:        "url-decode-all",
+		Description: "Decode percent-encoding repeatedly, for doubly encoded text",
+		Apply: func(in, _ string) (string, error) {
+			out, err := urlDecode(in)
+			if err != nil {
+				return "", err
+			}
+			// Only decode again while escapes remain, so a literal + that
+			// was encoded as %2B is not turned into a space.
+			for i := 1; i < maxDecodes && strings.Contains(out, "%"); i++ {
+				in = out
+				if out, err = urlDecode(in); err != nil {
+					// The % was literal text rather than an escape.
+					return in, nil
+				}
+			}
+			return out, nil
+		},
+	})
+}
+
+// maxDecodes bounds the number of times url-decode-all decodes.
+const maxDecodes = 16
+
+// urlDecode decodes percent-encoding, treating + as a space as query
+// strings do.
+func urlDecode(in string) (string, error) {
+	out, err := url.QueryUnescape(in)
+	if err != nil {
+		if e, ok := err.(url.EscapeError); ok {
+			return "", fmt.Errorf("invalid percent-encoding %q", string(e))
+		}
+		return "", err
+	}
+	return out, nil
+}
@@ -0,0 +1,24 @@
+package transform
+
+import "testing"
+
+func TestURL(t *testing.T) {
+	testOutputs(t, []outputTest{
+		{"url-encode", "", "a b&c=d/é", "a+b%26c%3Dd%2F%C3%A9"},
+		{"url-path-encode", "", "a b/c", "a%20b%2Fc"},
+		{"url-decode", "", "a+b%26c%3Dd%2F%C3%A9", "a b&c=d/é"},
+		{"url-decode", "", "%252F", "%2F"},
+		{"url-decode-all", "", "%252F", "/"},
+		{"url-decode-all", "", "1%252B1", "1+1"},
+		{"url-decode-all", "", "100%25 sure", "100% sure"},
+		{"url-decode-all", "", "plain", "plain"},
+	})
+}
+
+func TestURLErrors(t *testing.T) {
+	testErrors(t, []errorTest{
+		{"url-decode", "", "100%", `invalid percent-encoding "%"`},
+		{"url-decode", "", "%zz", `invalid percent-encoding "%zz"`},
+		{"url-decode-all", "", "%G1", "invalid percent-encoding"},
+	})
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strcli/pkg/diff"
+	"strcli/pkg/render"
+)
+
+var (
+	presentStyle = lipgloss.NewStyle().
+			Border(lipgloss.ThickBorder()).
+			Padding(1, 4)
+	presentTitleStyle  = lipgloss.NewStyle().Bold(true)
+	presentInsertStyle = lipgloss.NewStyle().Bold(true)
+	presentDeleteStyle = lipgloss.NewStyle().Bold(true).Strikethrough(true)
+)
+
+// presentation shows one hunk of the diff at a time, large and in high
+// contrast, for walking others through the changes.
+type presentation struct {
+	hunk int
+}
+
+func newPresentation(m *model) overlay {
+	if m.result == "" {
+		m.notice = "compare the panes before presenting"
+		return nil
+	}
+	if len(m.diff.Hunks) == 0 {
+		m.notice = "no changes to present"
+		return nil
+	}
+	return &presentation{}
+}
+
+func (p *presentation) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		return true, nil
+	case " ", "right", "down", "n", "enter", "pgdown":
+		p.hunk = min(p.hunk+1, len(m.diff.Hunks)-1)
+	case "left", "up", "p", "backspace", "pgup":
+		p.hunk = max(p.hunk-1, 0)
+	case "home", "g":
+		p.hunk = 0
+	case "end", "G":
+		p.hunk = len(m.diff.Hunks) - 1
+	}
+	return false, nil
+}
+
+func (p *presentation) view(m *model) string {
+	d := m.diff
+	h := d.Hunks[p.hunk]
+	var b strings.Builder
+	title := fmt.Sprintf("Change %d of %d · %s · A %s · B %s", p.hunk+1, len(d.Hunks), h.Kind, lineRange(h.A), lineRange(h.B))
+	b.WriteString(presentTitleStyle.Render(title) + "\n\n")
+
+	before, after := hunkContext(d, h)
+	body := before
+	for _, c := range h.Changes {
+		t := render.Text(d, c)
+		switch c.Op {
+		case diff.Insert:
+			body += markEach(t, presentInsertStyle)
+		case diff.Delete:
+			body += markEach(t, presentDeleteStyle)
+		default:
+			body += t
+		}
+	}
+	b.WriteString(strings.TrimSuffix(body+after, "\n") + "\n\n")
+	b.WriteString("space/→ next • ←/backspace previous • home/end first/last • esc close")
+	return presentStyle.Width(m.width - 4).Render(b.String())
+}
+
+// markEach styles every line of s separately, so the styling does not run
+// past line breaks, and shows changed line breaks as ↵.
+func markEach(s string, style lipgloss.Style) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		if i < len(lines)-1 {
+			l += "↵"
+		}
+		if l != "" {
+			lines[i] = style.Render(l)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hunkContext returns the unchanged text on the first line of h before it
+// and on its last line after it.
+func hunkContext(d diff.Diff, h diff.Hunk) (before, after string) {
+	first, last := h.Changes[0], h.Changes[len(h.Changes)-1]
+	for i, c := range d.Changes {
+		if c != first {
+			continue
+		}
+		if i > 0 && d.Changes[i-1].Op == diff.Equal {
+			t := render.Text(d, d.Changes[i-1])
+			before = t[strings.LastIndex(t, "\n")+1:]
+		}
+		j := i + len(h.Changes)
+		if j < len(d.Changes) && d.Changes[j-1] == last && d.Changes[j].Op == diff.Equal {
+			t := render.Text(d, d.Changes[j])
+			after, _, _ = strings.Cut(t, "\n")
+		}
+		break
+	}
+	return before, after
+}
+
+func lineRange(r diff.Range) string {
+	switch r.Len() {
+	case 0:
+		return fmt.Sprintf("before line %d", r.Start)
+	case 1:
+		return fmt.Sprintf("line %d", r.Start)
+	}
+	return fmt.Sprintf("lines %d–%d", r.Start, r.End-1)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/compare"
+	"strcli/pkg/diff"
+)
+
+func TestPresentation(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []tea.KeyMsg
+		want string
+	}{
+		{"first", nil, "Change 1 of 2 · modified · A line 1 · B line 1"},
+		{"next", []tea.KeyMsg{{Type: tea.KeySpace, Runes: []rune{' '}}}, "Change 2 of 2"},
+		{"stops at the last", []tea.KeyMsg{{Type: tea.KeyRight}, {Type: tea.KeyRight}}, "Change 2 of 2"},
+		{"back", []tea.KeyMsg{{Type: tea.KeyEnd}, {Type: tea.KeyLeft}}, "Change 1 of 2"},
+		{"stops at the first", []tea.KeyMsg{{Type: tea.KeyBackspace}}, "Change 1 of 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.width = 80
+			m.inputs[0].SetValue("one two\n2\n3\n4\n5\n6\n7\n8\n9\nten")
+			m.inputs[1].SetValue("one 2\n2\n3\n4\n5\n6\n7\n8\n9\n10")
+			m = settle(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyF7})
+			for _, k := range tt.keys {
+				m, _ = update(m, k)
+			}
+			if v := m.View(); !strings.Contains(v, tt.want) {
+				t.Errorf("shows\n%s\nwant %q in it", v, tt.want)
+			}
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+			if m.overlay != nil {
+				t.Error("esc does not close the presentation")
+			}
+		})
+	}
+}
+
+func TestLineRange(t *testing.T) {
+	for r, want := range map[diff.Range]string{
+		{Start: 3, End: 3}: "before line 3",
+		{Start: 3, End: 4}: "line 3",
+		{Start: 3, End: 6}: "lines 3–5",
+	} {
+		if got := lineRange(r); got != want {
+			t.Errorf("lineRange(%+v) = %q, want %q", r, got, want)
+		}
+	}
+}
+
+func TestPresentationNotices(t *testing.T) {
+	m := newModel()
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyF7})
+	if m.overlay != nil || m.notice != "compare the panes before presenting" {
+		t.Errorf("overlay %v, notice %q", m.overlay, m.notice)
+	}
+	m.inputs[0].SetValue("same")
+	m.inputs[1].SetValue("same")
+	m = settle(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyF7})
+	if m.overlay != nil || m.notice != "no changes to present" {
+		t.Errorf("overlay %v, notice %q", m.overlay, m.notice)
+	}
+}
+
+func TestHunkContext(t *testing.T) {
+	tests := []struct {
+		a, b          string
+		before, after string
+	}{
+		{"one two three\nfour", "one 2 three\nfour", "one ", " three"},
+		{"two\nx", "2\nx", "", ""},
+		{"a\nb two", "a\nb 2", "b ", ""},
+	}
+	for _, tt := range tests {
+		d := compare.Compare(tt.a, tt.b, compare.Options{}).Diff
+		before, after := hunkContext(d, d.Hunks[0])
+		if before != tt.before || after != tt.after {
+			t.Errorf("%q → %q: context %q, %q, want %q, %q", tt.a, tt.b, before, after, tt.before, tt.after)
+		}
+	}
+}
+
+func TestMarkEach(t *testing.T) {
+	got := markEach("ab\n\ncd", presentInsertStyle.UnsetBold().UnsetBackground().UnsetForeground())
+	if got != "ab↵\n↵\ncd" {
+		t.Errorf("got %q", got)
+	}
+}
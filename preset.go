@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"strcli/pkg/preset"
+)
+
+// lookupPreset returns the preset called name, or no preset if name is empty.
+func lookupPreset(name string) (preset.Preset, error) {
+	if name == "" {
+		return preset.Preset{}, nil
+	}
+	p, ok := preset.Lookup(name)
+	if !ok {
+		return p, fmt.Errorf("unknown preset %q", name)
+	}
+	return p, nil
+}
+
+// presetMenu lists every preset. Choosing one uses it for all following
+// comparisons and compares right away.
+func presetMenu() *menu {
+	mn := newMenu("Presets", presetItems())
+	mn.list.AdditionalShortHelpKeys = nil
+	return mn
+}
+
+// presetItems returns the entries of presetMenu.
+func presetItems() []menuItem {
+	items := []menuItem{{
+		title: "none",
+		desc:  "Compare the inputs as they are",
+		run: func(m *model, _ bool) tea.Cmd {
+			m.options.Preset = preset.Preset{}
+			return m.startCompare()
+		},
+	}}
+	for _, p := range preset.All() {
+		p := p
+		items = append(items, menuItem{
+			title: p.Name,
+			desc:  p.Description,
+			run: func(m *model, _ bool) tea.Cmd {
+				m.options.Preset = p
+				return m.startCompare()
+			},
+		})
+	}
+	return items
+}
+
+// completePresets completes the names of presets.
+func completePresets(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	for _, p := range preset.All() {
+		names = append(names, p.Name+"\t"+p.Description)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPresetMenu(t *testing.T) {
+	tests := []struct {
+		choose     string
+		wantPreset string
+		wantEqual  bool
+	}{
+		{"dbdump", "dbdump", true},
+		{"none", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.choose, func(t *testing.T) {
+			m := newModel()
+			m.inputs[0].SetValue("id,name\n1,amy\n")
+			m.inputs[1].SetValue("id,name\n2,amy\n")
+			m, _ = update(m, alt('p'))
+			selectItem(t, m.overlay.(*menu), tt.choose)
+			m = settle(m, tea.KeyMsg{Type: tea.KeyEnter})
+			if m.options.Preset.Name != tt.wantPreset {
+				t.Errorf("preset = %q, want %q", m.options.Preset.Name, tt.wantPreset)
+			}
+			if m.diff.Equal() != tt.wantEqual {
+				t.Errorf("Equal() = %v, want %v", m.diff.Equal(), tt.wantEqual)
+			}
+		})
+	}
+}
+
+func TestPresetFlag(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.csv"), filepath.Join(dir, "b.csv")
+	os.WriteFile(a, []byte("id,name\n1,amy\n2,bob\n"), 0o644)
+	os.WriteFile(b, []byte("id,name\n9,bob\n8,amy\n"), 0o644)
+	manifest := filepath.Join(dir, "m.csv")
+	os.WriteFile(manifest, []byte("a.csv,b.csv\n"), 0o644)
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"compare without preset", []string{"compare", "-q", a, b}, exitDiffer},
+		{"compare with preset", []string{"compare", "-q", "-p", "dbdump", a, b}, exitSame},
+		{"batch with preset", []string{"batch", "-q", "--preset", "dbdump", manifest}, exitSame},
+		{"unknown preset", []string{"compare", "-q", "-p", "nope", a, b}, exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, code := runMain(t, "", tt.args...); code != tt.want {
+				t.Errorf("exit status = %d, want %d", code, tt.want)
+			}
+		})
+	}
+}
+
+func TestPresetReport(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")
+	os.WriteFile(a, []byte("      flat  flat%   sum%        cum   cum%\n     1.00s 100% 100%      1.00s 100%  main.run\n"), 0o644)
+	os.WriteFile(b, []byte("      flat  flat%   sum%        cum   cum%\n     2.00s 100% 100%      2.00s 100%  main.run\n"), 0o644)
+
+	tests := []struct {
+		format string
+		want   bool
+	}{
+		{"plain", true},
+		{"json", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out, code := runMain(t, "", "compare", "-p", "pprof", "--format", tt.format, a, b)
+			if code != exitSame {
+				t.Errorf("exit status = %d, want %d", code, exitSame)
+			}
+			if got := strings.Contains(out, "main.run  1.00s   2.00s   +100.0%"); got != tt.want {
+				t.Errorf("report printed = %v, want %v; output:\n%s", got, tt.want, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// privacyScreen hides the panes until a key is pressed.
+type privacyScreen struct{}
+
+func (privacyScreen) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	return true, nil
+}
+
+func (privacyScreen) view(m *model) string {
+	return overlayStyle.Render(overlayTitleStyle.Render("Hidden") + "\n\nThe panes are hidden.\nPress any key to show them.")
+}
+
+// idleMsg asks the model to check whether it has been idle long enough to
+// hide the panes.
+type idleMsg struct{}
+
+// idleCmd waits for d and then checks for idleness.
+func idleCmd(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return idleMsg{} })
+}
+
+// checkIdle hides the panes if no key was pressed for m.lockAfter, and
+// otherwise checks again when that time would be up.
+func (m *model) checkIdle() tea.Cmd {
+	idle := time.Since(m.lastKey)
+	if idle < m.lockAfter {
+		return idleCmd(m.lockAfter - idle)
+	}
+	if m.overlay == nil {
+		m.overlay = privacyScreen{}
+	}
+	return idleCmd(m.lockAfter)
+}
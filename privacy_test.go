@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestHideKey(t *testing.T) {
+	m := newModel()
+	m.inputs[0].SetValue("secret")
+	m, _ = update(m, alt('h'))
+	if v := m.View(); strings.Contains(v, "secret") || !strings.Contains(v, "The panes are hidden") {
+		t.Fatalf("the panes are not hidden:\n%s", v)
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if v := m.View(); !strings.Contains(v, "secret") {
+		t.Errorf("the panes are still hidden after a key:\n%s", v)
+	}
+}
+
+func TestCheckIdle(t *testing.T) {
+	tests := []struct {
+		name   string
+		idle   time.Duration
+		hidden bool
+	}{
+		{"busy", time.Second, false},
+		{"idle", time.Hour, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.lockAfter = time.Minute
+			m.lastKey = time.Now().Add(-tt.idle)
+			if cmd := m.checkIdle(); cmd == nil {
+				t.Error("idleness is no longer checked")
+			}
+			if _, hidden := m.overlay.(privacyScreen); hidden != tt.hidden {
+				t.Errorf("hidden = %v, want %v", hidden, tt.hidden)
+			}
+		})
+	}
+}
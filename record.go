@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// recorder writes every distinct screen of a session to an asciicast v2
+// file, which asciinema can replay and tools such as agg turn into a GIF.
+type recorder struct {
+	f     *os.File
+	start time.Time
+	last  string
+	err   error
+}
+
+func newRecorder(path string) (*recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &recorder{f: f}, nil
+}
+
+// frame records view unless it is the same as the last one. The header is
+// written with the first frame, once the size of the screen is known.
+func (r *recorder) frame(view string, width, height int) {
+	if r.err != nil || view == r.last || width == 0 {
+		return
+	}
+	if r.start.IsZero() {
+		r.start = time.Now()
+		r.write(map[string]any{"version": 2, "width": width, "height": height, "timestamp": r.start.Unix()})
+	}
+	r.last = view
+	screen := "\x1b[H\x1b[2J" + strings.ReplaceAll(view, "\n", "\r\n")
+	r.write([]any{time.Since(r.start).Seconds(), "o", screen})
+}
+
+func (r *recorder) write(v any) {
+	if r.err != nil {
+		return
+	}
+	b, err := json.Marshal(v)
+	if err == nil {
+		_, err = fmt.Fprintf(r.f, "%s\n", b)
+	}
+	r.err = err
+}
+
+// close finishes the recording and reports the first error it ran into.
+func (r *recorder) close() error {
+	if err := r.f.Close(); r.err == nil {
+		r.err = err
+	}
+	return r.err
+}
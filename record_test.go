@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.cast")
+	r, err := newRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.frame("before the size is known", 0, 0)
+	r.frame("one\ntwo", 80, 24)
+	r.frame("one\ntwo", 80, 24)
+	r.frame("three", 80, 24)
+	if err := r.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines []string
+	for s := bufio.NewScanner(f); s.Scan(); {
+		lines = append(lines, s.Text())
+	}
+	if len(lines) != 3 {
+		t.Fatalf("recorded %d lines, want a header and 2 frames:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	var header struct{ Version, Width, Height int }
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatal(err)
+	}
+	if header.Version != 2 || header.Width != 80 || header.Height != 24 {
+		t.Errorf("header %+v", header)
+	}
+	for i, want := range []string{"\x1b[H\x1b[2Jone\r\ntwo", "\x1b[H\x1b[2Jthree"} {
+		var event []any
+		if err := json.Unmarshal([]byte(lines[i+1]), &event); err != nil {
+			t.Fatal(err)
+		}
+		if len(event) != 3 || event[1] != "o" || event[2] != want {
+			t.Errorf("frame %d is %q, want output %q", i+1, event, want)
+		}
+	}
+}
+
+func TestRecorderCreateError(t *testing.T) {
+	if _, err := newRecorder(filepath.Join(t.TempDir(), "missing", "session.cast")); err == nil {
+		t.Error("recording into a missing directory did not fail")
+	}
+}
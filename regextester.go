@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	// regexMatchStyle marks a whole match and groupStyles its capture
+	// groups, taking turns for groups past the last style.
+	regexMatchStyle = lipgloss.NewStyle()
+	groupStyles     []lipgloss.Style
+)
+
+// regexTester highlights the matches of a regular expression and its
+// capture groups in a sample text as either is edited.
+type regexTester struct {
+	pattern textinput.Model
+	text    textarea.Model
+	// editingText is set while keys go to the sample text.
+	editingText bool
+}
+
+func newRegexTester(m *model) overlay {
+	r := &regexTester{pattern: textinput.New(), text: textarea.New()}
+	r.pattern.Prompt = "pattern: "
+	r.pattern.Placeholder = "a Go regular expression, e.g. (?P<key>\\w+)=(\\d+)"
+	r.pattern.Focus()
+	r.text.CharLimit = 0
+	r.text.MaxHeight = 0
+	r.text.ShowLineNumbers = false
+	r.text.Placeholder = "Text to match against"
+	if m.focus <= 1 {
+		r.text.SetValue(m.inputs[m.focus].Value())
+	}
+	return r
+}
+
+func (r *regexTester) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		return true, nil
+	case tea.KeyTab, tea.KeyShiftTab:
+		r.editingText = !r.editingText
+		if r.editingText {
+			r.pattern.Blur()
+			return false, r.text.Focus()
+		}
+		r.text.Blur()
+		return false, r.pattern.Focus()
+	}
+	var cmd tea.Cmd
+	if r.editingText {
+		r.text, cmd = r.text.Update(msg)
+	} else {
+		r.pattern, cmd = r.pattern.Update(msg)
+	}
+	return false, cmd
+}
+
+func (r *regexTester) view(m *model) string {
+	width := m.width - 6
+	r.pattern.Width = width - len(r.pattern.Prompt) - 1
+	r.text.SetWidth(width)
+	r.text.SetHeight(max(m.height/4, 3))
+
+	var b strings.Builder
+	b.WriteString(overlayTitleStyle.Render("Regex tester") + "\n\n")
+	b.WriteString(r.pattern.View() + "\n\n")
+	b.WriteString(r.text.View() + "\n\n")
+	budget := max(m.height-m.height/4-14, 4)
+	b.WriteString(lipgloss.NewStyle().MaxWidth(width).Render(r.results(budget)) + "\n\n")
+	b.WriteString("tab switch between pattern and text • esc close")
+	return overlayStyle.Render(b.String())
+}
+
+// results highlights the matches in the sample text and explains where
+// each match and group is, using at most about limit lines.
+func (r *regexTester) results(limit int) string {
+	if r.pattern.Value() == "" {
+		return "Type a pattern to see its matches."
+	}
+	re, err := regexp.Compile(r.pattern.Value())
+	if err != nil {
+		return errorStyle.Render(err.Error())
+	}
+	text := r.text.Value()
+	matches := re.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return "No matches"
+	}
+
+	var b strings.Builder
+	lines := strings.Split(highlightMatches(text, matches), "\n")
+	shown := min(len(lines), limit/2)
+	b.WriteString(strings.Join(lines[:shown], "\n"))
+	if shown < len(lines) {
+		fmt.Fprintf(&b, "\n… %d more lines", len(lines)-shown)
+	}
+
+	fmt.Fprintf(&b, "\n\n%d matches", len(matches))
+	names := re.SubexpNames()
+	left := limit - shown
+	for i, loc := range matches {
+		if left <= 0 {
+			fmt.Fprintf(&b, "\n… and %d more", len(matches)-i)
+			break
+		}
+		fmt.Fprintf(&b, "\n#%d %s %q", i+1, position(text, loc[0], loc[1]), text[loc[0]:loc[1]])
+		left--
+		for g := 1; g < len(names); g++ {
+			name := fmt.Sprintf("$%d", g)
+			if names[g] != "" {
+				name += " " + names[g]
+			}
+			style := groupStyles[(g-1)%len(groupStyles)]
+			if loc[2*g] < 0 {
+				fmt.Fprintf(&b, "\n   %s did not take part", style.Render(name))
+			} else {
+				fmt.Fprintf(&b, "\n   %s %s %q", style.Render(name), position(text, loc[2*g], loc[2*g+1]), text[loc[2*g]:loc[2*g+1]])
+			}
+			left--
+		}
+	}
+	return b.String()
+}
+
+// position describes the span of text from byte start to end by line, the
+// first and last column, and the bytes as a half-open range.
+func position(text string, start, end int) string {
+	line := strings.Count(text[:start], "\n") + 1
+	col := utf8.RuneCountInString(text[strings.LastIndex(text[:start], "\n")+1:start]) + 1
+	if start == end {
+		return fmt.Sprintf("line %d, col %d, empty at byte %d", line, col, start)
+	}
+	return fmt.Sprintf("line %d, col %d–%d, bytes %d–%d", line, col, col+utf8.RuneCountInString(text[start:end])-1, start, end)
+}
+
+// highlightMatches styles each match of text and, within it, each capture
+// group. Nested groups win over the groups around them.
+func highlightMatches(text string, matches [][]int) string {
+	// style[i] is 0 for unmatched bytes, 1 for matched bytes outside any
+	// group and 1+g for bytes in group g.
+	style := make([]int, len(text))
+	for _, loc := range matches {
+		for g := 0; 2*g < len(loc); g++ {
+			for i := max(loc[2*g], 0); i < loc[2*g+1]; i++ {
+				style[i] = 1 + g
+			}
+		}
+	}
+	var b strings.Builder
+	for start := 0; start < len(text); {
+		end := start
+		for end < len(text) && style[end] == style[start] {
+			end++
+		}
+		for i, seg := range strings.Split(text[start:end], "\n") {
+			if i > 0 {
+				b.WriteByte('\n')
+			}
+			switch s := style[start]; {
+			case seg == "" || s == 0:
+				b.WriteString(seg)
+			case s == 1:
+				b.WriteString(regexMatchStyle.Render(seg))
+			default:
+				b.WriteString(groupStyles[(s-2)%len(groupStyles)].Render(seg))
+			}
+		}
+		start = end
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPosition(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		start, end int
+		want       string
+	}{
+		{"first line", "key=1", 0, 3, "line 1, col 1–3, bytes 0–3"},
+		{"later line", "a\nb\u00df=c", 2, 5, "line 2, col 1–2, bytes 2–5"},
+		{"after a rune", "\u00df=c", 2, 3, "line 1, col 2–2, bytes 2–3"},
+		{"empty", "ab\ncd", 4, 4, "line 2, col 2, empty at byte 4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := position(tt.text, tt.start, tt.end); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegexTesterResults(t *testing.T) {
+	tests := []struct {
+		name, pattern, text string
+		limit               int
+		want                string
+	}{
+		{"no pattern", "", "x", 10, "Type a pattern to see its matches."},
+		{"invalid", "(", "x", 10, "missing closing )"},
+		{"no matches", "z", "x", 10, "No matches"},
+		{"groups", `(?P<key>\w+)=(\d+)?`, "a=1\nb=", 20, `a=1
+b=
+
+2 matches
+#1 line 1, col 1–3, bytes 0–3 "a=1"
+   $1 key line 1, col 1–1, bytes 0–1 "a"
+   $2 line 1, col 3–3, bytes 2–3 "1"
+#2 line 2, col 1–2, bytes 4–6 "b="
+   $1 key line 2, col 1–1, bytes 4–5 "b"
+   $2 did not take part`},
+		{"limited", `\d`, "1\n2\n3\n4", 4, `1
+2
+… 2 more lines
+
+4 matches
+#1 line 1, col 1–1, bytes 0–1 "1"
+#2 line 2, col 1–1, bytes 2–3 "2"
+… and 2 more`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRegexTester(&model{session: session{focus: 2}}).(*regexTester)
+			r.pattern.SetValue(tt.pattern)
+			r.text.SetValue(tt.text)
+			if got := r.results(tt.limit); !strings.Contains(got, tt.want) {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegexTesterKeys(t *testing.T) {
+	m := newModel()
+	m.inputs[0].SetValue("abc")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyF8})
+	r, ok := m.overlay.(*regexTester)
+	if !ok {
+		t.Fatalf("overlay %T, want the regex tester", m.overlay)
+	}
+	if r.text.Value() != "abc" {
+		t.Errorf("sample text %q, want that of pane A", r.text.Value())
+	}
+	m = typeText(m, "b")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyTab})
+	m = typeText(m, "b")
+	if r.pattern.Value() != "b" || r.text.Value() != "abcb" {
+		t.Errorf("pattern %q, text %q", r.pattern.Value(), r.text.Value())
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.overlay != nil {
+		t.Error("esc does not close the tester")
+	}
+	if m.inputs[0].Value() != "abc" {
+		t.Errorf("pane A changed to %q", m.inputs[0].Value())
+	}
+}
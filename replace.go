@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	findStyle    = lipgloss.NewStyle().Strikethrough(true)
+	replaceStyle = lipgloss.NewStyle()
+)
+
+// findReplace replaces the matches of a regular expression in the focused
+// pane, previewing each replacement as the pattern is typed.
+type findReplace struct {
+	pane int
+	// fields are the pattern and its replacement; focused is the one being
+	// edited.
+	fields  [2]textinput.Model
+	focused int
+}
+
+func newFindReplace(m *model) overlay {
+	if m.focus > 1 {
+		m.err = errNoInputPane
+		return nil
+	}
+	f := &findReplace{pane: m.focus}
+	for i, p := range []string{"find: ", "replace: "} {
+		f.fields[i] = textinput.New()
+		f.fields[i].Prompt = p
+	}
+	f.fields[1].Placeholder = "$1 or ${name} inserts a group"
+	f.fields[0].Focus()
+	return f
+}
+
+func (f *findReplace) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEsc:
+		return true, nil
+	case msg.Type == tea.KeyTab, msg.Type == tea.KeyShiftTab, msg.Type == tea.KeyUp, msg.Type == tea.KeyDown:
+		f.fields[f.focused].Blur()
+		f.focused = 1 - f.focused
+		return false, f.fields[f.focused].Focus()
+	case key.Matches(msg, menuChoose), key.Matches(msg, menuSplit):
+		re, err := regexp.Compile(f.fields[0].Value())
+		if err != nil || f.fields[0].Value() == "" {
+			return false, nil
+		}
+		target := f.pane
+		if key.Matches(msg, menuSplit) {
+			target = 1 - f.pane
+		}
+		in := m.inputs[f.pane].Value()
+		n := len(re.FindAllStringIndex(in, -1))
+		m.replacePane(target, re.ReplaceAllString(in, f.fields[1].Value()))
+		m.stats.recordTransform("replace")
+		m.notice = fmt.Sprintf("replaced %d matches", n)
+		return true, nil
+	}
+	var cmd tea.Cmd
+	f.fields[f.focused], cmd = f.fields[f.focused].Update(msg)
+	return false, cmd
+}
+
+func (f *findReplace) view(m *model) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", overlayTitleStyle.Render(fmt.Sprintf("Find and replace in pane %c", 'A'+f.pane)))
+	for i := range f.fields {
+		f.fields[i].Width = m.width - 20
+		b.WriteString(f.fields[i].View() + "\n")
+	}
+	b.WriteString("\n" + f.preview(m.inputs[f.pane].Value(), max(m.height-12, 1)) + "\n\n")
+	b.WriteString("tab switch field • enter replace all • alt+enter to other pane • esc close")
+	return overlayStyle.Render(b.String())
+}
+
+// preview shows up to limit replacements in text, each on the line it
+// happens on.
+func (f *findReplace) preview(text string, limit int) string {
+	pattern := f.fields[0].Value()
+	if pattern == "" {
+		return "Type a Go regular expression, e.g. (\\w+)@example\\.com"
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return errorStyle.Render(err.Error())
+	}
+	matches := re.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return "No matches"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d matches", len(matches))
+	for i, loc := range matches {
+		if i == limit {
+			fmt.Fprintf(&b, "\n… and %d more", len(matches)-limit)
+			break
+		}
+		start := strings.LastIndex(text[:loc[0]], "\n") + 1
+		end := loc[1] + strings.IndexByte(text[loc[1]:]+"\n", '\n')
+		repl := re.ExpandString(nil, f.fields[1].Value(), text, loc)
+		fmt.Fprintf(&b, "\n%4d  %s%s%s%s", strings.Count(text[:loc[0]], "\n")+1,
+			text[start:loc[0]],
+			findStyle.Render(text[loc[0]:loc[1]]),
+			replaceStyle.Render(string(repl)),
+			text[loc[1]:end])
+	}
+	return b.String()
+}
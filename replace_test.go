@@ -0,0 +1,90 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// typeText passes the runes of s to m as key presses.
+func typeText(m model, s string) model {
+	for _, r := range s {
+		m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestFindReplace(t *testing.T) {
+	tests := []struct {
+		name, find, replace string
+		key                 tea.KeyMsg
+		want                [2]string
+		notice              string
+	}{
+		{"replace all", "o", "0", tea.KeyMsg{Type: tea.KeyEnter}, [2]string{"f00 b0b", ""}, "replaced 3 matches"},
+		{"groups", `(\w)(\w+)`, "$2$1", tea.KeyMsg{Type: tea.KeyEnter}, [2]string{"oof obb", ""}, "replaced 2 matches"},
+		{"to the other pane", "b", "B", tea.KeyMsg{Type: tea.KeyEnter, Alt: true}, [2]string{"foo bob", "foo BoB"}, "replaced 2 matches"},
+		{"no matches", "x", "y", tea.KeyMsg{Type: tea.KeyEnter}, [2]string{"foo bob", ""}, "replaced 0 matches"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.inputs[0].SetValue("foo bob")
+			m, _ = update(m, alt('s'))
+			m = typeText(m, tt.find)
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyTab})
+			m = typeText(m, tt.replace)
+			m, _ = update(m, tt.key)
+			if m.overlay != nil {
+				t.Fatal("the screen is still open")
+			}
+			if got := [2]string{m.inputs[0].Value(), m.inputs[1].Value()}; got != tt.want {
+				t.Errorf("panes %q, want %q", got, tt.want)
+			}
+			if m.notice != tt.notice {
+				t.Errorf("notice %q, want %q", m.notice, tt.notice)
+			}
+		})
+	}
+}
+
+func TestFindReplaceInvalidPattern(t *testing.T) {
+	m := newModel()
+	m.inputs[0].SetValue("foo")
+	m, _ = update(m, alt('s'))
+	m = typeText(m, "(o")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.overlay == nil {
+		t.Fatal("an invalid pattern closed the screen")
+	}
+	if v := m.View(); !strings.Contains(v, "missing closing )") {
+		t.Errorf("shows\n%s\nwithout the error", v)
+	}
+	if m.inputs[0].Value() != "foo" {
+		t.Errorf("pane A changed to %q", m.inputs[0].Value())
+	}
+}
+
+func TestFindReplacePreview(t *testing.T) {
+	tests := []struct {
+		name, find, replace string
+		limit               int
+		want                string
+	}{
+		{"empty", "", "", 5, `Type a Go regular expression, e.g. (\w+)@example\.com`},
+		{"matches", "o+", "0", 5, "3 matches\n   1  foo0 bob\n   1  foo bo0b\n   2  yo0"},
+		{"limited", "o", "0", 1, "4 matches\n   1  fo0o bob\n… and 3 more"},
+		{"none", "z", "", 5, "No matches"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFindReplace(&model{}).(*findReplace)
+			f.fields[0].SetValue(tt.find)
+			f.fields[1].SetValue(tt.replace)
+			if got := f.preview("foo bob\nyo", tt.limit); got != tt.want {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// resultPane shows the colored result in a scrollable viewport. It is
+// drawn in place of the result text area, which only keeps the focus.
+type resultPane struct {
+	view viewport.Model
+	// content is the result wrapped to width, as set on view, and text
+	// and query what it was made from.
+	content string
+	text    string
+	width   int
+	query   string
+}
+
+// sizeResult sizes the result pane to w by h cells, with the frame of the
+// text areas around it.
+func (m *model) sizeResult(w, h int) {
+	r := &m.results
+	r.view.Width = max(w-focusedBorderStyle.GetHorizontalFrameSize(), 1)
+	r.view.Height = max(h, 1)
+	m.refreshResult()
+}
+
+// refreshResult wraps the result to the width of the result pane, unless
+// it already is.
+func (m *model) refreshResult() {
+	r := &m.results
+	query := ""
+	if m.search != nil && m.search.re != nil {
+		query = m.search.re.String()
+	}
+	if r.text == m.result && r.width == r.view.Width && r.query == query {
+		return
+	}
+	r.text, r.width, r.query = m.result, r.view.Width, query
+	r.content = lipgloss.NewStyle().Width(r.width).Render(m.result)
+	shown := r.content
+	if query != "" {
+		// Matches are marked on the plain text of a line, which loses the
+		// colors of the diff on lines with a match.
+		lines := strings.Split(shown, "\n")
+		for n, l := range lines {
+			if plain := escapePattern.ReplaceAllString(l, ""); m.search.re.MatchString(plain) {
+				lines[n] = m.search.highlight(plain)
+			}
+		}
+		shown = strings.Join(lines, "\n")
+	}
+	r.view.SetContent(shown)
+}
+
+// resultView draws the result pane.
+func (m *model) resultView() string {
+	t := m.inputs[len(m.inputs)-1]
+	style := t.BlurredStyle.Base
+	if t.Focused() {
+		style = t.FocusedStyle.Base
+	}
+	return style.Render(m.results.view.View())
+}
+
+// scrollResult scrolls the result pane for a key: the arrow keys, page up
+// and down, home and end, and the viewport's own keys such as j and k.
+func (m *model) scrollResult(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "home", "g":
+		m.results.view.GotoTop()
+		return nil
+	case "end", "G":
+		m.results.view.GotoBottom()
+		return nil
+	}
+	var cmd tea.Cmd
+	m.results.view, cmd = m.results.view.Update(msg)
+	return cmd
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// longResult returns a sized model showing a result of n numbered lines,
+// with the result pane focused.
+func longResult(n int) model {
+	m := newModel()
+	m, _ = update(m, tea.WindowSizeMsg{Width: 80, Height: 30})
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	m.setResult(strings.Join(lines, "\n"))
+	m.focus = len(m.inputs) - 1
+	return m
+}
+
+func TestResultScroll(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []tea.KeyMsg
+		top  int
+	}{
+		{"down", []tea.KeyMsg{{Type: tea.KeyDown}, {Type: tea.KeyDown}}, 2},
+		{"down and up", []tea.KeyMsg{{Type: tea.KeyDown}, {Type: tea.KeyUp}}, 0},
+		{"end", []tea.KeyMsg{{Type: tea.KeyEnd}}, 100 - resultHeight},
+		{"G", []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'G'}}}, 100 - resultHeight},
+		{"end and home", []tea.KeyMsg{{Type: tea.KeyEnd}, {Type: tea.KeyHome}}, 0},
+		{"page down", []tea.KeyMsg{{Type: tea.KeyPgDown}}, resultHeight},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := longResult(100)
+			for _, k := range tt.keys {
+				m, _ = update(m, k)
+			}
+			if got := m.results.view.YOffset; got != tt.top {
+				t.Errorf("scrolled to %d, want %d", got, tt.top)
+			}
+			if !strings.Contains(m.resultView(), fmt.Sprintf("line %d ", tt.top+1)) {
+				t.Errorf("line %d is not shown", tt.top+1)
+			}
+		})
+	}
+}
+
+func TestResultWheel(t *testing.T) {
+	m := longResult(100)
+	m.focus = 0
+	r := m.paneRects()[2]
+	m, _ = update(m, tea.MouseMsg{X: r.x + 1, Y: r.y + 1, Button: tea.MouseButtonWheelDown})
+	if got := m.results.view.YOffset; got != wheelLines {
+		t.Errorf("the wheel scrolled to %d, want %d", got, wheelLines)
+	}
+	if m.focus != 0 {
+		t.Error("the wheel moved the focus")
+	}
+}
+
+func TestResultWraps(t *testing.T) {
+	m := longResult(0)
+	m.setResult(strings.Repeat("word ", 40))
+	if w := lipgloss.Width(m.resultView()); w != 80 {
+		t.Errorf("the result pane is %d wide", w)
+	}
+	if m.results.view.TotalLineCount() < 2 {
+		t.Error("a long result is not wrapped")
+	}
+	// Keys for the result pane do not reach the input panes.
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if m.inputs[0].Value() != "" || m.inputs[1].Value() != "" {
+		t.Error("typing in the result pane edits an input pane")
+	}
+}
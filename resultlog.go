@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"strcli/pkg/compare"
+)
+
+var logLabelStyle = lipgloss.NewStyle().Bold(true)
+
+// toggleAppend switches between replacing the result with each comparison
+// and appending labeled results to a log. Turning it on starts a new log.
+func (m *model) toggleAppend() {
+	m.appending = !m.appending
+	m.resultLog = nil
+}
+
+// appendResult adds res to the result log and returns the colored log to
+// show in the result pane.
+func (m *model) appendResult(res compare.Result) string {
+	labels := m.paneLabels()
+	label := fmt.Sprintf("── #%d %s vs %s · %s ──", len(m.resultLog)+1, labels[0], labels[1], time.Now().Format("15:04:05"))
+	m.resultLog = append(m.resultLog, label+"\n"+res.Report+m.render(res.Diff))
+	out := logLabelStyle.Render(label) + "\n" + res.Report + m.colorDiff(res.Diff)
+	if len(m.resultLog) == 1 {
+		return out
+	}
+	return m.result + "\n" + out
+}
+
+// exportedResult returns what the export key writes: the result log when
+// appending, otherwise the last diff.
+func (m *model) exportedResult() string {
+	if m.appending && len(m.resultLog) > 0 {
+		return strings.Join(m.resultLog, "\n")
+	}
+	return m.render(m.diff)
+}
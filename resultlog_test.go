@@ -0,0 +1,46 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAppendResults(t *testing.T) {
+	m := newModel()
+	m.paths[0] = "/tmp/old.txt"
+	m.inputs[0].SetValue("one")
+	m.inputs[1].SetValue("two")
+	m, _ = update(m, alt('a'))
+	m = settle(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	m.inputs[1].SetValue("one")
+	m = settle(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+
+	if len(m.resultLog) != 2 {
+		t.Fatalf("logged %d results, want 2", len(m.resultLog))
+	}
+	if !strings.Contains(m.View(), "appending results (2)") {
+		t.Error("the help line does not show the log")
+	}
+	label := regexp.MustCompile(`── #(\d) old\.txt vs B · \d\d:\d\d:\d\d ──\n`)
+	exported := m.exportedResult()
+	if got := label.FindAllStringSubmatch(exported, -1); len(got) != 2 || got[0][1] != "1" || got[1][1] != "2" {
+		t.Errorf("exported\n%s\nwant results #1 and #2", exported)
+	}
+	if !strings.Contains(exported, "{+tw+}o[-ne-]") {
+		t.Errorf("exported\n%s\nwithout the first diff", exported)
+	}
+	if r := m.result; !strings.Contains(r, "#1 old.txt") || !strings.Contains(r, "#2 old.txt") {
+		t.Errorf("result pane\n%s\nwant both results", r)
+	}
+
+	m, _ = update(m, alt('a'))
+	if m.appending || m.resultLog != nil {
+		t.Error("switching off does not drop the log")
+	}
+	if got := m.exportedResult(); got != m.render(m.diff) {
+		t.Errorf("exported %q, want the last diff", got)
+	}
+}
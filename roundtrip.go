@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"strcli/pkg/compare"
+	"strcli/pkg/render"
+	"strcli/pkg/transform"
+)
+
+func newRoundTripCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "roundtrip [NAME [FILE]]",
+		Short: "Check whether decoding and re-encoding a file is lossless, or list checks",
+		Long: `Decode FILE with the encoding NAME, encode it again and report whether the
+result matches. If it does not, the differences are printed. The exit status
+is 0 if the round trip is lossless, 1 if it is not and 2 on error.`,
+		Args: cobra.MaximumNArgs(2),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			var names []string
+			for _, rt := range transform.RoundTrips() {
+				names = append(names, rt.Name+"\t"+rt.Description)
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+				for _, rt := range transform.RoundTrips() {
+					fmt.Fprintf(w, "%s\t%s\n", rt.Name, rt.Description)
+				}
+				return w.Flush()
+			}
+			rt, ok := transform.LookupRoundTrip(args[0])
+			if !ok {
+				return fmt.Errorf("unknown round trip %q", args[0])
+			}
+			in, err := readInput(fileArg(args[1:]))
+			if err != nil {
+				return err
+			}
+			before, after, err := rt.Check(in)
+			if err != nil {
+				return err
+			}
+			if before == after {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s round trip is lossless\n", rt.Name)
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s round trip changes the text:\n", rt.Name)
+			fmt.Fprint(cmd.OutOrStdout(), render.Plain(compare.Compare(before, after, compare.Options{}).Diff))
+			return errDiffer
+		},
+	}
+	return cmd
+}
+
+// roundTripMenu lists every round trip check. Choosing one runs it on the
+// focused pane and shows what the round trip changed as the diff.
+func roundTripMenu() *menu {
+	mn := newMenu("Round trip checks", roundTripItems())
+	mn.list.AdditionalShortHelpKeys = nil
+	return mn
+}
+
+// roundTripItems returns the entries of roundTripMenu.
+func roundTripItems() []menuItem {
+	var items []menuItem
+	for _, rt := range transform.RoundTrips() {
+		rt := rt
+		items = append(items, menuItem{
+			title: rt.Name,
+			desc:  rt.Description,
+			run: func(m *model, _ bool) tea.Cmd {
+				m.checkRoundTrip(rt)
+				return nil
+			},
+		})
+	}
+	return items
+}
+
+// checkRoundTrip runs rt on the focused input pane and reports the outcome.
+// The diff shows the pane's content before and after the round trip.
+func (m *model) checkRoundTrip(rt transform.RoundTrip) {
+	if m.focus > 1 {
+		m.err = errNoInputPane
+		return
+	}
+	before, after, err := rt.Check(m.inputs[m.focus].Value())
+	if err != nil {
+		m.err = fmt.Errorf("%s round trip: %w", rt.Name, err)
+		return
+	}
+	m.err = nil
+	m.gen++
+	m.setDiff(compare.Compare(before, after, compare.Options{}))
+	if before == after {
+		m.notice = rt.Name + " round trip is lossless"
+	} else {
+		m.notice = rt.Name + " round trip changes the text; see the diff"
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRoundTripCommand(t *testing.T) {
+	dir := t.TempDir()
+	sorted := filepath.Join(dir, "sorted.json")
+	os.WriteFile(sorted, []byte(`{"a": 1, "b": 2}`), 0o644)
+	unsorted := filepath.Join(dir, "unsorted.json")
+	os.WriteFile(unsorted, []byte(`{"b": 2, "a": 1}`), 0o644)
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     string
+		wantCode int
+	}{
+		{"list", nil, "base64", exitSame},
+		{"lossless", []string{"json", sorted}, "json round trip is lossless\n", exitSame},
+		{"lossy", []string{"json", unsorted}, "json round trip changes the text:\n@@", exitDiffer},
+		{"invalid input", []string{"base64", sorted}, "", exitError},
+		{"unknown check", []string{"xml", sorted}, "", exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, code := runMain(t, "", append([]string{"roundtrip"}, tt.args...)...)
+			if code != tt.wantCode {
+				t.Errorf("exit status = %d, want %d", code, tt.wantCode)
+			}
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("printed\n%s\nwant %q in it", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundTripMenu(t *testing.T) {
+	tests := []struct {
+		name, check, in string
+		wantNotice      string
+		wantErr         bool
+		wantEqual       bool
+	}{
+		{"lossless", "json", `{"a": 1}`, "json round trip is lossless", false, true},
+		{"lossy", "json", `{"b": 1, "a": 2}`, "json round trip changes the text; see the diff", false, false},
+		{"invalid", "base64", "not base64!", "", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.inputs[0].SetValue(tt.in)
+			m, _ = update(m, alt('v'))
+			selectItem(t, m.overlay.(*menu), tt.check)
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+			if m.notice != tt.wantNotice {
+				t.Errorf("notice = %q, want %q", m.notice, tt.wantNotice)
+			}
+			if (m.err != nil) != tt.wantErr {
+				t.Errorf("err = %v", m.err)
+			}
+			if m.diff.Equal() != tt.wantEqual {
+				t.Errorf("Equal() = %v, want %v", m.diff.Equal(), tt.wantEqual)
+			}
+		})
+	}
+}
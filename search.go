@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// searchStyle marks matches of the search; applyTheme colors it.
+var searchStyle = lipgloss.NewStyle()
+
+// search finds text in the focused pane. While editing, keys go to the
+// query; afterwards the matches stay highlighted and the cursor can jump
+// between them.
+type search struct {
+	input   textinput.Model
+	editing bool
+	re      *regexp.Regexp
+}
+
+func newSearch() *search {
+	s := &search{input: textinput.New(), editing: true}
+	s.input.Prompt = "/"
+	s.input.Placeholder = "search"
+	s.input.Focus()
+	return s
+}
+
+// compile updates the pattern to the query, which is plain text. Queries
+// without capitals ignore case.
+func (s *search) compile() {
+	q := s.input.Value()
+	if q == "" {
+		s.re = nil
+		return
+	}
+	p := regexp.QuoteMeta(q)
+	if !strings.ContainsFunc(q, unicode.IsUpper) {
+		p = "(?i)" + p
+	}
+	s.re = regexp.MustCompile(p)
+}
+
+// highlight marks the matches in line.
+func (s *search) highlight(line string) string {
+	if s == nil || s.re == nil {
+		return line
+	}
+	return s.re.ReplaceAllStringFunc(line, func(s string) string { return searchStyle.Render(s) })
+}
+
+// update handles a key while the query is edited.
+func (s *search) update(m *model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.search = nil
+		m.refreshResult()
+		return nil
+	case "enter":
+		s.editing = false
+		s.input.Blur()
+		m.jumpToMatch(1)
+		return nil
+	}
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	s.compile()
+	m.refreshResult()
+	return cmd
+}
+
+// view shows the query while it is edited, or else the number of matches
+// in the focused pane.
+func (s *search) view(m *model) string {
+	if s.editing {
+		return s.input.View()
+	}
+	if s.re == nil {
+		return ""
+	}
+	n := 0
+	for _, l := range m.searchLines() {
+		n += len(s.re.FindAllStringIndex(l, -1))
+	}
+	if n == 1 {
+		return fmt.Sprintf("/%s: 1 match", s.input.Value())
+	}
+	return fmt.Sprintf("/%s: %d matches", s.input.Value(), n)
+}
+
+// searchLines returns the lines of the focused pane that are searched:
+// the text of an input pane, or the result as shown.
+func (m *model) searchLines() []string {
+	if m.focus == len(m.inputs)-1 {
+		return strings.Split(escapePattern.ReplaceAllString(m.results.content, ""), "\n")
+	}
+	return strings.Split(m.inputs[m.focus].Value(), "\n")
+}
+
+// jumpToMatch moves to the next match of the search in the focused pane,
+// or the previous one if dir is negative, wrapping around at the ends.
+func (m *model) jumpToMatch(dir int) {
+	if m.search == nil || m.search.re == nil {
+		return
+	}
+	if m.focus == len(m.inputs)-1 {
+		m.jumpToResultMatch(dir)
+		return
+	}
+	t := &m.inputs[m.focus]
+	lines := strings.Split(t.Value(), "\n")
+	li := t.LineInfo()
+	cur := panePos{line: t.Line(), col: li.StartColumn + li.ColumnOffset}
+	var found []panePos
+	for n, l := range lines {
+		for _, loc := range m.search.re.FindAllStringIndex(l, -1) {
+			found = append(found, panePos{line: n, col: utf8.RuneCountInString(l[:loc[0]])})
+		}
+	}
+	if len(found) == 0 {
+		m.notice = "no matches"
+		return
+	}
+	next := found[0]
+	if dir < 0 {
+		next = found[len(found)-1]
+	}
+	for k := range found {
+		p := found[k]
+		if dir < 0 {
+			p = found[len(found)-1-k]
+		}
+		if dir > 0 && (p.line > cur.line || p.line == cur.line && p.col > cur.col) ||
+			dir < 0 && (p.line < cur.line || p.line == cur.line && p.col < cur.col) {
+			next = p
+			break
+		}
+	}
+	m.moveCursor(m.focus, next)
+}
+
+// jumpToResultMatch scrolls the result pane to the next line with a match,
+// or the previous one if dir is negative.
+func (m *model) jumpToResultMatch(dir int) {
+	v := &m.results.view
+	lines := m.searchLines()
+	var found []int
+	for n, l := range lines {
+		if m.search.re.MatchString(l) {
+			found = append(found, n)
+		}
+	}
+	if len(found) == 0 {
+		m.notice = "no matches"
+		return
+	}
+	next := found[0]
+	if dir < 0 {
+		next = found[len(found)-1]
+	}
+	for k := range found {
+		n := found[k]
+		if dir < 0 {
+			n = found[len(found)-1-k]
+		}
+		if dir > 0 && n > v.YOffset || dir < 0 && n < v.YOffset {
+			next = n
+			break
+		}
+	}
+	v.SetYOffset(next)
+}
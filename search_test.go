@@ -0,0 +1,120 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSearchCompile(t *testing.T) {
+	tests := []struct {
+		query, text string
+		want        int
+	}{
+		{"hello", "Hello hello HELLO", 3},
+		{"Hello", "Hello hello HELLO", 1},
+		{"a.b", "a.b axb", 1},
+		{"(x)", "(x) x", 1},
+		{"", "anything", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			s := newSearch()
+			s.input.SetValue(tt.query)
+			s.compile()
+			got := 0
+			if s.re != nil {
+				got = len(s.re.FindAllString(tt.text, -1))
+			}
+			if got != tt.want {
+				t.Errorf("%d matches, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// searchPane returns a sized model with pane A focused, holding text and
+// its cursor at the start, searching for query.
+func searchPane(text, query string) model {
+	m := newModel()
+	m, _ = update(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+	m.inputs[0].SetValue(text)
+	for m.inputs[0].Line() > 0 {
+		m.inputs[0].CursorUp()
+	}
+	m.inputs[0].CursorStart()
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlF})
+	m = typeText(m, query)
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	return m
+}
+
+func TestSearchPane(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []tea.KeyMsg
+		line int
+		col  int
+	}{
+		{"first match", nil, 1, 4},
+		{"next", []tea.KeyMsg{alt('n')}, 2, 0},
+		{"wraps to the start", []tea.KeyMsg{alt('n'), alt('n')}, 0, 0},
+		{"previous", []tea.KeyMsg{alt('N')}, 0, 0},
+		{"previous wraps to the end", []tea.KeyMsg{alt('N'), alt('N')}, 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := searchPane("foo bar\nbaz foo\nfoo", "foo")
+			for _, k := range tt.keys {
+				m, _ = update(m, k)
+			}
+			li := m.inputs[0].LineInfo()
+			if line, col := m.inputs[0].Line(), li.StartColumn+li.ColumnOffset; line != tt.line || col != tt.col {
+				t.Errorf("cursor at %d:%d, want %d:%d", line, col, tt.line, tt.col)
+			}
+		})
+	}
+}
+
+func TestSearchView(t *testing.T) {
+	m := searchPane("foo bar\nbaz foo\nfoo", "foo")
+	if v := m.view(); !strings.Contains(v, "/foo: 3 matches") {
+		t.Errorf("the help line does not count the matches:\n%s", v)
+	}
+	m = searchPane("foo", "bar")
+	if m.notice != "no matches" {
+		t.Errorf("notice %q", m.notice)
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.search != nil {
+		t.Error("esc does not end the search")
+	}
+	if v := m.view(); strings.Contains(v, "/bar") {
+		t.Error("the query is still shown")
+	}
+}
+
+func TestSearchResult(t *testing.T) {
+	m := longResult(100)
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if m.search == nil || !m.search.editing {
+		t.Fatal("/ does not search the result pane")
+	}
+	m = typeText(m, "line 5")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	// line 5, line 50 to 59
+	want := []int{4, 49, 50, 51}
+	for n, top := range want {
+		if n > 0 {
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+		}
+		if got := m.results.view.YOffset; got != top {
+			t.Errorf("match %d: scrolled to %d, want %d", n+1, got, top)
+		}
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	if got := m.results.view.YOffset; got != 50 {
+		t.Errorf("N scrolled to %d, want 50", got)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const statsFile = "stats.json"
+
+// usageStats counts how strcli is used. Nothing is recorded until the user
+// turns recording on, and the counts never leave the local state directory.
+type usageStats struct {
+	Enabled      bool           `json:"enabled"`
+	Since        time.Time      `json:"since"`
+	Comparisons  int            `json:"comparisons"`
+	Transforms   map[string]int `json:"transforms"`
+	BiggestInput int            `json:"biggest_input"`
+
+	// unsaved counts what was recorded since the stats were loaded, which
+	// save adds to what other runs saved meanwhile.
+	unsaved struct {
+		comparisons int
+		transforms  map[string]int
+	}
+}
+
+func loadStats() (*usageStats, error) {
+	s := &usageStats{}
+	err := loadState(statsFile, s)
+	if s.Transforms == nil {
+		s.Transforms = map[string]int{}
+	}
+	return s, err
+}
+
+// recordCompare counts a comparison of inputs of the given sizes in bytes.
+// Like recordTransform, it only counts in memory until flush.
+func (s *usageStats) recordCompare(sizes ...int) {
+	if s == nil || !s.Enabled {
+		return
+	}
+	s.Comparisons++
+	s.unsaved.comparisons++
+	for _, n := range sizes {
+		s.BiggestInput = max(s.BiggestInput, n)
+	}
+}
+
+// recordTransform counts a use of the transform called name.
+func (s *usageStats) recordTransform(name string) {
+	if s == nil || !s.Enabled {
+		return
+	}
+	s.Transforms[name]++
+	if s.unsaved.transforms == nil {
+		s.unsaved.transforms = map[string]int{}
+	}
+	s.unsaved.transforms[name]++
+}
+
+// flush saves the stats if anything was recorded since they were loaded.
+func (s *usageStats) flush() error {
+	if s == nil || s.unsaved.comparisons == 0 && len(s.unsaved.transforms) == 0 {
+		return nil
+	}
+	return s.save()
+}
+
+// save adds what was recorded since the stats were loaded to the stats
+// saved, which other runs may have added to meanwhile, and saves them with
+// the settings of s. s then holds the sum.
+func (s *usageStats) save() error {
+	saved, err := loadStats()
+	if err != nil {
+		return err
+	}
+	saved.Enabled, saved.Since = s.Enabled, s.Since
+	saved.Comparisons += s.unsaved.comparisons
+	saved.BiggestInput = max(saved.BiggestInput, s.BiggestInput)
+	for name, n := range s.unsaved.transforms {
+		saved.Transforms[name] += n
+	}
+	if err := saveState(statsFile, saved); err != nil {
+		return err
+	}
+	*s = *saved
+	return nil
+}
+
+// statsScreen shows the recorded usage stats.
+type statsScreen struct{}
+
+func (statsScreen) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		return true, nil
+	case "r":
+		m.stats.Enabled = !m.stats.Enabled
+		if m.stats.Enabled && m.stats.Since.IsZero() {
+			m.stats.Since = time.Now()
+		}
+		m.err = m.stats.save()
+	case "x":
+		*m.stats = usageStats{Enabled: m.stats.Enabled, Transforms: map[string]int{}}
+		if m.stats.Enabled {
+			m.stats.Since = time.Now()
+		}
+		m.err = saveState(statsFile, m.stats)
+	}
+	return false, nil
+}
+
+func (statsScreen) view(m *model) string {
+	s := m.stats
+	var b strings.Builder
+	b.WriteString(overlayTitleStyle.Render("Usage stats") + "\n\n")
+	if !s.Enabled {
+		b.WriteString("Recording is off. Stats are only kept on this machine.\n\n")
+	} else {
+		fmt.Fprintf(&b, "Recording since %s\n\n", s.Since.Format(time.DateOnly))
+	}
+	fmt.Fprintf(&b, "Comparisons run:      %d\n", s.Comparisons)
+	fmt.Fprintf(&b, "Biggest input:        %d bytes\n", s.BiggestInput)
+
+	type count struct {
+		name string
+		n    int
+	}
+	var counts []count
+	for name, n := range s.Transforms {
+		counts = append(counts, count{name, n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].n != counts[j].n {
+			return counts[i].n > counts[j].n
+		}
+		return counts[i].name < counts[j].name
+	})
+	b.WriteString("\nMost used transforms:\n")
+	if len(counts) == 0 {
+		b.WriteString("  none yet\n")
+	}
+	for i, c := range counts {
+		if i == 10 {
+			break
+		}
+		fmt.Fprintf(&b, "  %-20s %d\n", c.name, c.n)
+	}
+	b.WriteString("\nr toggle recording • x reset • esc close")
+	return overlayStyle.Render(b.String())
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/transform"
+)
+
+func TestStatsScreen(t *testing.T) {
+	upper, _ := transform.Lookup("upper")
+	tests := []struct {
+		name            string
+		keys            []string
+		wantEnabled     bool
+		wantComparisons int
+		wantTransforms  map[string]int
+	}{
+		{"off by default", nil, false, 0, map[string]int{}},
+		{"recording", []string{"r"}, true, 1, map[string]int{"upper": 1}},
+		{"toggled off again", []string{"r", "r"}, false, 0, map[string]int{}},
+		{"reset", []string{"r", "x"}, true, 1, map[string]int{"upper": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			m := newModel()
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyF2})
+			if _, ok := m.overlay.(statsScreen); !ok {
+				t.Fatalf("overlay = %T, want the stats screen", m.overlay)
+			}
+			for _, k := range tt.keys {
+				m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+			}
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+			m.inputs[0].SetValue("abc")
+			m.startCompare()
+			m.applyTransform(upper, "", false)
+			if m.err != nil {
+				t.Fatal(m.err)
+			}
+			if err := m.stats.flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			saved, err := loadStats()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range []*usageStats{m.stats, saved} {
+				if s.Enabled != tt.wantEnabled || s.Comparisons != tt.wantComparisons || !reflect.DeepEqual(s.Transforms, tt.wantTransforms) {
+					t.Errorf("stats = %+v, want enabled %v, %d comparisons, transforms %v",
+						s, tt.wantEnabled, tt.wantComparisons, tt.wantTransforms)
+				}
+			}
+			if tt.wantComparisons > 0 && saved.BiggestInput != 3 {
+				t.Errorf("biggest input = %d, want 3", saved.BiggestInput)
+			}
+		})
+	}
+}
+
+func TestStatsMerge(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := saveState(statsFile, &usageStats{Enabled: true, Comparisons: 5}); err != nil {
+		t.Fatal(err)
+	}
+	first, err := loadStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := loadStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.recordCompare(10)
+	first.recordTransform("upper")
+	second.recordCompare(20)
+	second.recordTransform("upper")
+	second.recordTransform("lower")
+	if saved, _ := loadStats(); saved.Comparisons != 5 {
+		t.Errorf("recording saved %d comparisons before flushing", saved.Comparisons)
+	}
+	for _, s := range []*usageStats{first, second} {
+		if err := s.flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := first.flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := loadStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"upper": 2, "lower": 1}
+	if saved.Comparisons != 7 || saved.BiggestInput != 20 || !reflect.DeepEqual(saved.Transforms, want) {
+		t.Errorf("stats = %+v, want 7 comparisons of up to 20 bytes and transforms %v", saved, want)
+	}
+}
+
+func TestStatsFromCommands(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := saveState(statsFile, &usageStats{Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	a := filepath.Join(t.TempDir(), "a.txt")
+	os.WriteFile(a, []byte("twelve bytes"), 0o644)
+	runMain(t, "", "compare", a, a)
+	runMain(t, "", "transform", "upper", a)
+	runMain(t, "", "transform", "upper", a)
+
+	s, err := loadStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Comparisons != 1 || s.BiggestInput != 12 || s.Transforms["upper"] != 2 {
+		t.Errorf("stats = %+v, want 1 comparison of 12 bytes and 2 uses of upper", s)
+	}
+}
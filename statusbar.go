@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// statusStyle is the bar showing the state of the comparison; applyTheme
+// colors it.
+var statusStyle = lipgloss.NewStyle().Padding(0, 1)
+
+// statusView draws the status bar: what the panes hold, how they are
+// compared and the state of the view.
+func (m *model) statusView() string {
+	labels := m.paneLabels()
+	var parts []string
+	if m.vim != nil {
+		parts = append(parts, m.vim.view())
+	}
+	for i, l := range labels {
+		n, unit := utf8.RuneCountInString(m.paneText(i)), "chars"
+		if n == 1 {
+			unit = "char"
+		}
+		parts = append(parts, fmt.Sprintf("%s: %d %s", l, n, unit))
+	}
+	if m.comparing() {
+		parts = append(parts, m.spinner.View()+" comparing")
+	}
+	if m.matching {
+		parts = append(parts, "matching "+m.syntax.String()+" in A")
+	} else {
+		parts = append(parts, m.options.Unit.String()+" diff")
+	}
+	if m.live {
+		parts = append(parts, "live")
+	}
+	if p := m.options.Preset.Name; p != "" {
+		parts = append(parts, "preset: "+p)
+	}
+	if m.options.IgnoreCase {
+		parts = append(parts, "ignoring case")
+	}
+	if eol := m.lineEndingsView(); eol != "" {
+		parts = append(parts, eol)
+	}
+	if m.options.Template {
+		parts = append(parts, "A is a template")
+	}
+	if m.options.Overlap {
+		parts = append(parts, "overlap only")
+	}
+	if m.appending {
+		parts = append(parts, fmt.Sprintf("appending results (%d)", len(m.resultLog)))
+	}
+	if w := m.watchView(); w != "" {
+		parts = append(parts, w)
+	}
+	if m.zoomed {
+		parts = append(parts, "zoomed")
+	}
+	for i, off := range m.unwrapped {
+		if off != nil {
+			parts = append(parts, fmt.Sprintf("%c unwrapped", 'A'+i))
+		}
+	}
+	if m.lockScroll {
+		parts = append(parts, "scroll locked")
+	}
+	if t := m.tabsView(); t != "" {
+		parts = append(parts, t)
+	}
+	parts = append(parts, m.cursorView())
+	return statusStyle.Copy().Width(m.width).MaxHeight(1).Render(strings.Join(parts, " · "))
+}
+
+// cursorView shows where the cursor of the focused input pane is, or how
+// far the result pane is scrolled.
+func (m *model) cursorView() string {
+	if m.focus == len(m.inputs)-1 {
+		v := m.results.view
+		return fmt.Sprintf("result line %d/%d", min(v.YOffset+1, v.TotalLineCount()), v.TotalLineCount())
+	}
+	t := &m.inputs[m.focus]
+	li := t.LineInfo()
+	return fmt.Sprintf("Ln %d/%d, Col %d", t.Line()+1, t.LineCount(), li.StartColumn+li.ColumnOffset+1)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"strcli/pkg/diff"
+	"strcli/pkg/pattern"
+)
+
+func TestStatusView(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(m *model)
+		want  string
+	}{
+		{"defaults", func(m *model) {}, "A: 0 chars · B: 0 chars · grapheme diff"},
+		{"counts", func(m *model) { m.setPane(0, "x"); m.setPane(1, "d\u00e9j\u00e0") }, "A: 1 char · B: 4 chars"},
+		{"file names", func(m *model) { m.paths[0] = "/tmp/a.txt" }, "a.txt: 0 chars · B: 0 chars"},
+		{"unit", func(m *model) { m.options.Unit = diff.Byte }, "byte diff"},
+		{"matching", func(m *model) { m.matching, m.syntax = true, pattern.Glob }, "matching glob in A"},
+		{"ignoring case", func(m *model) { m.options.IgnoreCase = true }, "grapheme diff · ignoring case"},
+		{"template", func(m *model) { m.options.Template = true }, "A is a template"},
+		{"overlap", func(m *model) { m.options.Overlap = true }, "overlap only"},
+		{"appending", func(m *model) { m.appending, m.resultLog = true, []string{"a", "b"} }, "appending results (2)"},
+		{"zoomed", func(m *model) { m.zoomed = true }, "zoomed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.width = 200
+			tt.setup(&m)
+			if got := m.statusView(); !strings.Contains(got, tt.want) {
+				t.Errorf("%q does not contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusViewFits(t *testing.T) {
+	m := newModel()
+	m.width = 30
+	m.options.IgnoreCase, m.options.Template, m.options.Overlap = true, true, true
+	if got := m.statusView(); lipgloss.Width(got) != 30 || lipgloss.Height(got) != 1 {
+		t.Errorf("status bar %q is %dx%d", got, lipgloss.Width(got), lipgloss.Height(got))
+	}
+}
+
+func TestCursorView(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(m *model)
+		want  string
+	}{
+		{"empty", func(m *model) {}, "Ln 1/1, Col 1"},
+		{"end of text", func(m *model) { m.setPane(0, "one\ntwo\nthree") }, "Ln 3/3, Col 6"},
+		{"moved", func(m *model) { m.setPane(0, "one\ntwo\nthree"); m.moveCursor(0, panePos{line: 1, col: 1}) }, "Ln 2/3, Col 2"},
+		{"pane B", func(m *model) { m.setPane(1, "ab"); m.focus = 1 }, "Ln 1/1, Col 3"},
+		{"result", func(m *model) { m.focus = 2; m.setResult(strings.Repeat("x\n", 99) + "x"); m.results.view.LineDown(4) }, "result line 5/100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := longResult(0)
+			m.focus = 0
+			tt.setup(&m)
+			if got := m.cursorView(); got != tt.want {
+				t.Errorf("%q, want %q", got, tt.want)
+			}
+		})
+	}
+}
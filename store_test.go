@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestState(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	v := map[string]int{"kept": 1}
+	if err := loadState("missing.json", &v); err != nil {
+		t.Fatalf("missing file: %v", err)
+	}
+	if want := map[string]int{"kept": 1}; !reflect.DeepEqual(v, want) {
+		t.Errorf("missing file changed the value to %v", v)
+	}
+
+	if err := saveState("state.json", map[string]int{"a": 1, "b": 2}); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]int
+	if err := loadState("state.json", &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]int{"a": 1, "b": 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSaveStateReplaces(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	for _, v := range []int{1, 2} {
+		if err := saveState("state.json", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var got int
+	if err := loadState("state.json", &got); err != nil || got != 2 {
+		t.Errorf("got %d, %v after saving twice", got, err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "strcli"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "state.json" {
+		t.Errorf("files left behind: %v", entries)
+	}
+	if fi, err := entries[0].Info(); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Errorf("saved state: %v, %v", fi, err)
+	}
+}
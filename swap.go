@@ -0,0 +1,18 @@
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// swapPanes swaps the contents of the input panes, and so the direction of
+// the diff. Each pane keeps its own undo history, so undoing in both panes
+// swaps them back. Loads still in flight are dropped, as they would land in
+// the wrong pane.
+func (m *model) swapPanes() tea.Cmd {
+	a, b := m.paneText(0), m.paneText(1)
+	m.replacePane(0, b)
+	m.replacePane(1, a)
+	m.paths[0], m.paths[1] = m.paths[1], m.paths[0]
+	m.paneGen[0]++
+	m.paneGen[1]++
+	m.notice = "swapped panes A and B"
+	return m.startCompare()
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSwapPanes(t *testing.T) {
+	m := newModel()
+	m.setInputs([]string{"one\r\ntwo", "three"}, []string{"a.txt", "b.txt"})
+	m = settle(m, alt('S'))
+	if m.paneText(0) != "three" || m.paneText(1) != "one\r\ntwo" {
+		t.Errorf("panes %q and %q after swapping", m.paneText(0), m.paneText(1))
+	}
+	if m.paths[0] != "b.txt" || m.paths[1] != "a.txt" {
+		t.Errorf("paths %q after swapping", m.paths)
+	}
+	if m.notice != "swapped panes A and B" {
+		t.Errorf("notice %q", m.notice)
+	}
+	if m.diff.Equal() || m.result == "" {
+		t.Error("the panes are not compared after swapping")
+	}
+
+	// Undoing in both panes swaps them back.
+	m = settle(m, undoKey)
+	m.focus = 1
+	m = settle(m, undoKey)
+	if m.paneText(0) != "one\r\ntwo" || m.paneText(1) != "three" {
+		t.Errorf("panes %q and %q after undoing", m.paneText(0), m.paneText(1))
+	}
+}
+
+func TestSwapWatchedPanes(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	os.WriteFile(a, []byte("a"), 0o644)
+	os.WriteFile(b, []byte("b"), 0o644)
+
+	m := newModel()
+	m.setInputs([]string{"a", "b"}, []string{a, b})
+	m.watch = &watcher{paths: []string{a, b}, changed: make(chan string), close: func() error { return nil }}
+	m = settle(m, alt('S'))
+	// The watcher reports a change to the file it was given first, a,
+	// which is now in pane B.
+	os.WriteFile(a, []byte("a changed"), 0o644)
+	m = settle(m, fileChangedMsg{path: a})
+	if m.inputs[0].Value() != "b" || m.inputs[1].Value() != "a changed" {
+		t.Errorf("panes %q and %q after a changed", m.inputs[0].Value(), m.inputs[1].Value())
+	}
+}
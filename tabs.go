@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/compare"
+	"strcli/pkg/diff"
+	"strcli/pkg/pattern"
+)
+
+// session is one comparison: the panes with their text and history, how
+// they are compared and the result. Each tab holds a session, and the
+// model embeds the one of the current tab.
+type session struct {
+	inputs []textarea.Model
+	focus  int
+	diff   diff.Diff
+	// result is the colored result of the last comparison or match, shown
+	// in results.
+	result  string
+	results resultPane
+	// options select how the inputs are compared.
+	options compare.Options
+	// matching, when set, matches the second pane against a pattern of
+	// the given syntax in the first instead of comparing them.
+	matching bool
+	syntax   pattern.Syntax
+	// appending adds each comparison to resultLog, rendered for export,
+	// instead of replacing the result.
+	appending bool
+	resultLog []string
+	// search, if set, highlights the matches of a query in the focused
+	// pane.
+	search *search
+	// unwrapped holds how far each input pane that does not wrap long
+	// lines is scrolled, or nil if it wraps them.
+	unwrapped [2]*offset
+
+	// paths holds the file each input pane was loaded from, if any.
+	paths []string
+	// eols are the line endings of the input panes' text, which the panes
+	// themselves always show as LF.
+	eols [2]string
+	// history holds the earlier versions of each input pane for undo.
+	history [2]history
+	// previous holds each pane's content from before it was last replaced
+	// by a load, so it can be restored.
+	previous []*string
+	// edits counts the edits of the input panes, and compared is the count
+	// when they were last compared.
+	edits, compared int
+	// stale marks the input panes whose file changed while watched and the
+	// tab was not shown.
+	stale [2]bool
+}
+
+func newSession() session {
+	s := session{
+		inputs:   make([]textarea.Model, initialInputs),
+		paths:    make([]string, initialInputs),
+		previous: make([]*string, initialInputs),
+	}
+	// The last textarea only keeps the focus for the result pane.
+	for i := range s.inputs {
+		s.inputs[i] = newTextarea()
+	}
+	s.inputs[s.focus].Focus()
+	return s
+}
+
+// empty reports whether there is only one tab and its input panes are
+// empty.
+func (m *model) empty() bool {
+	return len(m.tabs) == 1 && m.inputs[0].Value() == "" && m.inputs[1].Value() == ""
+}
+
+// newTab opens an empty tab after the current one, comparing the way the
+// current tab does.
+func (m *model) newTab() tea.Cmd {
+	s := newSession()
+	s.options = m.options
+	m.tabs[m.tab] = m.session
+	m.tabs = append(m.tabs[:m.tab+1], append([]session{s}, m.tabs[m.tab+1:]...)...)
+	return m.showTab(m.tab + 1)
+}
+
+// closeTab closes the current tab, dropping its panes, unless it is the
+// only one.
+func (m *model) closeTab() tea.Cmd {
+	if len(m.tabs) == 1 {
+		m.notice = "the only tab cannot be closed"
+		return nil
+	}
+	m.tabs = append(m.tabs[:m.tab], m.tabs[m.tab+1:]...)
+	return m.showTab(min(m.tab, len(m.tabs)-1))
+}
+
+// switchTab moves n tabs on from the current one, wrapping around.
+func (m *model) switchTab(n int) tea.Cmd {
+	if len(m.tabs) == 1 {
+		return nil
+	}
+	m.tabs[m.tab] = m.session
+	return m.showTab((m.tab + n + len(m.tabs)) % len(m.tabs))
+}
+
+// showTab makes tab i the current one. The current session must already be
+// stored in m.tabs, or be dropped.
+func (m *model) showTab(i int) tea.Cmd {
+	m.tab = i
+	m.session = m.tabs[i]
+	// Comparisons and loads still running belong to the tab left.
+	m.gen++
+	for p := range m.paneGen {
+		m.paneGen[p]++
+	}
+	m.sizeInputs()
+	status := ""
+	if m.result != "" {
+		status = m.diffStatus()
+	}
+	return tea.Batch(m.titleCmd(status), m.reloadStale())
+}
+
+// tabsView lists the tabs by the labels of their panes, bracketing the
+// current one, if there is more than one.
+func (m *model) tabsView() string {
+	if len(m.tabs) < 2 {
+		return ""
+	}
+	var b strings.Builder
+	for i := range m.tabs {
+		s := &m.tabs[i]
+		if i == m.tab {
+			s = &m.session
+		}
+		labels := s.paneLabels()
+		label := fmt.Sprintf("%d %s↔%s", i+1, labels[0], labels[1])
+		if i == m.tab {
+			label = "[" + label + "]"
+		}
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(label)
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	newTabKey   = tea.KeyMsg{Type: tea.KeyCtrlT}
+	closeTabKey = tea.KeyMsg{Type: tea.KeyCtrlW}
+	nextTabKey  = tea.KeyMsg{Type: tea.KeyCtrlRight}
+	prevTabKey  = tea.KeyMsg{Type: tea.KeyCtrlLeft}
+)
+
+// panesOf returns the text of each tab's pane A, the current one read from
+// the model.
+func panesOf(m model) []string {
+	var texts []string
+	for i := range m.tabs {
+		s := m.tabs[i]
+		if i == m.tab {
+			s = m.session
+		}
+		texts = append(texts, s.inputs[0].Value())
+	}
+	return texts
+}
+
+func TestTabs(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  []tea.KeyMsg
+		panes []string
+		tab   int
+	}{
+		{"new tab", []tea.KeyMsg{newTabKey}, []string{"one", ""}, 1},
+		{"back", []tea.KeyMsg{newTabKey, prevTabKey}, []string{"one", ""}, 0},
+		{"wraps around", []tea.KeyMsg{newTabKey, nextTabKey}, []string{"one", ""}, 0},
+		{"wraps back", []tea.KeyMsg{newTabKey, nextTabKey, prevTabKey}, []string{"one", ""}, 1},
+		{"opens after the current tab", []tea.KeyMsg{newTabKey, prevTabKey, newTabKey}, []string{"one", "", ""}, 1},
+		{"close", []tea.KeyMsg{newTabKey, prevTabKey, closeTabKey}, []string{""}, 0},
+		{"close the last", []tea.KeyMsg{newTabKey, closeTabKey}, []string{"one"}, 0},
+		{"only tab", []tea.KeyMsg{closeTabKey}, []string{"one"}, 0},
+		{"switch with one tab", []tea.KeyMsg{nextTabKey}, []string{"one"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.setPane(0, "one")
+			for _, k := range tt.keys {
+				m, _ = update(m, k)
+			}
+			if got := panesOf(m); strings.Join(got, ",") != strings.Join(tt.panes, ",") || m.tab != tt.tab {
+				t.Errorf("tabs %q at %d, want %q at %d", got, m.tab, tt.panes, tt.tab)
+			}
+		})
+	}
+}
+
+func TestNewTabKeepsOptions(t *testing.T) {
+	m := newModel()
+	m.options.IgnoreCase = true
+	m.setResult("result")
+	m, _ = update(m, newTabKey)
+	if !m.options.IgnoreCase || m.result != "" {
+		t.Errorf("the new tab has options %+v and result %q", m.options, m.result)
+	}
+	m, _ = update(m, prevTabKey)
+	if m.result != "result" {
+		t.Errorf("the first tab lost its result: %q", m.result)
+	}
+}
+
+func TestCloseOnlyTab(t *testing.T) {
+	m := newModel()
+	m, _ = update(m, closeTabKey)
+	if m.notice != "the only tab cannot be closed" {
+		t.Errorf("notice %q", m.notice)
+	}
+}
+
+func TestTabsView(t *testing.T) {
+	m := newModel()
+	if v := m.tabsView(); v != "" {
+		t.Errorf("one tab is listed as %q", v)
+	}
+	m.paths[0] = "/tmp/old.txt"
+	m, _ = update(m, newTabKey)
+	m.paths[1] = "new.txt"
+	if v, want := m.tabsView(), "1 old.txt↔B [2 A↔new.txt]"; v != want {
+		t.Errorf("tabs %q, want %q", v, want)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+	"strcli/pkg/transform"
+)
+
+var sentenceEndRe = regexp.MustCompile(`[.!?]+(\s|$)`)
+
+// textStats counts the characters, bytes, words, lines, sentences and
+// distinct words of s.
+func textStats(s string) string {
+	words := textWords(s)
+	distinct := map[string]bool{}
+	for _, w := range words {
+		distinct[transform.Fold(w)] = true
+	}
+	lines := 0
+	if s != "" {
+		lines = strings.Count(strings.TrimSuffix(s, "\n"), "\n") + 1
+	}
+	sentences := len(sentenceEndRe.FindAllStringIndex(s, -1))
+	if tail := sentenceEndRe.ReplaceAllString(s, "\n"); strings.TrimSpace(tail[strings.LastIndex(tail, "\n")+1:]) != "" {
+		sentences++ // the last sentence has no end mark yet
+	}
+	return fmt.Sprintf("%d chars · %d bytes · %d words · %d lines · %d sentences · %d unique words",
+		uniseg.GraphemeClusterCount(s), len(s), len(words), lines, sentences, len(distinct))
+}
+
+// textWords splits s into words: runs of letters, digits and apostrophes.
+func textWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+	})
+}
+
+// textStatsView shows the stats of the focused pane, if they are switched
+// on.
+func (m *model) textStatsView() string {
+	if !m.showTextStats || m.focus > 1 {
+		return ""
+	}
+	return fmt.Sprintf("%c: %s", 'A'+m.focus, textStats(m.inputs[m.focus].Value()))
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTextStats(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"empty", "", "0 chars · 0 bytes · 0 words · 0 lines · 0 sentences · 0 unique words"},
+		{"sentences", "Hi there. It's me!\nHi again", "27 chars · 27 bytes · 6 words · 2 lines · 3 sentences · 5 unique words"},
+		{"final line break", "one.\ntwo.\n", "10 chars · 10 bytes · 2 words · 2 lines · 2 sentences · 2 unique words"},
+		{"graphemes", "café 👍🏽", "6 chars · 14 bytes · 1 words · 1 lines · 1 sentences · 1 unique words"},
+		{"case folded", "Go go GO", "8 chars · 8 bytes · 3 words · 1 lines · 1 sentences · 1 unique words"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := textStats(tt.in); got != tt.want {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextStatsKey(t *testing.T) {
+	m := newModel()
+	m.inputs[0].SetValue("one two")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyF5})
+	if !strings.Contains(m.View(), "A: 7 chars · 7 bytes") {
+		t.Error("the stats of pane A are not shown")
+	}
+	m.focus = 2
+	if strings.Contains(m.View(), "bytes ·") {
+		t.Error("stats are shown for the result pane")
+	}
+	m.focus = 0
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyF5})
+	if strings.Contains(m.View(), "bytes ·") {
+		t.Error("stats are still shown after switching them off")
+	}
+}
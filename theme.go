@@ -0,0 +1,101 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strcli/pkg/render"
+	"strcli/pkg/theme"
+)
+
+// currentTheme is the theme the styles were last colored with.
+var currentTheme theme.Theme
+
+// diffStyles are the styles diffs are colored in; applyTheme sets them.
+var diffStyles = render.DefaultStyles()
+
+// darkBackground is whether the terminal has a dark background. It is
+// only detected for the TUI, so other commands assume a dark one.
+var darkBackground = true
+
+func init() {
+	applyTheme(theme.Default)
+}
+
+// applyTheme colors every style of the TUI with t. Panes created before
+// need restyling with styleTextarea.
+func applyTheme(t theme.Theme) {
+	t = t.ForBackground(darkBackground)
+	currentTheme = t
+
+	cursorStyle = cursorStyle.Foreground(t.Accent)
+	cursorLineStyle = cursorLineStyle.Background(t.CursorLine).Foreground(t.CursorLineText).Reverse(t.Plain)
+	placeholderStyle = placeholderStyle.Foreground(t.Border)
+	endOfBufferStyle = endOfBufferStyle.Foreground(t.Faint)
+	focusedPlaceholderStyle = focusedPlaceholderStyle.Foreground(t.Subtle)
+	focusedBorderStyle = focusedBorderStyle.BorderForeground(t.Border)
+	errorStyle = errorStyle.Foreground(t.Error).Bold(t.Plain)
+
+	overlayStyle = overlayStyle.BorderForeground(t.Subtle)
+	overlayTitleStyle = overlayTitleStyle.Foreground(t.Accent)
+	selectedStyle = selectedStyle.Foreground(t.Accent).Reverse(t.Plain)
+	tipStyle = tipStyle.Foreground(t.Subtle)
+	statusStyle = statusStyle.Background(t.Border).Foreground(t.Foreground).Reverse(t.Plain)
+	visualStyle = visualStyle.Background(t.Match).Foreground(t.MatchText).Reverse(t.Plain)
+	searchStyle = searchStyle.Background(t.Note).Foreground(t.Background).Reverse(t.Plain)
+	logLabelStyle = logLabelStyle.Foreground(t.Subtle)
+	matchStyle = matchStyle.Foreground(t.Insert).Underline(t.Plain)
+	findStyle = findStyle.Foreground(t.Delete)
+	replaceStyle = replaceStyle.Foreground(t.Insert).Underline(t.Plain)
+
+	presentStyle = presentStyle.BorderForeground(t.Foreground)
+	presentTitleStyle = presentTitleStyle.Foreground(t.Foreground)
+	presentInsertStyle = presentInsertStyle.Foreground(t.Background).Background(t.Insert).Underline(t.Plain)
+	presentDeleteStyle = presentDeleteStyle.Foreground(t.Foreground).Background(t.Delete)
+
+	regexMatchStyle = regexMatchStyle.Background(t.Match).Foreground(t.MatchText).Underline(t.Plain)
+	groupStyles = groupStyles[:0]
+	for _, c := range t.Groups {
+		groupStyles = append(groupStyles, regexMatchStyle.Copy().Foreground(c).Bold(true))
+	}
+
+	diffStyles = render.Styles{
+		Insert:    lipgloss.NewStyle().Foreground(t.Insert).Underline(t.Plain),
+		Delete:    lipgloss.NewStyle().Foreground(t.Delete).Strikethrough(t.Plain),
+		Note:      lipgloss.NewStyle().Foreground(t.Note).Bold(true),
+		Glyph:     lipgloss.NewStyle().Foreground(t.Glyph).Faint(t.Plain),
+		Invisible: lipgloss.NewStyle().Foreground(t.Note).Bold(true),
+		Trailing:  lipgloss.NewStyle().Background(t.Trailing).Reverse(t.Plain),
+		Markers:   t.Plain,
+	}
+}
+
+// styleTextarea gives t the styles of the current theme.
+func styleTextarea(t *textarea.Model) {
+	t.Cursor.Style = cursorStyle
+	t.FocusedStyle.Placeholder = focusedPlaceholderStyle
+	t.BlurredStyle.Placeholder = placeholderStyle
+	t.FocusedStyle.CursorLine = cursorLineStyle
+	t.FocusedStyle.Base = focusedBorderStyle
+	t.BlurredStyle.Base = blurredBorderStyle
+	t.FocusedStyle.EndOfBuffer = endOfBufferStyle
+	t.BlurredStyle.EndOfBuffer = endOfBufferStyle
+}
+
+// setTheme switches the TUI to t.
+func (m *model) setTheme(t theme.Theme) {
+	applyTheme(t)
+	for i := range m.inputs {
+		styleTextarea(&m.inputs[i])
+	}
+	if m.preview != nil {
+		m.preview.out = ""
+	}
+}
+
+// cycleTheme switches to the next theme.
+func (m *model) cycleTheme() tea.Cmd {
+	m.setTheme(theme.Next(currentTheme))
+	m.notice = "theme: " + currentTheme.Name
+	return m.startCompare()
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"strcli/pkg/theme"
+)
+
+func TestThemeKey(t *testing.T) {
+	t.Cleanup(func() { applyTheme(theme.Default) })
+	m := newModel()
+	for _, want := range []string{"dracula", "solarized-dark", "solarized-light", "monochrome", "default"} {
+		m, _ = update(m, alt('c'))
+		if currentTheme.Name != want || m.notice != "theme: "+want {
+			t.Errorf("theme %q, notice %q, want %q", currentTheme.Name, m.notice, want)
+		}
+	}
+}
+
+func TestApplyTheme(t *testing.T) {
+	t.Cleanup(func() { applyTheme(theme.Default) })
+	dracula, _ := theme.Lookup("dracula")
+	m := newModel()
+	m.setTheme(dracula)
+	if got := m.inputs[0].FocusedStyle.Base.GetBorderTopForeground(); got != dracula.Border {
+		t.Errorf("pane border %v, want %v", got, dracula.Border)
+	}
+	if got := overlayTitleStyle.GetForeground(); got != dracula.Accent {
+		t.Errorf("overlay titles %v, want %v", got, dracula.Accent)
+	}
+	if len(groupStyles) != len(dracula.Groups) {
+		t.Errorf("%d group styles, want %d", len(groupStyles), len(dracula.Groups))
+	}
+}
+
+func TestLightBackground(t *testing.T) {
+	t.Cleanup(func() {
+		darkBackground = true
+		applyTheme(theme.Default)
+	})
+	darkBackground = false
+	applyTheme(theme.Default)
+	if currentTheme.Background != theme.Default.Light.Background {
+		t.Errorf("background %q on a light terminal", currentTheme.Background)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const tipsFile = "tips.json"
+
+var tipStyle = lipgloss.NewStyle().Italic(true)
+
+// A tip points out a feature when it becomes relevant, until the user
+// dismisses it for good.
+type tip struct {
+	id string
+	// show reports whether the tip is relevant in the current state.
+	show func(m *model) bool
+	text func(m *model) string
+}
+
+// tips are checked in order and the first relevant, unseen one is shown.
+var tips = []tip{
+	{
+		id: "compare",
+		show: func(m *model) bool {
+			return m.inputs[0].Value() != "" && m.inputs[1].Value() != "" && m.gen == 0
+		},
+		text: func(m *model) string {
+			return fmt.Sprintf("press %s to compare the two panes", m.keymap.compare.Help().Key)
+		},
+	},
+	{
+		id: "restore",
+		show: func(m *model) bool {
+			return m.focus < len(m.previous) && m.previous[m.focus] != nil
+		},
+		text: func(m *model) string {
+			return fmt.Sprintf("press %s to bring back what this pane held before", m.keymap.restore.Help().Key)
+		},
+	},
+	{
+		id: "transforms",
+		show: func(m *model) bool {
+			return m.focus < 2 && m.inputs[m.focus].Value() != ""
+		},
+		text: func(m *model) string {
+			return fmt.Sprintf("press %s for transforms; alt+enter there writes to the other pane", m.keymap.transform.Help().Key)
+		},
+	},
+	{
+		id: "export",
+		show: func(m *model) bool {
+			return !m.diff.Equal()
+		},
+		text: func(m *model) string {
+			return fmt.Sprintf("press %s to export the diff to a file", m.keymap.export.Help().Key)
+		},
+	},
+}
+
+// tipStore remembers which tips were dismissed.
+type tipStore struct {
+	Seen map[string]bool `json:"seen"`
+}
+
+func loadTips() (*tipStore, error) {
+	s := &tipStore{Seen: map[string]bool{}}
+	err := loadState(tipsFile, s)
+	if s.Seen == nil {
+		s.Seen = map[string]bool{}
+	}
+	return s, err
+}
+
+// currentTip returns the first relevant tip that was not dismissed.
+func (m *model) currentTip() (tip, bool) {
+	for _, t := range tips {
+		if !m.tips.Seen[t.id] && t.show(m) {
+			return t, true
+		}
+	}
+	return tip{}, false
+}
+
+// dismissTip hides the current tip for good.
+func (m *model) dismissTip() {
+	t, ok := m.currentTip()
+	if !ok {
+		return
+	}
+	m.tips.Seen[t.id] = true
+	m.err = saveState(tipsFile, m.tips)
+}
+
+func (m *model) tipView() string {
+	t, ok := m.currentTip()
+	if !ok {
+		return ""
+	}
+	return tipStyle.Render(fmt.Sprintf("tip: %s (%s to dismiss)", t.text(m), m.keymap.dismissTip.Help().Key))
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCurrentTip(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(m *model)
+		want  string
+	}{
+		{"empty panes", func(m *model) {}, ""},
+		{"both panes filled", func(m *model) {
+			m.inputs[0].SetValue("a")
+			m.inputs[1].SetValue("b")
+		}, "compare"},
+		{"pane replaced", func(m *model) {
+			m.replacePane(0, "a")
+		}, "restore"},
+		{"focused pane filled", func(m *model) {
+			m.inputs[0].SetValue("a")
+		}, "transforms"},
+		{"compare dismissed", func(m *model) {
+			m.inputs[0].SetValue("a")
+			m.inputs[1].SetValue("b")
+			m.tips.Seen["compare"] = true
+		}, "transforms"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			tt.setup(&m)
+			got, _ := m.currentTip()
+			if got.id != tt.want {
+				t.Errorf("tip = %q, want %q", got.id, tt.want)
+			}
+		})
+	}
+}
+
+func TestDismissTipIsSaved(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	m := newModel()
+	m.inputs[0].SetValue("a")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlG})
+	if m.err != nil {
+		t.Fatal(m.err)
+	}
+	if tip, ok := m.currentTip(); ok {
+		t.Errorf("tip %q still shown after dismissing", tip.id)
+	}
+	saved, err := loadTips()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.Seen["transforms"] {
+		t.Errorf("saved tips = %v, want transforms seen", saved.Seen)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// titleCmd sets the terminal window title to the labels of the panes and
+// status, the outcome of the last comparison, if titles are switched on.
+func (m *model) titleCmd(status string) tea.Cmd {
+	if !m.title {
+		return nil
+	}
+	labels := m.paneLabels()
+	title := "strcli: " + labels[0] + " ↔ " + labels[1]
+	if status != "" {
+		title += " — " + status
+	}
+	return tea.SetWindowTitle(title)
+}
+
+// paneLabels names the input panes by the files loaded into them, or by
+// letter.
+func (s *session) paneLabels() [2]string {
+	labels := [2]string{"A", "B"}
+	for i, p := range s.paths[:2] {
+		if p != "" {
+			labels[i] = filepath.Base(p)
+		}
+	}
+	return labels
+}
+
+// diffStatus describes the outcome of the last comparison for the title.
+func (m *model) diffStatus() string {
+	if m.diff.Equal() {
+		return "identical"
+	}
+	return "differs"
+}
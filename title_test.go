@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTitleCmd(t *testing.T) {
+	tests := []struct {
+		name   string
+		title  bool
+		paths  []string
+		status string
+		want   string
+	}{
+		{"off", false, []string{"a.txt", "b.txt", ""}, "differs", ""},
+		{"panes", true, []string{"", "", ""}, "", "strcli: A ↔ B"},
+		{"files", true, []string{"/tmp/old/a.txt", "b.txt", ""}, "identical", "strcli: a.txt ↔ b.txt — identical"},
+		{"one file", true, []string{"", "b.txt", ""}, "does not match", "strcli: A ↔ b.txt — does not match"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.title = tt.title
+			m.paths = tt.paths
+			cmd := m.titleCmd(tt.status)
+			if cmd == nil {
+				if tt.want != "" {
+					t.Fatalf("no title, want %q", tt.want)
+				}
+				return
+			}
+			if got := fmt.Sprint(cmd()); got != tt.want {
+				t.Errorf("title %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/transform"
+)
+
+// transformMenu lists every transform. Choosing one applies it to the
+// focused pane; choosing it with the split modifier writes the output to the
+// other input pane instead, keeping the original for comparison.
+func transformMenu() *menu {
+	return newMenu("Transforms", transformItems())
+}
+
+// transformItems returns the entries of transformMenu.
+func transformItems() []menuItem {
+	var items []menuItem
+	for _, t := range transform.All() {
+		t := t
+		items = append(items, menuItem{
+			title: t.Name,
+			desc:  t.Description,
+			run: func(m *model, split bool) tea.Cmd {
+				if t.Arg == "" {
+					m.applyTransform(t, "", split)
+					return nil
+				}
+				m.overlay = newPrompt(fmt.Sprintf("%s: %s", t.Name, t.Arg), "", func(m *model, arg string) tea.Cmd {
+					m.applyTransform(t, arg, split)
+					return nil
+				})
+				return nil
+			},
+		})
+	}
+	return items
+}
+
+var errNoInputPane = errors.New("focus an input pane to transform it")
+
+// applyTransform runs t on the focused input pane and writes the output back
+// to it, or to the other input pane if split is set. The overwritten content
+// can be restored.
+func (m *model) applyTransform(t transform.Transform, arg string, split bool) {
+	if m.focus > 1 {
+		m.err = errNoInputPane
+		return
+	}
+	out, err := t.Apply(m.inputs[m.focus].Value(), arg)
+	if err != nil {
+		m.err = fmt.Errorf("%s: %w", t.Name, err)
+		return
+	}
+	m.stats.recordTransform(t.Name)
+	target := m.focus
+	if split {
+		target = 1 - m.focus
+	}
+	m.replacePane(target, out)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"strcli/pkg/transform"
+)
+
+// selectItem moves the selection of mn to the item called title.
+func selectItem(t *testing.T, mn *menu, title string) {
+	t.Helper()
+	for i, it := range mn.list.Items() {
+		if it.(menuItem).title == title {
+			mn.list.Select(i)
+			return
+		}
+	}
+	t.Fatalf("no menu item %q", title)
+}
+
+func TestTransformMenu(t *testing.T) {
+	tests := []struct {
+		name   string
+		choose tea.KeyMsg
+		want   [2]string
+	}{
+		{"apply to the focused pane", tea.KeyMsg{Type: tea.KeyEnter}, [2]string{"HELLO", "other"}},
+		{"write to the other pane", tea.KeyMsg{Type: tea.KeyEnter, Alt: true}, [2]string{"Hello", "HELLO"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m, _ = update(m, tea.WindowSizeMsg{Width: 80, Height: 40})
+			m.inputs[0].SetValue("Hello")
+			m.inputs[1].SetValue("other")
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyF10})
+			if m.overlay == nil {
+				t.Fatal("f10 did not open the menu")
+			}
+			selectItem(t, m.overlay.(*menu), "upper")
+			m, _ = update(m, tt.choose)
+			if m.overlay != nil {
+				t.Error("menu still open after choosing")
+			}
+			if got := [2]string{m.inputs[0].Value(), m.inputs[1].Value()}; got != tt.want {
+				t.Errorf("panes = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyTransformErrors(t *testing.T) {
+	failing := transform.Transform{Name: "fail", Apply: func(string, string) (string, error) {
+		return "", errors.New("bad input")
+	}}
+	upper, _ := transform.Lookup("upper")
+	tests := []struct {
+		name    string
+		t       transform.Transform
+		focus   int
+		wantErr string
+	}{
+		{"result pane focused", upper, 2, errNoInputPane.Error()},
+		{"transform fails", failing, 0, "fail: bad input"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.inputs[0].SetValue("keep")
+			m.focus = tt.focus
+			m.applyTransform(tt.t, "", false)
+			if m.err == nil || m.err.Error() != tt.wantErr {
+				t.Errorf("err = %v, want %q", m.err, tt.wantErr)
+			}
+			if got := m.inputs[0].Value(); got != "keep" {
+				t.Errorf("pane changed to %q", got)
+			}
+		})
+	}
+}
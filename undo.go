@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// maxUndo is how many earlier versions of a pane are kept.
+	maxUndo = 100
+	// undoPause is how long typing has to stop for the next edit to be
+	// undone separately; quicker edits are undone together.
+	undoPause = time.Second
+)
+
+// history holds the earlier and undone versions of an input pane's text.
+type history struct {
+	undo, redo []string
+	// last is when the latest version was kept.
+	last time.Time
+}
+
+// keep records text as the version of pane i before an edit. Typing
+// merges with the edit before it unless it paused for undoPause.
+func (m *model) keep(i int, text string, typing bool) {
+	h := &m.history[i]
+	now := time.Now()
+	if typing && len(h.undo) > 0 && now.Sub(h.last) < undoPause {
+		h.last = now
+		return
+	}
+	h.undo = append(h.undo, text)
+	if len(h.undo) > maxUndo {
+		h.undo = h.undo[len(h.undo)-maxUndo:]
+	}
+	h.redo = nil
+	h.last = now
+}
+
+// undo brings back the version of the focused pane before the last edit.
+func (m *model) undo() error {
+	if m.focus >= len(m.history) {
+		return errNoInputPane
+	}
+	h := &m.history[m.focus]
+	if len(h.undo) == 0 {
+		return errors.New("nothing to undo")
+	}
+	h.redo = append(h.redo, m.paneText(m.focus))
+	m.showVersion(&h.undo)
+	return nil
+}
+
+// redo brings back the version of the focused pane before the last undo.
+func (m *model) redo() error {
+	if m.focus >= len(m.history) {
+		return errNoInputPane
+	}
+	h := &m.history[m.focus]
+	if len(h.redo) == 0 {
+		return errors.New("nothing to redo")
+	}
+	h.undo = append(h.undo, m.paneText(m.focus))
+	m.showVersion(&h.redo)
+	return nil
+}
+
+// showVersion takes the last version off versions and shows it in the
+// focused pane, keeping the cursor on the same line.
+func (m *model) showVersion(versions *[]string) {
+	v := *versions
+	line := m.inputs[m.focus].Line()
+	m.setPane(m.focus, v[len(v)-1])
+	*versions = v[:len(v)-1]
+	m.moveCursor(m.focus, panePos{line: line})
+	m.history[m.focus].last = time.Time{}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	undoKey = tea.KeyMsg{Type: tea.KeyCtrlZ}
+	redoKey = tea.KeyMsg{Type: tea.KeyCtrlY}
+)
+
+// pause makes the next edit of pane i start a new version, as if typing
+// had stopped for undoPause.
+func pause(m *model, i int) {
+	m.history[i].last = time.Now().Add(-undoPause)
+}
+
+func TestUndo(t *testing.T) {
+	m := newModel()
+	m = typeText(m, "abc")
+	pause(&m, 0)
+	m = typeText(m, "def")
+
+	steps := []struct {
+		key  tea.KeyMsg
+		want string
+		err  string
+	}{
+		{undoKey, "abc", ""},
+		{undoKey, "", ""},
+		{undoKey, "", "nothing to undo"},
+		{redoKey, "abc", ""},
+		{redoKey, "abcdef", ""},
+		{redoKey, "abcdef", "nothing to redo"},
+	}
+	for n, s := range steps {
+		m, _ = update(m, s.key)
+		if got := m.inputs[0].Value(); got != s.want {
+			t.Errorf("step %d: %q, want %q", n+1, got, s.want)
+		}
+		if got := fmt.Sprint(m.err); s.err != "" && got != s.err || s.err == "" && m.err != nil {
+			t.Errorf("step %d: error %v, want %q", n+1, m.err, s.err)
+		}
+	}
+}
+
+func TestUndoClearsRedo(t *testing.T) {
+	m := newModel()
+	m = typeText(m, "ab")
+	m, _ = update(m, undoKey)
+	m = typeText(m, "x")
+	m, _ = update(m, redoKey)
+	if m.err == nil || m.inputs[0].Value() != "x" {
+		t.Errorf("redo after an edit: %q, %v", m.inputs[0].Value(), m.err)
+	}
+}
+
+func TestUndoReplace(t *testing.T) {
+	m := newModel()
+	m.eols[0] = "CRLF"
+	m.setPane(0, "one\r\ntwo")
+	m.replacePane(0, "three")
+	m, _ = update(m, undoKey)
+	if got := m.paneText(0); got != "one\r\ntwo" {
+		t.Errorf("undoing a replacement gives %q", got)
+	}
+}
+
+func TestUndoPanes(t *testing.T) {
+	m := newModel()
+	m = typeText(m, "a")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyTab})
+	m = typeText(m, "b")
+	m, _ = update(m, undoKey)
+	if m.inputs[0].Value() != "a" || m.inputs[1].Value() != "" {
+		t.Errorf("undo in B gives %q and %q", m.inputs[0].Value(), m.inputs[1].Value())
+	}
+	m.focus = len(m.inputs) - 1
+	m, _ = update(m, undoKey)
+	if m.err != errNoInputPane {
+		t.Errorf("undo in the result pane: %v", m.err)
+	}
+}
+
+func TestUndoLimit(t *testing.T) {
+	var m model
+	for n := 0; n < maxUndo+10; n++ {
+		m.keep(0, fmt.Sprint(n), false)
+	}
+	h := m.history[0]
+	if len(h.undo) != maxUndo || h.undo[0] != "10" {
+		t.Errorf("%d versions kept, the oldest %q", len(h.undo), h.undo[0])
+	}
+}
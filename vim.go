@@ -0,0 +1,319 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// visualStyle marks the visual selection; applyTheme colors it.
+var visualStyle = lipgloss.NewStyle()
+
+type vimMode int
+
+const (
+	vimNormal vimMode = iota
+	vimInsert
+	vimVisual
+)
+
+func (v vimMode) String() string {
+	switch v {
+	case vimInsert:
+		return "INSERT"
+	case vimVisual:
+		return "VISUAL"
+	default:
+		return "NORMAL"
+	}
+}
+
+// vim edits the input panes modally, the way vim does. In normal and
+// visual mode letters are commands; keys of the keymap, such as ctrl+r,
+// work in every mode, except that esc returns to normal mode rather than
+// quitting.
+type vim struct {
+	mode vimMode
+	// pending is the first key of a two-key command such as dd or gg.
+	pending string
+	// anchor is where the visual selection started.
+	anchor panePos
+}
+
+// vimMotions are the commands that move the cursor, as the keys that move
+// it in the text areas.
+var vimMotions = map[string]tea.KeyMsg{
+	"h":         {Type: tea.KeyLeft},
+	"backspace": {Type: tea.KeyLeft},
+	"l":         {Type: tea.KeyRight},
+	" ":         {Type: tea.KeyRight},
+	"j":         {Type: tea.KeyDown},
+	"enter":     {Type: tea.KeyDown},
+	"k":         {Type: tea.KeyUp},
+	"0":         {Type: tea.KeyHome},
+	"$":         {Type: tea.KeyEnd},
+	"gg":        {Type: tea.KeyCtrlHome},
+	"G":         {Type: tea.KeyCtrlEnd},
+}
+
+// update handles a key for the focused input pane and reports whether it
+// was a command; other keys are handled as without vim.
+func (v *vim) update(m *model, msg tea.KeyMsg) (bool, tea.Cmd) {
+	if m.focus > 1 {
+		return false, nil
+	}
+	s := msg.String()
+	if v.mode == vimInsert {
+		if s == "esc" {
+			v.mode = vimNormal
+			return true, nil
+		}
+		return false, nil
+	}
+	if s == "esc" {
+		v.mode, v.pending = vimNormal, ""
+		return true, nil
+	}
+	_, motion := vimMotions[s]
+	if !motion && (msg.Type != tea.KeyRunes || msg.Alt) {
+		v.pending = ""
+		return false, nil
+	}
+
+	i := m.focus
+	cmd := v.pending + s
+	v.pending = ""
+	if k, ok := vimMotions[cmd]; ok {
+		m.inputs[i], _ = m.inputs[i].Update(k)
+		return true, nil
+	}
+	if v.mode == vimVisual {
+		return true, v.visual(m, cmd)
+	}
+	switch cmd {
+	case "w", "b", "e":
+		m.moveCursor(i, m.wordMotion(i, cmd))
+	case "g", "d", "y":
+		v.pending = cmd
+	case "i":
+		v.mode = vimInsert
+	case "a":
+		m.inputs[i], _ = m.inputs[i].Update(vimMotions["l"])
+		v.mode = vimInsert
+	case "A":
+		m.inputs[i].CursorEnd()
+		v.mode = vimInsert
+	case "I":
+		m.inputs[i].CursorStart()
+		v.mode = vimInsert
+	case "o", "O":
+		lines := strings.Split(m.inputs[i].Value(), "\n")
+		at := m.inputs[i].Line()
+		if cmd == "o" {
+			at++
+		}
+		lines = append(lines[:at], append([]string{""}, lines[at:]...)...)
+		m.editPane(i, strings.Join(lines, "\n"), panePos{line: at})
+		v.mode = vimInsert
+	case "x":
+		if cur := m.cursorPos(i); m.rangeText(i, cur, cur) != "\n" {
+			m.deleteRange(i, cur, cur)
+		}
+	case "dd":
+		lines := strings.Split(m.inputs[i].Value(), "\n")
+		at := m.inputs[i].Line()
+		// Like vim, deleting keeps the text to put back with p, if the
+		// clipboard can be written.
+		copyText(lines[at] + "\n")
+		lines = append(lines[:at], lines[at+1:]...)
+		m.editPane(i, strings.Join(lines, "\n"), panePos{line: max(min(at, len(lines)-1), 0)})
+	case "yy":
+		lines := strings.Split(m.inputs[i].Value(), "\n")
+		if m.err = copyText(lines[m.inputs[i].Line()] + "\n"); m.err == nil {
+			m.notice = "copied line"
+		}
+	case "p":
+		return true, m.paste()
+	case "u":
+		m.err = m.undo()
+	case "v":
+		v.mode = vimVisual
+		v.anchor = m.cursorPos(i)
+	case "/":
+		m.search = newSearch()
+		return true, textinput.Blink
+	case "n":
+		m.jumpToMatch(1)
+	case "N":
+		m.jumpToMatch(-1)
+	}
+	return true, nil
+}
+
+// visual handles a command in visual mode, which selects the text between
+// the anchor and the cursor.
+func (v *vim) visual(m *model, cmd string) tea.Cmd {
+	i := m.focus
+	from, to := v.selection(m)
+	switch cmd {
+	case "v":
+		v.mode = vimNormal
+	case "y":
+		if m.err = copyText(m.rangeText(i, from, to)); m.err == nil {
+			m.notice = "copied selection"
+		}
+		m.moveCursor(i, from)
+		v.mode = vimNormal
+	case "d", "x":
+		copyText(m.rangeText(i, from, to))
+		m.deleteRange(i, from, to)
+		v.mode = vimNormal
+	}
+	return nil
+}
+
+// selection returns the ends of the visual selection in order.
+func (v *vim) selection(m *model) (panePos, panePos) {
+	from, to := v.anchor, m.cursorPos(m.focus)
+	if to.line < from.line || to.line == from.line && to.col < from.col {
+		from, to = to, from
+	}
+	return from, to
+}
+
+// selected reports whether column col of line n in the focused pane is in
+// the visual selection.
+func (v *vim) selected(m *model, n, col int) bool {
+	from, to := v.selection(m)
+	return (n > from.line || n == from.line && col >= from.col) &&
+		(n < to.line || n == to.line && col <= to.col)
+}
+
+// selectionView draws the focused pane with the visual selection marked.
+func (v *vim) selectionView(m *model) string {
+	return m.linesViewAt(m.focus, func(n int, line string) string {
+		var b strings.Builder
+		runes := []rune(line)
+		for col, r := range runes {
+			if v.selected(m, n, col) {
+				b.WriteString(visualStyle.Render(string(r)))
+			} else {
+				b.WriteRune(r)
+			}
+		}
+		if v.selected(m, n, len(runes)) {
+			b.WriteString(visualStyle.Render(" "))
+		}
+		return b.String()
+	})
+}
+
+func (v *vim) view() string {
+	if v.pending != "" {
+		return fmt.Sprintf("%s %s", v.mode, v.pending)
+	}
+	return v.mode.String()
+}
+
+// cursorPos returns where the cursor of pane i is.
+func (m *model) cursorPos(i int) panePos {
+	t := &m.inputs[i]
+	li := t.LineInfo()
+	return panePos{line: t.Line(), col: li.StartColumn + li.ColumnOffset}
+}
+
+// editPane replaces the text of input pane i by an edit that can be undone,
+// and puts the cursor at p.
+func (m *model) editPane(i int, text string, p panePos) {
+	m.keep(i, m.paneText(i), false)
+	m.setPane(i, m.withLineEndings(i, text))
+	m.moveCursor(i, p)
+}
+
+// rangeText returns the text of pane i from from through to, both
+// included.
+func (m *model) rangeText(i int, from, to panePos) string {
+	runes := []rune(m.inputs[i].Value())
+	start, end := runeOffset(runes, from), runeOffset(runes, to)
+	return string(runes[start:min(end+1, len(runes))])
+}
+
+// deleteRange deletes the text of pane i from from through to, both
+// included.
+func (m *model) deleteRange(i int, from, to panePos) {
+	runes := []rune(m.inputs[i].Value())
+	start, end := runeOffset(runes, from), runeOffset(runes, to)
+	if start >= len(runes) {
+		return
+	}
+	text := string(runes[:start]) + string(runes[min(end+1, len(runes)):])
+	m.editPane(i, text, from)
+}
+
+// wordMotion returns where the cursor of pane i goes for w, to the start
+// of the next word, b, to the start of the word before, or e, to the end of
+// the word. Words are separated by whitespace.
+func (m *model) wordMotion(i int, cmd string) panePos {
+	text := []rune(m.inputs[i].Value())
+	off := runeOffset(text, m.cursorPos(i))
+	space := func(at int) bool { return unicode.IsSpace(text[at]) }
+	switch cmd {
+	case "w":
+		for off < len(text) && !space(off) {
+			off++
+		}
+		for off < len(text) && space(off) {
+			off++
+		}
+	case "b":
+		for off > 0 && space(off-1) {
+			off--
+		}
+		for off > 0 && !space(off-1) {
+			off--
+		}
+	case "e":
+		off++
+		for off < len(text) && space(off) {
+			off++
+		}
+		for off+1 < len(text) && !space(off+1) {
+			off++
+		}
+	}
+	return panePosAt(text, min(off, len(text)))
+}
+
+// panePosAt returns the position of the rune at index off in text.
+func panePosAt(text []rune, off int) panePos {
+	var p panePos
+	for _, r := range text[:off] {
+		if r == '\n' {
+			p.line++
+			p.col = 0
+		} else {
+			p.col++
+		}
+	}
+	return p
+}
+
+// runeOffset returns the index in text of the rune at p, or of the line
+// break ending its line if p is past the end of the line.
+func runeOffset(text []rune, p panePos) int {
+	off, line := 0, 0
+	for off < len(text) && line < p.line {
+		if text[off] == '\n' {
+			line++
+		}
+		off++
+	}
+	for col := 0; col < p.col && off < len(text) && text[off] != '\n'; col++ {
+		off++
+	}
+	return off
+}
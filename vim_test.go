@@ -0,0 +1,122 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// vimKeys presses keys, each a rune but for "⎋", which stands for esc.
+func vimKeys(m model, keys string) model {
+	for _, r := range keys {
+		k := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+		if r == '⎋' {
+			k = tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		m, _ = update(m, k)
+	}
+	return m
+}
+
+func TestVim(t *testing.T) {
+	tests := []struct {
+		name, text string
+		at         panePos
+		keys       string
+		want       string
+		cursor     panePos
+		mode       vimMode
+	}{
+		{"letters are commands", "abc", panePos{}, "zq", "abc", panePos{}, vimNormal},
+		{"h and l", "abc", panePos{}, "llh", "abc", panePos{col: 1}, vimNormal},
+		{"j and k", "one\ntwo\nthree", panePos{}, "jjk", "one\ntwo\nthree", panePos{line: 1}, vimNormal},
+		{"0 and $", "abc", panePos{col: 1}, "$", "abc", panePos{col: 3}, vimNormal},
+		{"gg", "one\ntwo", panePos{line: 1, col: 2}, "gg", "one\ntwo", panePos{}, vimNormal},
+		{"G", "one\ntwo", panePos{}, "G", "one\ntwo", panePos{line: 1, col: 3}, vimNormal},
+		{"w", "foo bar  baz", panePos{}, "ww", "foo bar  baz", panePos{col: 9}, vimNormal},
+		{"w across lines", "foo\nbar", panePos{}, "w", "foo\nbar", panePos{line: 1}, vimNormal},
+		{"b", "foo bar baz", panePos{col: 9}, "b", "foo bar baz", panePos{col: 8}, vimNormal},
+		{"b from the start of a word", "foo bar baz", panePos{col: 8}, "b", "foo bar baz", panePos{col: 4}, vimNormal},
+		{"e", "foo bar", panePos{}, "ee", "foo bar", panePos{col: 6}, vimNormal},
+		{"x", "abc", panePos{col: 1}, "x", "ac", panePos{col: 1}, vimNormal},
+		{"x keeps line breaks", "a\nb", panePos{col: 1}, "x", "a\nb", panePos{col: 1}, vimNormal},
+		{"dd", "one\ntwo\nthree", panePos{line: 1}, "dd", "one\nthree", panePos{line: 1}, vimNormal},
+		{"dd on the last line", "one\ntwo", panePos{line: 1}, "dd", "one", panePos{}, vimNormal},
+		{"o", "one\ntwo", panePos{}, "o", "one\n\ntwo", panePos{line: 1}, vimInsert},
+		{"O", "one\ntwo", panePos{}, "O", "\none\ntwo", panePos{}, vimInsert},
+		{"i", "abc", panePos{col: 1}, "iX⎋", "aXbc", panePos{col: 2}, vimNormal},
+		{"a", "abc", panePos{col: 1}, "aX", "abXc", panePos{col: 3}, vimInsert},
+		{"A", "abc", panePos{}, "A!", "abc!", panePos{col: 4}, vimInsert},
+		{"I", "abc", panePos{col: 2}, "I!", "!abc", panePos{col: 1}, vimInsert},
+		{"u", "abc", panePos{}, "xxu", "bc", panePos{}, vimNormal},
+		{"visual delete", "abcd", panePos{col: 1}, "vld", "ad", panePos{col: 1}, vimNormal},
+		{"visual backwards", "abcd", panePos{col: 2}, "vhx", "ad", panePos{col: 1}, vimNormal},
+		{"visual across lines", "ab\ncd", panePos{col: 1}, "vjd", "a", panePos{col: 1}, vimNormal},
+		{"visual esc", "abc", panePos{}, "vl⎋x", "ac", panePos{col: 1}, vimNormal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			m.vim = &vim{}
+			m.setPane(0, tt.text)
+			m.moveCursor(0, tt.at)
+			m = vimKeys(m, tt.keys)
+			if got := m.inputs[0].Value(); got != tt.want {
+				t.Errorf("text %q, want %q", got, tt.want)
+			}
+			if got := m.cursorPos(0); got != tt.cursor {
+				t.Errorf("cursor at %+v, want %+v", got, tt.cursor)
+			}
+			if m.vim.mode != tt.mode {
+				t.Errorf("mode %v, want %v", m.vim.mode, tt.mode)
+			}
+		})
+	}
+}
+
+func TestVimView(t *testing.T) {
+	m := newModel()
+	m.vim = &vim{}
+	m = vimKeys(m, "d")
+	if got := m.vim.view(); got != "NORMAL d" {
+		t.Errorf("pending command shown as %q", got)
+	}
+	m = vimKeys(m, "⎋i")
+	if got := m.vim.view(); got != "INSERT" {
+		t.Errorf("insert mode shown as %q", got)
+	}
+	if !m.inputs[0].Focused() {
+		t.Error("esc in normal mode quits")
+	}
+}
+
+func TestVimResultPane(t *testing.T) {
+	m := newModel()
+	m.vim = &vim{}
+	m.focus = 2
+	if done, _ := m.vim.update(&m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}); done {
+		t.Error("vim handles keys for the result pane")
+	}
+}
+
+func TestRuneOffset(t *testing.T) {
+	text := []rune("ab\ncde\n")
+	tests := []struct {
+		p    panePos
+		want int
+	}{
+		{panePos{}, 0},
+		{panePos{col: 1}, 1},
+		{panePos{col: 5}, 2},
+		{panePos{line: 1, col: 2}, 5},
+		{panePos{line: 2}, 7},
+	}
+	for _, tt := range tests {
+		if got := runeOffset(text, tt.p); got != tt.want {
+			t.Errorf("runeOffset(%+v) = %d, want %d", tt.p, got, tt.want)
+		}
+		if got := panePosAt(text, tt.want); tt.p.col < 5 && got != tt.p {
+			t.Errorf("panePosAt(%d) = %+v, want %+v", tt.want, got, tt.p)
+		}
+	}
+}
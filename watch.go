@@ -0,0 +1,172 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+	"strcli/pkg/compare"
+)
+
+func newWatchCmd() *cobra.Command {
+	var of optionFlags
+	cmd := &cobra.Command{
+		Use:   "watch A B",
+		Short: "Compare two files and re-compare whenever either changes",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := of.options()
+			if err != nil {
+				return err
+			}
+			texts, err := readInputs(args)
+			if err != nil {
+				return err
+			}
+			w, err := watchFiles(args)
+			if err != nil {
+				return err
+			}
+			defer w.close()
+
+			m := newModel()
+			m.setInputs(texts, args)
+			m.watch = w
+			m.options = opts
+			m.setDiff(compare.Compare(texts[0], texts[1], m.options))
+			return runTUI(cmd, m)
+		},
+	}
+	of.register(cmd, "both files")
+	return cmd
+}
+
+// watcher reports changes to the files loaded into the input panes.
+type watcher struct {
+	// paths are the watched files, as given.
+	paths []string
+	// changed receives the absolute path of a file each time it is written
+	// or replaced.
+	changed chan string
+	close   func() error
+}
+
+// watchFiles watches the files at paths. The parent directories are watched
+// rather than the files, so files replaced by an editor's atomic save keep
+// being followed.
+func watchFiles(paths []string) (*watcher, error) {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	abs := make([]string, len(paths))
+	for i, p := range paths {
+		if abs[i], err = filepath.Abs(p); err != nil {
+			fw.Close()
+			return nil, err
+		}
+		if err := fw.Add(filepath.Dir(abs[i])); err != nil {
+			fw.Close()
+			return nil, err
+		}
+	}
+
+	w := &watcher{paths: paths, changed: make(chan string), close: fw.Close}
+	go func() {
+		defer close(w.changed)
+		for {
+			select {
+			case ev, ok := <-fw.Events:
+				if !ok {
+					return
+				}
+				if !ev.Has(fsnotify.Write | fsnotify.Create | fsnotify.Rename) {
+					continue
+				}
+				for _, p := range abs {
+					if filepath.Clean(ev.Name) == p {
+						w.changed <- p
+					}
+				}
+			case _, ok := <-fw.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+	return w, nil
+}
+
+// fileChangedMsg reports that the file at the absolute path changed.
+type fileChangedMsg struct {
+	path string
+}
+
+// waitForChange waits for the next change reported by w.
+func (w *watcher) waitForChange() tea.Cmd {
+	return func() tea.Msg {
+		path, ok := <-w.changed
+		if !ok {
+			return nil
+		}
+		return fileChangedMsg{path: path}
+	}
+}
+
+// fileChanged reloads the input panes of the current tab that were loaded
+// from the file at the absolute path. Those of other tabs are marked stale
+// and reloaded when their tab is shown again, so the files follow the tab
+// they were loaded into wherever it moves.
+func (m *model) fileChanged(path string) tea.Cmd {
+	var cmds []tea.Cmd
+	for t := range m.tabs {
+		s := &m.tabs[t]
+		if t == m.tab {
+			s = &m.session
+		}
+		for i, p := range s.paths[:2] {
+			if abs, err := filepath.Abs(p); p == "" || err != nil || abs != path {
+				continue
+			}
+			if t == m.tab {
+				cmds = append(cmds, m.reloadPane(i))
+			} else {
+				s.stale[i] = true
+			}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// reloadStale reloads the input panes whose file changed while their tab
+// was not shown.
+func (m *model) reloadStale() tea.Cmd {
+	var cmds []tea.Cmd
+	for i, stale := range m.stale {
+		if stale {
+			m.stale[i] = false
+			cmds = append(cmds, m.reloadPane(i))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// reloadPane reloads the file loaded into pane in the background.
+func (m *model) reloadPane(pane int) tea.Cmd {
+	path := m.paths[pane]
+	return m.startLoad(pane, func() (string, error) {
+		b, err := os.ReadFile(path)
+		return string(b), err
+	})
+}
+
+func (m *model) watchView() string {
+	if m.watch == nil {
+		return ""
+	}
+	return "watching " + strings.Join(m.watch.paths, ", ")
+}
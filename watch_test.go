@@ -0,0 +1,122 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchFiles(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "a"), filepath.Join(dir, "b")}
+	for _, p := range paths {
+		os.WriteFile(p, []byte("old"), 0o644)
+	}
+	w, err := watchFiles(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.close()
+
+	tests := []struct {
+		name   string
+		change func() error
+		want   string
+	}{
+		{"write", func() error { return os.WriteFile(paths[1], []byte("new"), 0o644) }, paths[1]},
+		{"atomic save", func() error {
+			tmp := filepath.Join(dir, "a.swp")
+			if err := os.WriteFile(tmp, []byte("new"), 0o644); err != nil {
+				return err
+			}
+			return os.Rename(tmp, paths[0])
+		}, paths[0]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.change(); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case got := <-w.changed:
+				if got != tt.want {
+					t.Errorf("changed %q, want %q", got, tt.want)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("no change reported")
+			}
+			// Drain the events the same change may have caused.
+			for drained := false; !drained; {
+				select {
+				case <-w.changed:
+				case <-time.After(100 * time.Millisecond):
+					drained = true
+				}
+			}
+		})
+	}
+}
+
+func TestFileChangedReloads(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	os.WriteFile(a, []byte("same"), 0o644)
+	os.WriteFile(b, []byte("same"), 0o644)
+
+	m := newModel()
+	m.setInputs([]string{"same", "same"}, []string{a, b})
+	m.watch = &watcher{paths: []string{a, b}, changed: make(chan string), close: func() error { return nil }}
+	if got := m.watchView(); got != "watching "+a+", "+b {
+		t.Errorf("watch view = %q", got)
+	}
+
+	os.WriteFile(b, []byte("changed"), 0o644)
+	m = settle(m, fileChangedMsg{path: b})
+	if got := m.inputs[1].Value(); got != "changed" {
+		t.Fatalf("pane 2 = %q, want the new content", got)
+	}
+	if m.diff.Equal() {
+		t.Error("panes not compared again after the reload")
+	}
+}
+
+func TestFileChangedInOtherTab(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	os.WriteFile(a, []byte("a"), 0o644)
+	os.WriteFile(b, []byte("b"), 0o644)
+
+	m := newModel()
+	m.setInputs([]string{"a", "b"}, []string{a, b})
+	m.watch = &watcher{paths: []string{a, b}, changed: make(chan string), close: func() error { return nil }}
+	m = settle(m, newTabKey)
+	m.setPane(0, "other tab")
+
+	os.WriteFile(a, []byte("a changed"), 0o644)
+	m = settle(m, fileChangedMsg{path: a})
+	if got := m.inputs[0].Value(); got != "other tab" {
+		t.Fatalf("the shown tab was reloaded: %q", got)
+	}
+	if !m.tabs[0].stale[0] || m.tabs[0].stale[1] {
+		t.Errorf("stale panes %v", m.tabs[0].stale)
+	}
+	m = settle(m, prevTabKey)
+	if got := m.inputs[0].Value(); got != "a changed" {
+		t.Errorf("pane A = %q when its tab is shown again", got)
+	}
+	if m.stale != [2]bool{} {
+		t.Errorf("still stale: %v", m.stale)
+	}
+}
+
+func TestSetInputs(t *testing.T) {
+	m := newModel()
+	m.setInputs([]string{"from stdin", "from file"}, []string{"-", "b.txt"})
+	if m.paths[0] != "" || m.paths[1] != "b.txt" {
+		t.Errorf("paths = %q", m.paths)
+	}
+	if m.inputs[0].Value() != "from stdin" || m.inputs[1].Value() != "from file" {
+		t.Errorf("panes = %q, %q", m.inputs[0].Value(), m.inputs[1].Value())
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+	"strcli/pkg/diff"
+)
+
+// colorDiff renders d for the result pane, with whitespace made visible if
+// that is switched on.
+func (m *model) colorDiff(d diff.Diff) string {
+	if m.showWhitespace {
+		return diffStyles.ColorVisible(d)
+	}
+	return diffStyles.Color(d)
+}
+
+// visibleView draws input pane i with its whitespace and invisible
+// characters made visible.
+func (m *model) visibleView(i int) string {
+	return m.linesView(i, diffStyles.Visible)
+}
+
+// linesView draws input pane i at the size of the pane with each line
+// passed through show. Keys still go to the pane; the lines around the
+// cursor are shown.
+func (m *model) linesView(i int, show func(string) string) string {
+	return m.linesViewAt(i, func(_ int, line string) string { return show(line) })
+}
+
+// linesViewAt is like linesView, but show is also given the index of each
+// line.
+func (m *model) linesViewAt(i int, show func(n int, line string) string) string {
+	t := m.inputs[i]
+	pane := t.View()
+	style := t.BlurredStyle.Base
+	if t.Focused() {
+		style = t.FocusedStyle.Base
+	}
+	width := lipgloss.Width(pane) - style.GetHorizontalFrameSize()
+	height := lipgloss.Height(pane) - style.GetVerticalFrameSize()
+
+	lines := strings.Split(t.Value(), "\n")
+	start := max(t.Line()-height+1, 0)
+	end := min(start+height, len(lines))
+	var out []string
+	for n := start; n < end; n++ {
+		line := fmt.Sprintf("%3d ", n+1) + show(n, lines[n])
+		line = truncate.String(line, uint(width))
+		if n == t.Line() && t.Focused() {
+			line = cursorLineStyle.Render(line)
+		}
+		out = append(out, line)
+	}
+	return style.Copy().Width(width).Height(height).Render(strings.Join(out, "\n"))
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWhitespaceKey(t *testing.T) {
+	m := newModel()
+	m.width, m.height = 100, 30
+	m.sizeInputs()
+	m.inputs[0].SetValue("a b c ")
+	m.inputs[1].SetValue("a b")
+	m = settle(m, alt('w'))
+	v := m.View()
+	for _, want := range []string{"1 a·b·c·", "1 a⟨NBSP⟩b"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("shows\n%s\nwant %q in it", v, want)
+		}
+	}
+	if !strings.Contains(m.result, "⟨NBSP⟩") {
+		t.Errorf("result %q does not show the no-break space", m.result)
+	}
+	m = settle(m, alt('w'))
+	if v := m.View(); strings.Contains(v, "a·b") {
+		t.Errorf("still shows whitespace after switching off:\n%s", v)
+	}
+}
+
+func TestVisibleViewFollowsCursor(t *testing.T) {
+	m := newModel()
+	m.width, m.height = 100, 20
+	m.sizeInputs()
+	lines := make([]string, 50)
+	for i := range lines {
+		lines[i] = "line"
+	}
+	m.inputs[0].SetValue(strings.Join(lines, "\n"))
+	v := m.visibleView(0)
+	if !strings.Contains(v, " 50 line") || strings.Contains(v, "  1 line") {
+		t.Errorf("does not show the lines around the cursor at the end:\n%s", v)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// lineNumberWidth is the width of the line numbers drawn by unwrappedView,
+// as wide as those of the text areas.
+const lineNumberWidth = 3
+
+// offset is how far an unwrapped pane is scrolled: the first line shown
+// and the first cell of each line.
+type offset struct {
+	top, left int
+}
+
+// toggleWrap switches the focused input pane between wrapping long lines
+// and scrolling sideways to the cursor.
+func (m *model) toggleWrap() {
+	if m.focus > 1 {
+		m.err = errNoInputPane
+		return
+	}
+	if m.unwrapped[m.focus] != nil {
+		m.unwrapped[m.focus] = nil
+		m.notice = fmt.Sprintf("wrapping long lines in pane %c", 'A'+m.focus)
+		return
+	}
+	m.unwrapped[m.focus] = &offset{}
+	m.notice = fmt.Sprintf("scrolling long lines in pane %c", 'A'+m.focus)
+}
+
+// unwrappedView draws input pane i with long lines cut off at the edge of
+// the pane rather than wrapped, scrolling the pane to keep its cursor in
+// view.
+func (m *model) unwrappedView(i int) string {
+	t := m.inputs[i]
+	off := m.unwrapped[i]
+	style := t.BlurredStyle.Base
+	if t.Focused() {
+		style = t.FocusedStyle.Base
+	}
+	width := lipgloss.Width(t.View()) - style.GetHorizontalFrameSize()
+	textWidth := max(width-lineNumberWidth, 1)
+	height := t.Height()
+
+	lines := strings.Split(t.Value(), "\n")
+	line := t.Line()
+	runes := []rune(lines[line])
+	li := t.LineInfo()
+	col := min(li.StartColumn+li.ColumnOffset, len(runes))
+	cell := runewidth.StringWidth(string(runes[:col]))
+	off.top = min(max(off.top, line-height+1), line)
+	off.left = min(max(off.left, cell-textWidth+1), cell)
+
+	var out []string
+	for n := off.top; n < min(off.top+height, len(lines)); n++ {
+		number := fmt.Sprintf("%*d ", lineNumberWidth-1, n+1)
+		shown, start := cutCells([]rune(lines[n]), off.left, textWidth)
+		if n != line || !t.Focused() {
+			out = append(out, number+string(shown))
+			continue
+		}
+		at := col - start
+		cursor, after := " ", ""
+		if at < len(shown) {
+			cursor, after = string(shown[at]), string(shown[at+1:])
+		}
+		out = append(out, cursorLineStyle.Render(number+string(shown[:at]))+
+			cursorStyle.Copy().Reverse(true).Render(cursor)+
+			cursorLineStyle.Render(after))
+	}
+	return style.Copy().Width(width).Height(height).Render(strings.Join(out, "\n"))
+}
+
+// cutCells returns the runes of line that fit in width cells from cell
+// from, and the index in line of the first of them.
+func cutCells(line []rune, from, width int) ([]rune, int) {
+	start, cell := 0, 0
+	for start < len(line) && cell < from {
+		cell += runewidth.RuneWidth(line[start])
+		start++
+	}
+	end, used := start, 0
+	for end < len(line) && used+runewidth.RuneWidth(line[end]) <= width {
+		used += runewidth.RuneWidth(line[end])
+		end++
+	}
+	return line[start:end], start
+}
+
+// unwrappedPositionAt returns the position in the text of unwrapped input
+// pane i drawn at x, y, with r the area of the pane.
+func (m *model) unwrappedPositionAt(i, x, y int, r rect) (panePos, bool) {
+	t := m.inputs[i]
+	off := m.unwrapped[i]
+	frame := t.FocusedStyle.Base.GetBorderLeftSize()
+	row := y - r.y - frame
+	lines := strings.Split(t.Value(), "\n")
+	if row < 0 || row >= t.Height() || off.top+row >= len(lines) {
+		return panePos{}, false
+	}
+	line := []rune(lines[off.top+row])
+	cells := max(x-r.x-frame-lineNumberWidth, 0)
+	shown, start := cutCells(line, off.left, cells)
+	return panePos{line: off.top + row, col: start + len(shown)}, true
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCutCells(t *testing.T) {
+	tests := []struct {
+		line        string
+		from, width int
+		want        string
+		start       int
+	}{
+		{"abcdef", 0, 3, "abc", 0},
+		{"abcdef", 2, 3, "cde", 2},
+		{"abcdef", 4, 10, "ef", 4},
+		{"abc", 5, 3, "", 3},
+		{"日本語です", 0, 5, "日本", 0},
+		{"日本語です", 2, 4, "本語", 1},
+		// A wide character cut at the left edge is skipped whole.
+		{"日本語です", 1, 4, "本語", 1},
+	}
+	for _, tt := range tests {
+		got, start := cutCells([]rune(tt.line), tt.from, tt.width)
+		if string(got) != tt.want || start != tt.start {
+			t.Errorf("cutCells(%q, %d, %d) = %q, %d, want %q, %d", tt.line, tt.from, tt.width, string(got), start, tt.want, tt.start)
+		}
+	}
+}
+
+func TestWrapKey(t *testing.T) {
+	m := newModel()
+	m, _ = update(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+	long := strings.Repeat("abcdefghij", 20) + "END"
+	m.setPane(0, "short\n"+long)
+
+	m, _ = update(m, alt('e'))
+	if m.unwrapped[0] == nil || m.notice != "scrolling long lines in pane A" {
+		t.Fatalf("alt+e does not stop wrapping: %q", m.notice)
+	}
+	if !strings.Contains(m.statusView(), "A unwrapped") {
+		t.Error("the status bar does not say pane A is unwrapped")
+	}
+	v := m.unwrappedView(0)
+	if h := lipgloss.Height(v); h != lipgloss.Height(m.inputs[0].View()) {
+		t.Errorf("the unwrapped pane is %d high", h)
+	}
+	// The cursor is at the end of the long line, so the pane scrolls to it
+	// and cuts off the start of the line.
+	if !strings.Contains(v, "END") || strings.Contains(v, "2 abcdefghij") {
+		t.Errorf("the pane does not show the end of the line:\n%s", v)
+	}
+	if !strings.Contains(m.view(), "END") {
+		t.Error("the view does not draw the unwrapped pane")
+	}
+
+	m, _ = update(m, alt('e'))
+	if m.unwrapped[0] != nil || m.notice != "wrapping long lines in pane A" {
+		t.Errorf("alt+e again does not wrap: %q", m.notice)
+	}
+
+	m.focus = 2
+	m, _ = update(m, alt('e'))
+	if m.err != errNoInputPane {
+		t.Errorf("unwrapping the result pane: %v", m.err)
+	}
+}
+
+func TestUnwrappedClick(t *testing.T) {
+	m := newModel()
+	m, _ = update(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+	m.setPane(0, "one\ntwo\nthree")
+	m, _ = update(m, alt('e'))
+	m.view()
+	r := m.paneRects()[0]
+	// Rows start below the top border; columns after the border and the
+	// line number.
+	p, ok := m.positionAt(0, r.x+1+lineNumberWidth+2, r.y+2)
+	if !ok || p.line != 1 || p.col != 2 {
+		t.Errorf("clicked at %+v, %v, want line 1 column 2", p, ok)
+	}
+	if _, ok := m.positionAt(0, r.x+5, r.y+5); ok {
+		t.Error("a row past the text has a position")
+	}
+}